@@ -104,6 +104,8 @@ func setDefaultValue(v *viper.Viper) {
 	v.SetDefault("PLUGIN_DIR", "bin/plugins")
 	v.SetDefault("REMOTE_PLUGIN_DIR", "python/plugins")
 	v.SetDefault("SWAGGER_DOCS_DIR", "resources/swagger")
+	v.SetDefault("CANARY_ON_NEW_CONNECTIONS", true)
+	v.SetDefault("TOKEN_EXPIRY_WARNING_DAYS", 14)
 }
 
 func init() {
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+// ApiResponseCache holds a successful GET response, so re-running a pipeline within the TTL
+// replays it instead of requesting the remote server again
+type ApiResponseCache struct {
+	CacheKey   string    `gorm:"primaryKey;type:varchar(64)" json:"cacheKey"`
+	Url        string    `gorm:"type:text" json:"url"`
+	StatusCode int       `json:"statusCode"`
+	Header     []byte    `json:"header"`
+	Body       []byte    `json:"body"`
+	ExpiresAt  time.Time `gorm:"index" json:"expiresAt"`
+	CreatedAt  time.Time `json:"createdAt"`
+}
+
+func (ApiResponseCache) TableName() string {
+	return "_devlake_api_response_cache"
+}
@@ -76,6 +76,9 @@ type BlueprintScope struct {
 	PluginName   string `json:"-" gorm:"primaryKey;type:varchar(255)" validate:"required"`
 	ConnectionId uint64 `json:"-" gorm:"primaryKey" validate:"required"`
 	ScopeId      string `json:"scopeId" gorm:"primaryKey;type:varchar(255)" validate:"required"`
+	// ScopeConfigOverride is patched onto the scope config of the scope while the plan is made, it is only set to
+	// preview a change of the scope config and never saved
+	ScopeConfigOverride map[string]interface{} `json:"-" mapstructure:"-" gorm:"-"`
 }
 
 func (BlueprintScope) TableName() string {
@@ -87,4 +90,11 @@ type SyncPolicy struct {
 	FullSync       bool       `json:"fullSync"`
 	SkipCollectors bool       `json:"skipCollectors"`
 	TimeAfter      *time.Time `json:"timeAfter"`
+	// TaskTimeout and SubtaskTimeout are durations like "2h" or "30m", empty means no timeout
+	TaskTimeout    string `json:"taskTimeout" gorm:"type:varchar(32)"`
+	SubtaskTimeout string `json:"subtaskTimeout" gorm:"type:varchar(32)"`
+	// ProduceArtifacts dumps the rows collected by the pipeline into downloadable csv files once it finishes
+	ProduceArtifacts bool `json:"produceArtifacts"`
+	// MaxPages limits the pages the api collectors fetch for each input, 0 means unlimited, it is meant for canaries
+	MaxPages int `json:"maxPages"`
 }
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/core/models/common"
+
+// Canary is a small pipeline collecting a few pages of one scope of a connection, run to estimate the duration and
+// the api requests of the full collection before a blueprint is committed to. A connection has one canary
+type Canary struct {
+	common.Model
+	Plugin       string `json:"plugin" gorm:"type:varchar(100);uniqueIndex:idx_canaries_plugin_connection"`
+	ConnectionId uint64 `json:"connectionId" gorm:"uniqueIndex:idx_canaries_plugin_connection"`
+	ScopeId      string `json:"scopeId" gorm:"type:varchar(255)"`
+	PipelineId   uint64 `json:"pipelineId"`
+}
+
+func (Canary) TableName() string {
+	return "_devlake_canaries"
+}
+
+type ApiInputCanary struct {
+	Plugin       string `json:"plugin" validate:"required"`
+	ConnectionId uint64 `json:"connectionId" validate:"required"`
+	// ScopeId defaults to the first scope of the connection
+	ScopeId string `json:"scopeId"`
+}
@@ -34,6 +34,7 @@ type LatestSyncState struct {
 	RawDataTable       string     `json:"raw_data_table"`
 	RawDataParams      string     `json:"raw_data_params"`
 	LatestSuccessStart *time.Time `json:"latest_success_start"`
+	TimeAfter          *time.Time `json:"time_after"`
 }
 
 func (CollectorLatestState) TableName() string {
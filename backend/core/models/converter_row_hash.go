@@ -0,0 +1,31 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+// ConverterRowHash holds the hash of a domain row produced by a DataConverter with change detection enabled,
+// so unchanged rows can be skipped on the next conversion
+type ConverterRowHash struct {
+	DomainTable   string `gorm:"primaryKey;type:varchar(255)" json:"domainTable"`
+	RowId         string `gorm:"primaryKey;type:varchar(255)" json:"rowId"`
+	RawDataParams string `gorm:"type:varchar(255);index" json:"rawDataParams"`
+	Hash          string `gorm:"type:varchar(64)" json:"hash"`
+}
+
+func (ConverterRowHash) TableName() string {
+	return "_devlake_converter_row_hashes"
+}
@@ -0,0 +1,42 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueTraceabilityMetric is the weekly share of commits and pull requests of a repo that are linked to an issue,
+// the week is identified by its first day (Monday, UTC)
+type IssueTraceabilityMetric struct {
+	common.NoPKModel
+	RepoId                  string    `gorm:"primaryKey;type:varchar(255)"`
+	WeekStart               time.Time `gorm:"primaryKey"`
+	CommitsCount            int
+	LinkedCommitsCount      int
+	CommitLinkRate          float64
+	PullRequestsCount       int
+	LinkedPullRequestsCount int
+	PullRequestLinkRate     float64
+}
+
+func (IssueTraceabilityMetric) TableName() string {
+	return "issue_traceability_metrics"
+}
@@ -53,6 +53,8 @@ type PullRequest struct {
 	BaseRef        string `gorm:"type:varchar(255)"`
 	BaseCommitSha  string `gorm:"type:varchar(40)"`
 	HeadCommitSha  string `gorm:"type:varchar(40)"`
+	// IsDeleted is set by the reconciliation when the pull request no longer exists upstream
+	IsDeleted bool
 }
 
 func (PullRequest) TableName() string {
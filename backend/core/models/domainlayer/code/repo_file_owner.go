@@ -0,0 +1,36 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package code
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// RepoFileOwner is the owner of a file at the head of a repo according to its CODEOWNERS file, a file
+// may have several owners (users, teams or emails), Pattern is the CODEOWNERS rule the file matched
+type RepoFileOwner struct {
+	common.NoPKModel
+	RepoId   string `gorm:"primaryKey;type:varchar(255)"`
+	FilePath string `gorm:"primaryKey;type:varchar(255)"`
+	Owner    string `gorm:"primaryKey;type:varchar(255)"`
+	Pattern  string
+}
+
+func (RepoFileOwner) TableName() string {
+	return "repo_file_owners"
+}
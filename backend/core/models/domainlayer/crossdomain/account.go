@@ -31,6 +31,7 @@ type Account struct {
 	Organization string `gorm:"type:varchar(255)"`
 	CreatedDate  *time.Time
 	Status       int
+	IsBot        bool
 }
 
 func (Account) TableName() string {
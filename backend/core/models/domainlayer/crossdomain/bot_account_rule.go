@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"path"
+	"regexp"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// BotAccountRule overrides the bot detection for the accounts matching Pattern, a glob pattern (i.e. `svc-*`)
+// matched case-insensitively against the user name, full name and email of the account
+type BotAccountRule struct {
+	common.NoPKModel
+	Pattern string `gorm:"primaryKey;type:varchar(255)"`
+	IsBot   bool
+}
+
+func (BotAccountRule) TableName() string {
+	return "bot_account_rules"
+}
+
+// Validate rejects the malformed glob patterns, i.e. `svc-[`, which would never match any account
+func (rule *BotAccountRule) Validate() errors.Error {
+	if _, err := path.Match(strings.ToLower(rule.Pattern), ""); err != nil {
+		return errors.BadInput.Wrap(err, "invalid bot account rule pattern "+rule.Pattern)
+	}
+	return nil
+}
+
+// botAccountRegex matches the names commonly used by bots and CI users, i.e. `dependabot[bot]`, `renovate-bot`,
+// `github-actions` or `jenkins`
+var botAccountRegex = regexp.MustCompile(
+	`(?i)(\[bot\]|[-_. ]bot$|^bot[-_.]|^(dependabot|renovate|github-actions|greenkeeper|snyk|codecov|mergify|sonarcloud|jenkins|gitlab-ci|circleci)([-_. ]|$)|^ci([-_]|$)|[-_.]ci$)`,
+)
+
+// IsBotAccount tells whether the account belongs to a bot, the rules take precedence over the built-in heuristics
+// and when an account matches rules of both kinds, the ones flagging it as a human win
+func IsBotAccount(account *Account, rules []BotAccountRule) bool {
+	names := accountNames(account)
+	matched := false
+	for _, rule := range rules {
+		if !matchAny(strings.ToLower(rule.Pattern), names) {
+			continue
+		}
+		if !rule.IsBot {
+			return false
+		}
+		matched = true
+	}
+	if matched {
+		return true
+	}
+	for _, name := range names {
+		if botAccountRegex.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func accountNames(account *Account) []string {
+	var names []string
+	for _, name := range []string{account.UserName, account.FullName, account.Email} {
+		if name != "" {
+			names = append(names, strings.ToLower(name))
+		}
+	}
+	// the heuristics work on the local part of emails, i.e. `49699333+dependabot[bot]@users.noreply.github.com`
+	if i := strings.LastIndex(account.Email, "@"); i > 0 {
+		local := strings.ToLower(account.Email[:i])
+		if j := strings.Index(local, "+"); j >= 0 {
+			local = local[j+1:]
+		}
+		names = append(names, local)
+	}
+	return names
+}
+
+func matchAny(pattern string, names []string) bool {
+	for _, name := range names {
+		if ok, _ := path.Match(pattern, name); ok {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIsBotAccount(t *testing.T) {
+	for _, account := range []*Account{
+		{UserName: "dependabot[bot]"},
+		{UserName: "renovate-bot"},
+		{UserName: "github-actions"},
+		{FullName: "Jenkins"},
+		{UserName: "release-ci"},
+		{Email: "49699333+dependabot[bot]@users.noreply.github.com"},
+	} {
+		assert.True(t, IsBotAccount(account, nil), account)
+	}
+	for _, account := range []*Account{
+		{UserName: "abbot"},
+		{FullName: "Tom Jenkins"},
+		{UserName: "circe"},
+		{Email: "ci.lee@example.com"},
+		{},
+	} {
+		assert.False(t, IsBotAccount(account, nil), account)
+	}
+
+	rules := []BotAccountRule{
+		{Pattern: "svc-*", IsBot: true},
+		{Pattern: "*@build.example.com", IsBot: true},
+		{Pattern: "svc-oncall", IsBot: false},
+		{Pattern: "ci-jane", IsBot: false},
+	}
+	assert.True(t, IsBotAccount(&Account{UserName: "SVC-deploy"}, rules))
+	assert.True(t, IsBotAccount(&Account{UserName: "jane", Email: "jane@build.example.com"}, rules))
+	// allow rules win over both the deny rules and the heuristics
+	assert.False(t, IsBotAccount(&Account{UserName: "svc-oncall"}, rules))
+	assert.False(t, IsBotAccount(&Account{UserName: "ci-jane"}, rules))
+}
+
+func TestBotAccountRuleValidate(t *testing.T) {
+	assert.Nil(t, (&BotAccountRule{Pattern: "svc-*"}).Validate())
+	assert.Nil(t, (&BotAccountRule{Pattern: "svc-[a-z]"}).Validate())
+	assert.NotNil(t, (&BotAccountRule{Pattern: "svc-["}).Validate())
+}
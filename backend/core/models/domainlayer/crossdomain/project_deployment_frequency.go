@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+const (
+	DEPLOYMENT_FREQUENCY_WEEK  = "WEEK"
+	DEPLOYMENT_FREQUENCY_MONTH = "MONTH"
+)
+
+// ProjectDeploymentFrequency is the deployment frequency of a project over a calendar week (starting on Monday)
+// or month in UTC, DeployDays is the number of distinct days with at least one successful production deployment,
+// which is how Accelerate measures deployment frequency, while DeploymentsCount keeps the raw number
+type ProjectDeploymentFrequency struct {
+	common.NoPKModel
+	ProjectName      string    `gorm:"primaryKey;type:varchar(100)"`
+	PeriodType       string    `gorm:"primaryKey;type:varchar(20)"`
+	PeriodStart      time.Time `gorm:"primaryKey"`
+	DeploymentsCount int
+	DeployDays       int
+	DaysInPeriod     int
+	DeployDaysRate   float64
+}
+
+func (ProjectDeploymentFrequency) TableName() string {
+	return "project_deployment_frequencies"
+}
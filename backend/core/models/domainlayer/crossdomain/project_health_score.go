@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package crossdomain
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ProjectHealthScore is a daily snapshot of the weighted health score of a project, all scores range from 0 to 100,
+// a nil component score means there was no data to evaluate it and it is excluded from the weighted Score
+type ProjectHealthScore struct {
+	common.NoPKModel
+	ProjectName         string    `gorm:"primaryKey;type:varchar(100)"`
+	SnapshotDate        time.Time `gorm:"primaryKey"`
+	DoraScore           *float64
+	ReviewCoverageScore *float64
+	TestStabilityScore  *float64
+	IncidentLoadScore   *float64
+	Score               float64
+}
+
+func (ProjectHealthScore) TableName() string {
+	return "project_health_scores"
+}
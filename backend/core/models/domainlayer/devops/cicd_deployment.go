@@ -33,6 +33,10 @@ type CICDDeployment struct {
 	TaskDatesInfo
 	DurationSec       *float64
 	QueuedDurationSec *float64
+	// IsRollback marks a deployment that reverted the environment to an earlier version,
+	// RollbackOfDeploymentId points to the (failed) deployment it rolled back
+	IsRollback             bool
+	RollbackOfDeploymentId string `gorm:"type:varchar(255)"`
 }
 
 func (CICDDeployment) TableName() string {
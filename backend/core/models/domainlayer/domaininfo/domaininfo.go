@@ -23,6 +23,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/codequality"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 )
 
@@ -40,6 +41,9 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&code.PullRequestCommit{},
 		&code.PullRequestLabel{},
 		&code.Ref{},
+		&code.BranchLifetime{},
+		&code.BranchProtection{},
+		&code.IssueTraceabilityMetric{},
 		&code.CommitsDiff{},
 		&code.RefCommit{},
 		&code.RefsPrCherrypick{},
@@ -47,6 +51,7 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&code.RepoCommit{},
 		&code.RepoLanguage{},
 		&code.RepoSnapshot{},
+		&code.RepoFileOwner{},
 		// codequality
 		&codequality.CqFileMetrics{},
 		&codequality.CqIssueCodeBlock{},
@@ -55,9 +60,12 @@ func GetDomainTablesInfo() []dal.Tabler {
 		// crossdomain
 		&crossdomain.Account{},
 		&crossdomain.BoardRepo{},
+		&crossdomain.BotAccountRule{},
 		&crossdomain.IssueCommit{},
 		&crossdomain.IssueRepoCommit{},
 		&crossdomain.ProjectMapping{},
+		&crossdomain.ProjectDeploymentFrequency{},
+		&crossdomain.ProjectHealthScore{},
 		&crossdomain.ProjectIssueMetric{},
 		&crossdomain.ProjectPrMetric{},
 		&crossdomain.PullRequestIssue{},
@@ -73,7 +81,11 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&devops.CiCDPipelineCommit{},
 		&devops.CicdScope{},
 		&devops.CICDDeployment{},
+		&devops.CICDPipelineRelationship{},
 		// didgen no table
+		// security
+		&security.Vulnerability{},
+		&security.VulnerabilityStatusChange{},
 		// ticket
 		&ticket.Board{},
 		&ticket.BoardIssue{},
@@ -87,6 +99,8 @@ func GetDomainTablesInfo() []dal.Tabler {
 		&ticket.SprintIssue{},
 		&ticket.IssueAssignee{},
 		&ticket.IssueRelationship{},
+		&ticket.IncidentTimelineEvent{},
+		&ticket.IssueReopen{},
 		&ticket.IssueCustomArrayField{},
 	}
 }
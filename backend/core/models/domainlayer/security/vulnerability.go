@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+)
+
+// Vulnerability is a security finding raised against a scope (e.g. a repo) by a scanner such as
+// Dependabot, the status and dates make open/closed trends and the time to remediate queryable.
+// FilePath is the manifest or source file the finding points to, it can be joined with
+// repo_file_owners to attribute the finding to a team
+type Vulnerability struct {
+	domainlayer.DomainEntity
+	ScopeId         string `gorm:"index;type:varchar(255)"`
+	Source          string `gorm:"type:varchar(100)"`
+	Title           string
+	Severity        string `gorm:"type:varchar(100)"`
+	Status          string `gorm:"type:varchar(100)"`
+	OriginalStatus  string `gorm:"type:varchar(100)"`
+	Identifier      string `gorm:"type:varchar(255)"`
+	Package         string `gorm:"type:varchar(255)"`
+	Ecosystem       string `gorm:"type:varchar(100)"`
+	FilePath        string
+	Url             string
+	CreatedDate     time.Time
+	ResolvedDate    *time.Time
+	LeadTimeMinutes *uint
+}
+
+func (Vulnerability) TableName() string {
+	return "security_vulnerabilities"
+}
+
+const (
+	// sources
+	DEPENDABOT      = "DEPENDABOT"
+	CODE_SCANNING   = "CODE_SCANNING"
+	SECRET_SCANNING = "SECRET_SCANNING"
+
+	// statuses
+	OPEN      = "OPEN"
+	FIXED     = "FIXED"
+	DISMISSED = "DISMISSED"
+
+	// severities
+	CRITICAL = "CRITICAL"
+	HIGH     = "HIGH"
+	MEDIUM   = "MEDIUM"
+	LOW      = "LOW"
+)
@@ -0,0 +1,38 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package security
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// VulnerabilityStatusChange records when a vulnerability entered a status, e.g. when it was opened and
+// when it was fixed or dismissed, OriginalStatus is the status reported by the scanner
+type VulnerabilityStatusChange struct {
+	common.NoPKModel
+	VulnerabilityId string `gorm:"primaryKey;type:varchar(255)"`
+	OriginalStatus  string `gorm:"primaryKey;type:varchar(100)"`
+	Status          string `gorm:"type:varchar(100)"`
+	ChangedDate     time.Time
+}
+
+func (VulnerabilityStatusChange) TableName() string {
+	return "security_vulnerability_status_changes"
+}
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// milestones of an incident, the durations between them are the MTTx metrics,
+// i.e. MTTA is the mean of ACKNOWLEDGED - DETECTED
+const (
+	INCIDENT_DETECTED             = "DETECTED"
+	INCIDENT_ACKNOWLEDGED         = "ACKNOWLEDGED"
+	INCIDENT_MITIGATED            = "MITIGATED"
+	INCIDENT_RESOLVED             = "RESOLVED"
+	INCIDENT_POSTMORTEM_PUBLISHED = "POSTMORTEM_PUBLISHED"
+)
+
+// IncidentTimelineEvent records when an incident (an issue of type INCIDENT) reached a milestone
+type IncidentTimelineEvent struct {
+	common.NoPKModel
+	IncidentId    string    `gorm:"primaryKey;type:varchar(255)"`
+	EventType     string    `gorm:"primaryKey;type:varchar(100)"`
+	EventDate     time.Time `gorm:"primaryKey"`
+	OriginalEvent string    `gorm:"type:varchar(255)"`
+	ActorId       string    `gorm:"type:varchar(255)"`
+	ActorName     string    `gorm:"type:varchar(255)"`
+}
+
+func (IncidentTimelineEvent) TableName() string {
+	return "incident_timeline_events"
+}
+
+// IncidentMilestone is a milestone of an incident as reported by the source, the date is nil if it wasn't reached
+type IncidentMilestone struct {
+	EventType     string
+	EventDate     *time.Time
+	OriginalEvent string
+	ActorId       string
+	ActorName     string
+}
+
+// NewIncidentTimelineEvents returns the timeline events of the milestones reached by the incident
+func NewIncidentTimelineEvents(incidentId string, milestones ...IncidentMilestone) []interface{} {
+	events := make([]interface{}, 0, len(milestones))
+	for _, milestone := range milestones {
+		if milestone.EventDate == nil || milestone.EventDate.IsZero() {
+			continue
+		}
+		events = append(events, &IncidentTimelineEvent{
+			IncidentId:    incidentId,
+			EventType:     milestone.EventType,
+			EventDate:     *milestone.EventDate,
+			OriginalEvent: milestone.OriginalEvent,
+			ActorId:       milestone.ActorId,
+			ActorName:     milestone.ActorName,
+		})
+	}
+	return events
+}
@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ticket
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// IssueReopen records an issue being moved back from DONE to an unresolved status, ReopenSeq tells which reopen
+// of the issue it is (starting at 1) and TimeToReopenMinutes is the time since the issue was last resolved.
+// IsReintroduced flags the BUGs reopened after a successful production deployment of the project shipped their fix
+type IssueReopen struct {
+	common.NoPKModel
+	IssueId             string    `gorm:"primaryKey;type:varchar(255)"`
+	ReopenedDate        time.Time `gorm:"primaryKey"`
+	IssueType           string    `gorm:"type:varchar(100)"`
+	ReopenSeq           int
+	ResolvedDate        *time.Time
+	TimeToReopenMinutes *int64
+	ReopenedById        string `gorm:"type:varchar(255)"`
+	ReopenedByName      string `gorm:"type:varchar(255)"`
+	FromStatus          string `gorm:"type:varchar(255)"`
+	ToStatus            string `gorm:"type:varchar(255)"`
+	IsReintroduced      bool
+}
+
+func (IssueReopen) TableName() string {
+	return "issue_reopens"
+}
@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// MaintenanceWindow is a period during which scheduled blueprints are deferred until the window ends and queued
+// pipelines are held, it either recurs (CronConfig and Duration) or happens once (StartAt and EndAt)
+type MaintenanceWindow struct {
+	common.Model
+	Name       string     `json:"name" gorm:"type:varchar(255)"`
+	CronConfig string     `json:"cronConfig" gorm:"type:varchar(255)"`
+	Duration   string     `json:"duration" gorm:"type:varchar(32)"` // like "2h" or "30m"
+	StartAt    *time.Time `json:"startAt"`
+	EndAt      *time.Time `json:"endAt"`
+}
+
+func (MaintenanceWindow) TableName() string {
+	return "_devlake_maintenance_windows"
+}
+
+type ApiInputMaintenanceWindow struct {
+	Name       string     `json:"name" validate:"required"`
+	CronConfig string     `json:"cronConfig"`
+	Duration   string     `json:"duration"`
+	StartAt    *time.Time `json:"startAt"`
+	EndAt      *time.Time `json:"endAt"`
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBotAccounts)(nil)
+
+type account20231220 struct {
+	IsBot bool
+}
+
+func (account20231220) TableName() string {
+	return "accounts"
+}
+
+type botAccountRule20231220 struct {
+	archived.NoPKModel
+	Pattern string `gorm:"primaryKey;type:varchar(255)"`
+	IsBot   bool
+}
+
+func (botAccountRule20231220) TableName() string {
+	return "bot_account_rules"
+}
+
+type addBotAccounts struct{}
+
+func (*addBotAccounts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&account20231220{},
+		&botAccountRule20231220{},
+	)
+}
+
+func (*addBotAccounts) Version() uint64 {
+	return 20231220000012
+}
+
+func (*addBotAccounts) Name() string {
+	return "add is_bot to accounts and bot_account_rules table"
+}
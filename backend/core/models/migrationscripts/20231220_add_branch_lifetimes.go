@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBranchLifetimes)(nil)
+
+type addBranchLifetimes struct{}
+
+type branchLifetime20231220 struct {
+	archived.DomainEntity
+	RepoId          string `gorm:"index;type:varchar(255)"`
+	Name            string `gorm:"type:varchar(255)"`
+	BaseBranch      string `gorm:"type:varchar(255)"`
+	CommitSha       string `gorm:"type:varchar(40)"`
+	IsDefault       bool
+	IsProtected     bool
+	AheadBy         int
+	BehindBy        int
+	FirstCommitDate *time.Time
+	LastCommitDate  *time.Time
+	LifetimeMinutes *int
+	IsStale         bool
+}
+
+func (branchLifetime20231220) TableName() string {
+	return "branch_lifetimes"
+}
+
+func (*addBranchLifetimes) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&branchLifetime20231220{},
+	)
+}
+
+func (*addBranchLifetimes) Version() uint64 {
+	return 20231220000001
+}
+
+func (*addBranchLifetimes) Name() string {
+	return "add branch_lifetimes table"
+}
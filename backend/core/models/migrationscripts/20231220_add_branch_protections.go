@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBranchProtections)(nil)
+
+type addBranchProtections struct{}
+
+type branchProtection20231220 struct {
+	archived.DomainEntity
+	RepoId                 string `gorm:"index;type:varchar(255)"`
+	BranchPattern          string `gorm:"type:varchar(255)"`
+	IsDefaultBranch        bool
+	RequirePullRequest     bool
+	RequiredApprovals      int
+	RequireCodeOwnerReview bool
+	RequireStatusChecks    bool
+	RequiredStatusChecks   string
+	RequireSignedCommits   bool
+	AllowForcePushes       bool
+	AllowDeletions         bool
+	EnforceAdmins          bool
+}
+
+func (branchProtection20231220) TableName() string {
+	return "branch_protections"
+}
+
+func (*addBranchProtections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&branchProtection20231220{},
+	)
+}
+
+func (*addBranchProtections) Version() uint64 {
+	return 20231220000002
+}
+
+func (*addBranchProtections) Name() string {
+	return "add branch_protections table"
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addCanaries)(nil)
+
+type addMaxPagesToBlueprint struct {
+	MaxPages int
+}
+
+func (*addMaxPagesToBlueprint) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type addMaxPagesToPipeline struct {
+	MaxPages int
+}
+
+func (*addMaxPagesToPipeline) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type addApiRequestsToSubtask struct {
+	ApiRequests          int64
+	ProjectedApiRequests int64
+}
+
+func (*addApiRequestsToSubtask) TableName() string {
+	return "_devlake_subtasks"
+}
+
+type canary20231220 struct {
+	archived.Model
+	Plugin       string `gorm:"type:varchar(100);index"`
+	ConnectionId uint64 `gorm:"index"`
+	ScopeId      string `gorm:"type:varchar(255)"`
+	PipelineId   uint64
+}
+
+func (canary20231220) TableName() string {
+	return "_devlake_canaries"
+}
+
+type addCanaries struct{}
+
+func (*addCanaries) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&addMaxPagesToBlueprint{},
+		&addMaxPagesToPipeline{},
+		&addApiRequestsToSubtask{},
+		&canary20231220{},
+	)
+}
+
+func (*addCanaries) Version() uint64 {
+	return 20231220000016
+}
+
+func (*addCanaries) Name() string {
+	return "add max_pages to _devlake_blueprints and _devlake_pipelines, api requests to _devlake_subtasks, and add _devlake_canaries table"
+}
@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIncidentTimelineEvents)(nil)
+
+type addIncidentTimelineEvents struct{}
+
+type incidentTimelineEvent20231220 struct {
+	archived.NoPKModel
+	IncidentId    string    `gorm:"primaryKey;type:varchar(255)"`
+	EventType     string    `gorm:"primaryKey;type:varchar(100)"`
+	EventDate     time.Time `gorm:"primaryKey"`
+	OriginalEvent string    `gorm:"type:varchar(255)"`
+	ActorId       string    `gorm:"type:varchar(255)"`
+	ActorName     string    `gorm:"type:varchar(255)"`
+}
+
+func (incidentTimelineEvent20231220) TableName() string {
+	return "incident_timeline_events"
+}
+
+func (*addIncidentTimelineEvents) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&incidentTimelineEvent20231220{},
+	)
+}
+
+func (*addIncidentTimelineEvents) Version() uint64 {
+	return 20231220000004
+}
+
+func (*addIncidentTimelineEvents) Name() string {
+	return "add incident_timeline_events table"
+}
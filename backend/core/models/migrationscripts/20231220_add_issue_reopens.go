@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addIssueReopens)(nil)
+
+type addIssueReopens struct{}
+
+type issueReopen20231220 struct {
+	archived.NoPKModel
+	IssueId             string    `gorm:"primaryKey;type:varchar(255)"`
+	ReopenedDate        time.Time `gorm:"primaryKey"`
+	IssueType           string    `gorm:"type:varchar(100)"`
+	ReopenSeq           int
+	ResolvedDate        *time.Time
+	TimeToReopenMinutes *int64
+	ReopenedById        string `gorm:"type:varchar(255)"`
+	ReopenedByName      string `gorm:"type:varchar(255)"`
+	FromStatus          string `gorm:"type:varchar(255)"`
+	ToStatus            string `gorm:"type:varchar(255)"`
+}
+
+func (issueReopen20231220) TableName() string {
+	return "issue_reopens"
+}
+
+func (*addIssueReopens) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&issueReopen20231220{},
+	)
+}
+
+func (*addIssueReopens) Version() uint64 {
+	return 20231220000011
+}
+
+func (*addIssueReopens) Name() string {
+	return "add issue_reopens table"
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addPipelineArtifacts)(nil)
+
+type addProduceArtifactsToBlueprint struct {
+	ProduceArtifacts bool
+}
+
+func (*addProduceArtifactsToBlueprint) TableName() string {
+	return "_devlake_blueprints"
+}
+
+type addProduceArtifactsToPipeline struct {
+	ProduceArtifacts bool
+}
+
+func (*addProduceArtifactsToPipeline) TableName() string {
+	return "_devlake_pipelines"
+}
+
+type pipelineArtifact20231220 struct {
+	archived.Model
+	PipelineId    uint64 `gorm:"index"`
+	DataTable     string `gorm:"type:varchar(255)"`
+	RawDataParams string `gorm:"type:varchar(255)"`
+	Rows          int
+	Path          string `gorm:"type:varchar(255)"`
+}
+
+func (pipelineArtifact20231220) TableName() string {
+	return "_devlake_pipeline_artifacts"
+}
+
+type addPipelineArtifacts struct{}
+
+func (*addPipelineArtifacts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&addProduceArtifactsToBlueprint{},
+		&addProduceArtifactsToPipeline{},
+		&pipelineArtifact20231220{},
+	)
+}
+
+func (*addPipelineArtifacts) Version() uint64 {
+	return 20231220000014
+}
+
+func (*addPipelineArtifacts) Name() string {
+	return "add produce_artifacts to _devlake_blueprints and _devlake_pipelines, and add _devlake_pipeline_artifacts table"
+}
@@ -0,0 +1,62 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addProjectHealthScores)(nil)
+
+type addProjectHealthScores struct{}
+
+type projectHealthScore20231220 struct {
+	archived.NoPKModel
+	ProjectName         string    `gorm:"primaryKey;type:varchar(100)"`
+	SnapshotDate        time.Time `gorm:"primaryKey"`
+	DoraScore           *float64
+	ReviewCoverageScore *float64
+	TestStabilityScore  *float64
+	IncidentLoadScore   *float64
+	Score               float64
+}
+
+func (projectHealthScore20231220) TableName() string {
+	return "project_health_scores"
+}
+
+func (*addProjectHealthScores) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&projectHealthScore20231220{},
+	)
+}
+
+func (*addProjectHealthScores) Version() uint64 {
+	return 20231220000007
+}
+
+func (*addProjectHealthScores) Name() string {
+	return "add project_health_scores table"
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addRepoFileOwners)(nil)
+
+type addRepoFileOwners struct{}
+
+type repoFileOwner20231220 struct {
+	archived.NoPKModel
+	RepoId   string `gorm:"primaryKey;type:varchar(255)"`
+	FilePath string `gorm:"primaryKey;type:varchar(255)"`
+	Owner    string `gorm:"primaryKey;type:varchar(255)"`
+	Pattern  string
+}
+
+func (repoFileOwner20231220) TableName() string {
+	return "repo_file_owners"
+}
+
+func (*addRepoFileOwners) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&repoFileOwner20231220{},
+	)
+}
+
+func (*addRepoFileOwners) Version() uint64 {
+	return 20231220000009
+}
+
+func (*addRepoFileOwners) Name() string {
+	return "add repo_file_owners table"
+}
@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addReportSchedules)(nil)
+
+type addReportSchedules struct{}
+
+type reportSchedule20231220 struct {
+	archived.Model
+	ProjectName string `gorm:"type:varchar(255)"`
+	CronConfig  string `gorm:"type:varchar(255)"`
+	Recipients  string
+	Enable      bool
+	LastSentAt  *time.Time
+}
+
+func (reportSchedule20231220) TableName() string {
+	return "_devlake_report_schedules"
+}
+
+func (*addReportSchedules) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&reportSchedule20231220{},
+	)
+}
+
+func (*addReportSchedules) Version() uint64 {
+	return 20231220000008
+}
+
+func (*addReportSchedules) Name() string {
+	return "add _devlake_report_schedules table"
+}
@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addSecurityVulnerabilities)(nil)
+
+type securityVulnerability20231220 struct {
+	archived.DomainEntity
+	ScopeId         string `gorm:"index;type:varchar(255)"`
+	Source          string `gorm:"type:varchar(100)"`
+	Title           string
+	Severity        string `gorm:"type:varchar(100)"`
+	Status          string `gorm:"type:varchar(100)"`
+	OriginalStatus  string `gorm:"type:varchar(100)"`
+	Identifier      string `gorm:"type:varchar(255)"`
+	Package         string `gorm:"type:varchar(255)"`
+	Ecosystem       string `gorm:"type:varchar(100)"`
+	ManifestPath    string
+	Url             string
+	CreatedDate     time.Time
+	ResolvedDate    *time.Time
+	LeadTimeMinutes *uint
+}
+
+func (securityVulnerability20231220) TableName() string {
+	return "security_vulnerabilities"
+}
+
+type addSecurityVulnerabilities struct{}
+
+func (*addSecurityVulnerabilities) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&securityVulnerability20231220{},
+	)
+}
+
+func (*addSecurityVulnerabilities) Version() uint64 {
+	return 20231220000017
+}
+
+func (*addSecurityVulnerabilities) Name() string {
+	return "add security_vulnerabilities table"
+}
@@ -0,0 +1,64 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addVulnerabilityStatusChanges)(nil)
+
+type vulnerabilityStatusChange20231220 struct {
+	archived.NoPKModel
+	VulnerabilityId string `gorm:"primaryKey;type:varchar(255)"`
+	OriginalStatus  string `gorm:"primaryKey;type:varchar(100)"`
+	Status          string `gorm:"type:varchar(100)"`
+	ChangedDate     time.Time
+}
+
+func (vulnerabilityStatusChange20231220) TableName() string {
+	return "security_vulnerability_status_changes"
+}
+
+type addVulnerabilityStatusChanges struct{}
+
+func (*addVulnerabilityStatusChanges) Up(basicRes context.BasicRes) errors.Error {
+	// the path is not always a manifest, code scanning alerts point to source files
+	err := basicRes.GetDal().RenameColumn("security_vulnerabilities", "manifest_path", "file_path")
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&vulnerabilityStatusChange20231220{},
+	)
+}
+
+func (*addVulnerabilityStatusChanges) Version() uint64 {
+	return 20231220000018
+}
+
+func (*addVulnerabilityStatusChanges) Name() string {
+	return "rename security_vulnerabilities.manifest_path to file_path, add security_vulnerability_status_changes table"
+}
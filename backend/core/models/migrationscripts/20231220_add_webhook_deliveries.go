@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addWebhookDeliveries)(nil)
+
+type addWebhookDeliveries struct{}
+
+type webhookDelivery20231220 struct {
+	Plugin       string `gorm:"primaryKey;type:varchar(100)"`
+	ConnectionId uint64 `gorm:"primaryKey"`
+	DeliveryId   string `gorm:"primaryKey;type:varchar(255)"`
+	EventType    string `gorm:"type:varchar(100)"`
+	Payload      []byte
+	Status       string `gorm:"type:varchar(20);index"`
+	Message      string `gorm:"type:text"`
+	Attempts     int
+	ReceivedAt   time.Time
+	ProcessedAt  *time.Time
+}
+
+func (webhookDelivery20231220) TableName() string {
+	return "_devlake_webhook_deliveries"
+}
+
+func (*addWebhookDeliveries) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&webhookDelivery20231220{},
+	)
+}
+
+func (*addWebhookDeliveries) Version() uint64 {
+	return 20231220000013
+}
+
+func (*addWebhookDeliveries) Name() string {
+	return "add _devlake_webhook_deliveries table"
+}
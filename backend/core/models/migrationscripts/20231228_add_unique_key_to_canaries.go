@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addUniqueKeyToCanaries)(nil)
+
+type canary20231228 struct {
+	archived.Model
+	Plugin       string `gorm:"type:varchar(100);uniqueIndex:idx_canaries_plugin_connection"`
+	ConnectionId uint64 `gorm:"uniqueIndex:idx_canaries_plugin_connection"`
+	ScopeId      string `gorm:"type:varchar(255)"`
+	PipelineId   uint64
+}
+
+func (canary20231228) TableName() string {
+	return "_devlake_canaries"
+}
+
+type addUniqueKeyToCanaries struct{}
+
+func (*addUniqueKeyToCanaries) Up(basicRes context.BasicRes) errors.Error {
+	// keep the latest canary of each connection, the previous ones are duplicates created by concurrent calls
+	err := basicRes.GetDal().Exec(`
+DELETE FROM _devlake_canaries WHERE id NOT IN (
+	SELECT id FROM (SELECT MAX(id) AS id FROM _devlake_canaries GROUP BY plugin, connection_id) latest
+)`)
+	if err != nil {
+		return err
+	}
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&canary20231228{},
+	)
+}
+
+func (*addUniqueKeyToCanaries) Version() uint64 {
+	return 20231228000001
+}
+
+func (*addUniqueKeyToCanaries) Name() string {
+	return "add unique key on plugin and connection_id to _devlake_canaries"
+}
@@ -103,5 +103,30 @@ func All() []plugin.MigrationScript {
 		new(addCommitMsgtoDeploymentCommit),
 		new(modifyIssueOriginalTypeLength),
 		new(addCommitMsgtoPipelineCommit),
+		new(addPeakUsageToSubtasks),
+		new(addTimeoutsToSyncPolicy),
+		new(addApiResponseCache),
+		new(addIsDeletedToPullRequests),
+		new(addConverterRowHashes),
+		new(addBranchLifetimes),
+		new(addBranchProtections),
+		new(addCicdPipelineRelationships),
+		new(addIncidentTimelineEvents),
+		new(addRollbackToCicdDeployments),
+		new(addIssueTraceabilityMetrics),
+		new(addProjectHealthScores),
+		new(addReportSchedules),
+		new(addRepoFileOwners),
+		new(addProjectDeploymentFrequencies),
+		new(addIssueReopens),
+		new(addBotAccounts),
+		new(addWebhookDeliveries),
+		new(addPipelineArtifacts),
+		new(addMaintenanceWindows),
+		new(addCanaries),
+		new(addSecurityVulnerabilities),
+		new(addVulnerabilityStatusChanges),
+		new(addUniqueKeyToCanaries),
+		new(addIsReintroducedToIssueReopens),
 	}
 }
@@ -25,6 +25,7 @@ type NotificationType string
 
 const (
 	NotificationPipelineStatusChanged NotificationType = "PipelineStatusChanged"
+	NotificationTokenExpiring         NotificationType = "TokenExpiring"
 )
 
 // Notification records notifications sent by lake
@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import "github.com/apache/incubator-devlake/core/models/common"
+
+// PipelineArtifact is a csv file holding the rows of one table collected for one scope by a pipeline,
+// it is produced when the SyncPolicy.ProduceArtifacts of the pipeline is on
+type PipelineArtifact struct {
+	common.Model
+	PipelineId    uint64 `json:"pipelineId" gorm:"index"`
+	DataTable     string `json:"dataTable" gorm:"type:varchar(255)"`
+	RawDataParams string `json:"rawDataParams" gorm:"type:varchar(255)"`
+	Rows          int    `json:"rows"`
+	Path          string `json:"path" gorm:"type:varchar(255)"`
+}
+
+func (PipelineArtifact) TableName() string {
+	return "_devlake_pipeline_artifacts"
+}
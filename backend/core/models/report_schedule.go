@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// ReportSchedule emails a digest of the metric snapshots of a project to the recipients periodically
+type ReportSchedule struct {
+	common.Model
+	ProjectName string     `json:"projectName" gorm:"type:varchar(255)"`
+	CronConfig  string     `json:"cronConfig" gorm:"type:varchar(255)"`
+	Recipients  string     `json:"recipients"` // email addresses separated by `,`
+	Enable      bool       `json:"enable"`
+	LastSentAt  *time.Time `json:"lastSentAt"`
+}
+
+func (ReportSchedule) TableName() string {
+	return "_devlake_report_schedules"
+}
+
+type ApiInputReportSchedule struct {
+	ProjectName string `json:"projectName" validate:"required"`
+	// CronConfig defaults to every Monday 08:00 UTC
+	CronConfig string `json:"cronConfig"`
+	Recipients string `json:"recipients" validate:"required"`
+	Enable     *bool  `json:"enable"`
+}
@@ -79,12 +79,17 @@ func (Task) TableName() string {
 
 type Subtask struct {
 	common.Model
-	TaskID       uint64     `json:"task_id" gorm:"index"`
-	Name         string     `json:"name" gorm:"index"`
-	Number       int        `json:"number"`
-	BeganAt      *time.Time `json:"beganAt"`
-	FinishedAt   *time.Time `json:"finishedAt" gorm:"index"`
-	SpentSeconds int64      `json:"spentSeconds"`
+	TaskID         uint64     `json:"task_id" gorm:"index"`
+	Name           string     `json:"name" gorm:"index"`
+	Number         int        `json:"number"`
+	BeganAt        *time.Time `json:"beganAt"`
+	FinishedAt     *time.Time `json:"finishedAt" gorm:"index"`
+	SpentSeconds   int64      `json:"spentSeconds"`
+	PeakMemoryMb   uint64     `json:"peakMemoryMb"`
+	PeakGoroutines int        `json:"peakGoroutines"`
+	// ApiRequests made by the collectors of the subtask, and the ones they would make without SyncPolicy.MaxPages
+	ApiRequests          int64 `json:"apiRequests"`
+	ProjectedApiRequests int64 `json:"projectedApiRequests"`
 }
 
 func (Subtask) TableName() string {
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+)
+
+const (
+	WEBHOOK_DELIVERY_PROCESSING = "PROCESSING"
+	WEBHOOK_DELIVERY_DONE       = "DONE"
+	WEBHOOK_DELIVERY_FAILED     = "FAILED"
+	WEBHOOK_DELIVERY_IGNORED    = "IGNORED"
+)
+
+// WebhookDelivery records every payload received by the webhook endpoints of the plugins, a delivery received
+// twice is processed once, and a failed one keeps its payload to be replayed later
+type WebhookDelivery struct {
+	Plugin       string     `gorm:"primaryKey;type:varchar(100)" json:"plugin"`
+	ConnectionId uint64     `gorm:"primaryKey" json:"connectionId"`
+	DeliveryId   string     `gorm:"primaryKey;type:varchar(255)" json:"deliveryId"`
+	EventType    string     `gorm:"type:varchar(100)" json:"eventType"`
+	Payload      []byte     `json:"-"`
+	Status       string     `gorm:"type:varchar(20);index" json:"status"`
+	Message      string     `gorm:"type:text" json:"message"`
+	Attempts     int        `json:"attempts"`
+	ReceivedAt   time.Time  `json:"receivedAt"`
+	ProcessedAt  *time.Time `json:"processedAt"`
+}
+
+func (WebhookDelivery) TableName() string {
+	return "_devlake_webhook_deliveries"
+}
@@ -29,6 +29,7 @@ type ApiResourceInput struct {
 	Params  map[string]string      // path variables
 	Query   url.Values             // query string
 	Body    map[string]interface{} // json body
+	RawBody []byte                 // json body as received, i.e. to verify the signature of a webhook
 	Request *http.Request
 
 	User *common.User
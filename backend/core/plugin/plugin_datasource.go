@@ -22,6 +22,7 @@ import (
 	"fmt"
 
 	"net/http"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/dal"
 
@@ -60,17 +61,37 @@ type PrepareApiClient interface {
 	PrepareApiClient(apiClient ApiClient) errors.Error
 }
 
+// TokenExpiry tells when the token of a connection expires, a nil ExpiresAt means it never expires
+type TokenExpiry struct {
+	ConnectionId   uint64
+	ConnectionName string
+	ExpiresAt      *time.Time
+}
+
+// ExpiresWithin tells whether the token expires within the given days from now, expired tokens included
+func (e TokenExpiry) ExpiresWithin(now time.Time, days int) bool {
+	return e.ExpiresAt != nil && e.ExpiresAt.Before(now.AddDate(0, 0, days))
+}
+
+// PluginTokenExpiry is to be implemented by the plugins able to tell when the tokens of their connections
+// expire, so users could be warned before the collection starts failing
+type PluginTokenExpiry interface {
+	GetTokenExpiries() ([]TokenExpiry, errors.Error)
+}
+
 // MultiAuth
 const (
 	AUTH_METHOD_BASIC  = "BasicAuth"
 	AUTH_METHOD_TOKEN  = "AccessToken"
 	AUTH_METHOD_APPKEY = "AppKey"
+	AUTH_METHOD_OAUTH2 = "OAuth2"
 )
 
 var ALL_AUTH = map[string]bool{
 	AUTH_METHOD_BASIC:  true,
 	AUTH_METHOD_TOKEN:  true,
 	AUTH_METHOD_APPKEY: true,
+	AUTH_METHOD_OAUTH2: true,
 }
 
 // MultiAuthenticator represents the API Connection supports multiple authorization methods
@@ -93,6 +114,11 @@ type AppKeyAuthenticator interface {
 	GetAppKeyAuthenticator() ApiAuthenticator
 }
 
+// OAuth2Authenticator is to be implemented by the connections supporting OAuth 2.0 authorization
+type OAuth2Authenticator interface {
+	GetOAuth2Authenticator() ApiAuthenticator
+}
+
 // Scope represents the top level entity for a data source, i.e. github repo,
 // gitlab project, jira board. They turn into repo, board in Domain Layer. In
 // Apache Devlake, a Project is essentially a set of these top level entities,
@@ -19,6 +19,7 @@ package plugin
 
 import (
 	"context"
+	"sync/atomic"
 
 	corecontext "github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -59,12 +60,27 @@ type SubTaskContext interface {
 	TaskContext() TaskContext
 }
 
+// ApiCollectionStats counts the api requests made by the collectors of a subtask, ProjectedRequests are the ones
+// they would make without SyncPolicy.MaxPages, as far as the collectors know the total number of pages
+type ApiCollectionStats struct {
+	Requests          atomic.Int64
+	ProjectedRequests atomic.Int64
+}
+
+// ApiCollectionStatsHolder is implemented by the SubTaskContext which keeps the ApiCollectionStats of the subtask
+type ApiCollectionStatsHolder interface {
+	ApiCollectionStats() *ApiCollectionStats
+}
+
 // TaskContext This interface define all resources that needed for task execution
 type TaskContext interface {
 	ExecContext
 	SetData(data interface{})
 	SetSyncPolicy(syncPolicy *models.SyncPolicy)
 	SyncPolicy() *models.SyncPolicy
+	SetPipelineId(pipelineId uint64)
+	// PipelineId is the id of the pipeline running the task, 0 if the task is run directly
+	PipelineId() uint64
 	SubTaskContext(subtask string) (SubTaskContext, errors.Error)
 }
 
@@ -82,6 +98,7 @@ const DOMAIN_TYPE_CODE_REVIEW = "CODEREVIEW"   //nolint
 const DOMAIN_TYPE_CROSS = "CROSS"              //nolint
 const DOMAIN_TYPE_CICD = "CICD"                //nolint
 const DOMAIN_TYPE_CODE_QUALITY = "CODEQUALITY" //nolint
+const DOMAIN_TYPE_SECURITY = "SECURITY"        //nolint
 
 var DOMAIN_TYPES = []string{
 	DOMAIN_TYPE_CODE,
@@ -90,6 +107,7 @@ var DOMAIN_TYPES = []string{
 	DOMAIN_TYPE_CROSS,
 	DOMAIN_TYPE_CICD,
 	DOMAIN_TYPE_CODE_QUALITY,
+	DOMAIN_TYPE_SECURITY,
 } //nolint
 
 // SubTaskMeta Metadata of a subtask
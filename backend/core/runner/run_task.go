@@ -234,7 +234,26 @@ func RunPluginSubTasks(
 		}
 	}
 
+	budget, err := LoadSubtaskBudget(basicRes)
+	if err != nil {
+		return err
+	}
+	taskTimeout, subtaskTimeout, err := ParseTimeouts(syncPolicy)
+	if err != nil {
+		return err
+	}
+	budget.Timeout = subtaskTimeout
+	// a subtask exceeding its budget cancels the context shared by the rest of the task
+	var cancel gocontext.CancelFunc
+	if taskTimeout > 0 {
+		ctx, cancel = gocontext.WithTimeout(ctx, taskTimeout)
+	} else {
+		ctx, cancel = gocontext.WithCancel(ctx)
+	}
+	defer cancel()
+
 	taskCtx := contextimpl.NewDefaultTaskContext(ctx, basicRes, task.Plugin, subtasksFlag, progress)
+	taskCtx.SetPipelineId(task.PipelineId)
 	if closeablePlugin, ok := pluginTask.(plugin.CloseablePluginTask); ok {
 		defer closeablePlugin.Close(taskCtx)
 	}
@@ -270,8 +289,11 @@ func RunPluginSubTasks(
 				SubTaskNumber: subtaskNumber,
 			}
 		}
-		err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint)
+		err = runSubtask(basicRes, subtaskCtx, task.ID, subtaskNumber, subtaskMeta.EntryPoint, budget, cancel)
 		if err != nil {
+			if ctx.Err() == gocontext.DeadlineExceeded {
+				err = errors.Default.New(fmt.Sprintf("task timed out after %s", taskTimeout))
+			}
 			err = errors.SubtaskErr.Wrap(err, fmt.Sprintf("subtask %s ended unexpectedly", subtaskMeta.Name), errors.WithData(&subtaskMeta))
 			logger.Error(err, "")
 			return err
@@ -315,7 +337,9 @@ func runSubtask(
 	parentID uint64,
 	subtaskNumber int,
 	entryPoint plugin.SubTaskEntryPoint,
-) errors.Error {
+	budget *SubtaskBudget,
+	cancel gocontext.CancelFunc,
+) (err errors.Error) {
 	beginAt := time.Now()
 	subtask := &models.Subtask{
 		Name:    ctx.GetName(),
@@ -323,10 +347,22 @@ func runSubtask(
 		Number:  subtaskNumber,
 		BeganAt: &beginAt,
 	}
+	watcher := budget.watch(cancel)
 	defer func() {
+		if exceeded := watcher.Stop(); exceeded != nil {
+			// the error returned by the entrypoint is most likely a context cancellation caused by the watcher,
+			// replace it so the pipeline doesn't treat it as cancelled by the user
+			err = errors.Default.Wrap(exceeded, fmt.Sprintf("subtask %s was cancelled for exceeding its resource budget", subtask.Name))
+		}
 		finishedAt := time.Now()
 		subtask.FinishedAt = &finishedAt
 		subtask.SpentSeconds = finishedAt.Unix() - beginAt.Unix()
+		subtask.PeakMemoryMb = watcher.peakMemoryMb
+		subtask.PeakGoroutines = watcher.peakGoroutines
+		if holder, ok := ctx.(plugin.ApiCollectionStatsHolder); ok {
+			subtask.ApiRequests = holder.ApiCollectionStats().Requests.Load()
+			subtask.ProjectedApiRequests = holder.ApiCollectionStats().ProjectedRequests.Load()
+		}
 		recordSubtask(basicRes, subtask)
 	}()
 	return entryPoint(ctx)
@@ -0,0 +1,151 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	"fmt"
+	"runtime"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+const defaultSubtaskBudgetInterval = time.Second
+
+// SubtaskBudget limits the memory, goroutines and time a subtask may use, zero means unlimited.
+// Go doesn't account memory per goroutine, so the watermarks are sampled process-wide
+// while the subtask is running.
+type SubtaskBudget struct {
+	MaxMemoryMb   uint64
+	MaxGoroutines int
+	Timeout       time.Duration
+	Interval      time.Duration
+}
+
+// LoadSubtaskBudget reads SUBTASK_MAX_MEMORY_MB and SUBTASK_MAX_GOROUTINES from the config
+func LoadSubtaskBudget(basicRes context.BasicRes) (*SubtaskBudget, errors.Error) {
+	maxMemoryMb, err := utils.StrToIntOr(basicRes.GetConfig("SUBTASK_MAX_MEMORY_MB"), 0)
+	if err != nil || maxMemoryMb < 0 {
+		return nil, errors.BadInput.New("SUBTASK_MAX_MEMORY_MB must be a non-negative integer")
+	}
+	maxGoroutines, err := utils.StrToIntOr(basicRes.GetConfig("SUBTASK_MAX_GOROUTINES"), 0)
+	if err != nil || maxGoroutines < 0 {
+		return nil, errors.BadInput.New("SUBTASK_MAX_GOROUTINES must be a non-negative integer")
+	}
+	return &SubtaskBudget{
+		MaxMemoryMb:   uint64(maxMemoryMb),
+		MaxGoroutines: maxGoroutines,
+		Interval:      defaultSubtaskBudgetInterval,
+	}, nil
+}
+
+// subtaskWatcher samples resource usage of a running subtask and calls cancel once the budget is exceeded
+type subtaskWatcher struct {
+	budget         *SubtaskBudget
+	cancel         func()
+	stop           chan struct{}
+	done           sync.WaitGroup
+	peakMemoryMb   uint64
+	peakGoroutines int
+	exceeded       errors.Error
+}
+
+func (b *SubtaskBudget) watch(cancel func()) *subtaskWatcher {
+	w := &subtaskWatcher{
+		budget: b,
+		cancel: cancel,
+		stop:   make(chan struct{}),
+	}
+	w.sample()
+	w.done.Add(1)
+	go func() {
+		defer w.done.Done()
+		var timeout <-chan time.Time
+		if b.Timeout > 0 {
+			timer := time.NewTimer(b.Timeout)
+			defer timer.Stop()
+			timeout = timer.C
+		}
+		ticker := time.NewTicker(b.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-w.stop:
+				return
+			case <-timeout:
+				w.exceeded = errors.Default.New(fmt.Sprintf("subtask ran longer than the timeout of %s", b.Timeout))
+				w.cancel()
+				return
+			case <-ticker.C:
+				if w.sample() != nil {
+					return
+				}
+			}
+		}
+	}()
+	return w
+}
+
+func (w *subtaskWatcher) sample() errors.Error {
+	var memStats runtime.MemStats
+	runtime.ReadMemStats(&memStats)
+	memoryMb := memStats.HeapAlloc / 1024 / 1024
+	goroutines := runtime.NumGoroutine()
+	if memoryMb > w.peakMemoryMb {
+		w.peakMemoryMb = memoryMb
+	}
+	if goroutines > w.peakGoroutines {
+		w.peakGoroutines = goroutines
+	}
+	if w.budget.MaxMemoryMb > 0 && memoryMb > w.budget.MaxMemoryMb {
+		w.exceeded = errors.Default.New(fmt.Sprintf("memory usage %d MB exceeded the budget of %d MB", memoryMb, w.budget.MaxMemoryMb))
+	} else if w.budget.MaxGoroutines > 0 && goroutines > w.budget.MaxGoroutines {
+		w.exceeded = errors.Default.New(fmt.Sprintf("goroutine count %d exceeded the budget of %d", goroutines, w.budget.MaxGoroutines))
+	}
+	if w.exceeded != nil {
+		w.cancel()
+	}
+	return w.exceeded
+}
+
+// Stop terminates sampling and returns the budget violation if any
+func (w *subtaskWatcher) Stop() errors.Error {
+	close(w.stop)
+	w.done.Wait()
+	return w.exceeded
+}
+
+// ParseTimeouts returns the task and subtask timeouts configured in the sync policy, zero means no timeout
+func ParseTimeouts(syncPolicy *models.SyncPolicy) (taskTimeout time.Duration, subtaskTimeout time.Duration, err errors.Error) {
+	if syncPolicy == nil {
+		return 0, 0, nil
+	}
+	taskTimeout, err = utils.StrToDurationOr(syncPolicy.TaskTimeout, 0)
+	if err != nil || taskTimeout < 0 {
+		return 0, 0, errors.BadInput.New(fmt.Sprintf("invalid taskTimeout %q, expecting a duration like 2h or 30m", syncPolicy.TaskTimeout))
+	}
+	subtaskTimeout, err = utils.StrToDurationOr(syncPolicy.SubtaskTimeout, 0)
+	if err != nil || subtaskTimeout < 0 {
+		return 0, 0, errors.BadInput.New(fmt.Sprintf("invalid subtaskTimeout %q, expecting a duration like 2h or 30m", syncPolicy.SubtaskTimeout))
+	}
+	return taskTimeout, subtaskTimeout, nil
+}
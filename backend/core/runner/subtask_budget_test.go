@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package runner
+
+import (
+	gocontext "context"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSubtaskWatcherUnlimited(t *testing.T) {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	defer cancel()
+	budget := &SubtaskBudget{Interval: time.Millisecond}
+	watcher := budget.watch(cancel)
+	time.Sleep(5 * time.Millisecond)
+	assert.Nil(t, watcher.Stop())
+	assert.Nil(t, ctx.Err())
+	assert.Greater(t, watcher.peakGoroutines, 0)
+}
+
+func TestSubtaskWatcherGoroutinesExceeded(t *testing.T) {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	defer cancel()
+	budget := &SubtaskBudget{MaxGoroutines: 1, Interval: time.Millisecond}
+	watcher := budget.watch(cancel)
+	<-ctx.Done()
+	err := watcher.Stop()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "goroutine count")
+}
+
+func TestSubtaskWatcherTimeout(t *testing.T) {
+	ctx, cancel := gocontext.WithCancel(gocontext.Background())
+	defer cancel()
+	budget := &SubtaskBudget{Timeout: 10 * time.Millisecond, Interval: time.Hour}
+	watcher := budget.watch(cancel)
+	<-ctx.Done()
+	err := watcher.Stop()
+	assert.NotNil(t, err)
+	assert.Contains(t, err.Error(), "timeout of 10ms")
+}
+
+func TestParseTimeouts(t *testing.T) {
+	taskTimeout, subtaskTimeout, err := ParseTimeouts(nil)
+	assert.Nil(t, err)
+	assert.Zero(t, taskTimeout)
+	assert.Zero(t, subtaskTimeout)
+
+	taskTimeout, subtaskTimeout, err = ParseTimeouts(&models.SyncPolicy{TaskTimeout: "2h", SubtaskTimeout: "30m"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2*time.Hour, taskTimeout)
+	assert.Equal(t, 30*time.Minute, subtaskTimeout)
+
+	_, _, err = ParseTimeouts(&models.SyncPolicy{TaskTimeout: "two hours"})
+	assert.NotNil(t, err)
+}
@@ -62,6 +62,9 @@ import (
 //   3. Verify actual data from specified table against expected data from another `csv` file
 //   4. Repeat step 2 and 3
 //
+// Snapshots missing from disk are created from the database, set `E2E_REGENERATE_SNAPSHOTS=true` (or run
+// `make e2e-regenerate-snapshots PKG=./plugins/xxx/e2e/...`) to rewrite all of them after an intended change.
+//
 // Recommended Usage:
 
 // DataFlowTester use `N`
@@ -95,6 +98,9 @@ type TableOptions struct {
 	IgnoreTypes []interface{}
 	// if Nullable is set to be true, only the string `NULL` will be taken as NULL
 	Nullable bool
+	// Normalizers rewrite the values of the given fields (columns) on both sides before comparison, as well as
+	// when the snapshot is created. Useful for timestamps and auto-increment ids which differ between runs
+	Normalizers map[string]ValueNormalizer
 }
 
 // NewDataFlowTester create a *DataFlowTester to help developer test their subtasks data flow
@@ -357,7 +363,7 @@ func (t *DataFlowTester) CreateSnapshot(dst schema.Tabler, opts TableOptions) {
 			case *sql.NullTime:
 				value := forScanValues[i].(*sql.NullTime)
 				if value.Valid {
-					values[i] = value.Time.In(location).Format("2006-01-02T15:04:05.000-07:00")
+					values[i] = value.Time.In(location).Format(snapshotTimeFormat)
 				} else {
 					if opts.Nullable {
 						values[i] = "NULL"
@@ -396,6 +402,7 @@ func (t *DataFlowTester) CreateSnapshot(dst schema.Tabler, opts TableOptions) {
 			case *string:
 				values[i] = fmt.Sprint(*forScanValues[i].(*string))
 			}
+			values[i] = normalizeValue(opts, columns[i], values[i])
 		}
 		csvWriter.Write(values)
 	}
@@ -439,7 +446,7 @@ func formatDbValue(value interface{}, nullable bool) string {
 	location, _ := time.LoadLocation(`UTC`)
 	switch value := value.(type) {
 	case time.Time:
-		return value.In(location).Format("2006-01-02T15:04:05.000-07:00")
+		return value.In(location).Format(snapshotTimeFormat)
 	case bool:
 		if value {
 			return `1`
@@ -517,7 +524,7 @@ func (t *DataFlowTester) VerifyTableWithOptions(dst schema.Tabler, opts TableOpt
 		panic("CSV relative path missing")
 	}
 	_, err := os.Stat(opts.CSVRelPath)
-	if os.IsNotExist(err) {
+	if os.IsNotExist(err) || t.Cfg.GetBool(`E2E_REGENERATE_SNAPSHOTS`) {
 		t.CreateSnapshot(dst, opts)
 		return
 	}
@@ -568,8 +575,8 @@ func (t *DataFlowTester) VerifyTableWithOptions(dst schema.Tabler, opts TableOpt
 			continue
 		}
 		for _, field := range targetFields {
-			expectation := formatDbValue(expected[field], opts.Nullable)
-			reality := formatDbValue(actual[field], opts.Nullable)
+			expectation := normalizeValue(opts, field, formatDbValue(expected[field], opts.Nullable))
+			reality := normalizeValue(opts, field, formatDbValue(actual[field], opts.Nullable))
 			if !assert.Equal(t.T, expectation, reality, fmt.Sprintf(`%s.%s not match (with params from csv %s)`, dst.TableName(), field, pkValues)) {
 				_ = t.T // useful for debugging
 			}
@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2ehelper
+
+import (
+	"regexp"
+	"time"
+)
+
+// snapshotTimeFormat is the format used by the snapshots for all time columns
+const snapshotTimeFormat = "2006-01-02T15:04:05.000-07:00"
+
+// ValueNormalizer rewrites a formatted column value before it is written into or compared against a snapshot,
+// so values that vary between runs (timestamps, auto-increment ids, etc) don't break the verification
+type ValueNormalizer func(value string) string
+
+// NormalizePresence replaces any non-empty value with `placeholder`, it is handy for auto-increment ids
+// or `time.Now()` based columns where only the existence of the value matters
+func NormalizePresence(placeholder string) ValueNormalizer {
+	return func(value string) string {
+		if value == "" || value == "NULL" {
+			return value
+		}
+		return placeholder
+	}
+}
+
+// NormalizeTimePrecision truncates time values to the given precision, values that are not time are left untouched
+func NormalizeTimePrecision(precision time.Duration) ValueNormalizer {
+	return func(value string) string {
+		t, err := time.Parse(snapshotTimeFormat, value)
+		if err != nil {
+			return value
+		}
+		return t.Truncate(precision).Format(snapshotTimeFormat)
+	}
+}
+
+// NormalizeRegexp replaces all matches of `pattern` with `replacement`, see regexp.Regexp.ReplaceAllString for syntax
+func NormalizeRegexp(pattern string, replacement string) ValueNormalizer {
+	re := regexp.MustCompile(pattern)
+	return func(value string) string {
+		return re.ReplaceAllString(value, replacement)
+	}
+}
+
+func normalizeValue(opts TableOptions, column string, value string) string {
+	if normalizer, ok := opts.Normalizers[column]; ok {
+		return normalizer(value)
+	}
+	return value
+}
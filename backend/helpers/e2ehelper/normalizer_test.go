@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2ehelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalizers(t *testing.T) {
+	presence := NormalizePresence("<id>")
+	assert.Equal(t, "<id>", presence("123"))
+	assert.Equal(t, "", presence(""))
+	assert.Equal(t, "NULL", presence("NULL"))
+
+	precision := NormalizeTimePrecision(time.Minute)
+	assert.Equal(t, "2023-01-02T03:04:00.000+00:00", precision("2023-01-02T03:04:05.678+00:00"))
+	assert.Equal(t, "not a time", precision("not a time"))
+
+	pattern := NormalizeRegexp(`run-\d+`, "run-N")
+	assert.Equal(t, "job run-N of run-N", pattern("job run-12 of run-345"))
+
+	opts := TableOptions{Normalizers: map[string]ValueNormalizer{"id": presence}}
+	assert.Equal(t, "<id>", normalizeValue(opts, "id", "1"))
+	assert.Equal(t, "1", normalizeValue(opts, "name", "1"))
+}
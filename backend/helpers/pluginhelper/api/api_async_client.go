@@ -41,9 +41,11 @@ var HttpMinStatusRetryCode = http.StatusBadRequest
 type ApiAsyncClient struct {
 	*ApiClient
 	*WorkerScheduler
-	maxRetry     int
-	numOfWorkers int
-	logger       log.Logger
+	maxRetry         int
+	maxThrottleRetry int
+	numOfWorkers     int
+	logger           log.Logger
+	responseBudget   *ResponseBudget
 }
 
 const defaultTimeout = 120 * time.Second
@@ -59,6 +61,10 @@ func CreateAsyncApiClient(
 	if err != nil {
 		return nil, errors.BadInput.Wrap(err, "failed to parse API_RETRY")
 	}
+	throttleRetry, err := utils.StrToIntOr(taskCtx.GetConfig("API_THROTTLE_RETRY"), 10)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_THROTTLE_RETRY")
+	}
 
 	timeoutConf := taskCtx.GetConfig("API_TIMEOUT")
 	if timeoutConf != "" {
@@ -75,6 +81,11 @@ func CreateAsyncApiClient(
 
 	apiClient.SetLogger(taskCtx.GetLogger())
 
+	responseBudget, err := LoadResponseBudget(taskCtx)
+	if err != nil {
+		return nil, err
+	}
+
 	globalRateLimitPerHour, err := utils.StrToIntOr(taskCtx.GetConfig("API_REQUESTS_PER_HOUR"), 18000)
 	if err != nil {
 		return nil, errors.Default.Wrap(err, "failed to parse API_REQUESTS_PER_HOUR")
@@ -91,6 +102,19 @@ func CreateAsyncApiClient(
 		return nil, errors.Default.Wrap(err, "failed to calculate rateLimit for api")
 	}
 
+	// cache GET responses after the rate limit got calculated, so the limit is always up-to-date. The cache only
+	// serves the reruns of the same pipeline, the tasks run directly are never cached
+	cacheTtl, err := utils.StrToDurationOr(taskCtx.GetConfig("API_CACHE_TTL"), 0)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_CACHE_TTL")
+	}
+	if cacheTtl > 0 && taskCtx.PipelineId() != 0 {
+		err = apiClient.EnableResponseCache(taskCtx.GetDal(), cacheTtl, taskCtx.PipelineId(), taskCtx.GetLogger())
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	// it is hard to tell how many workers would be sufficient, it depends on how slow the server responds.
 	// we need more workers when server is responding slowly, because requests are sent in a fixed pace.
 	// and because workers are relatively cheap, lets assume response takes 5 seconds
@@ -127,8 +151,10 @@ func CreateAsyncApiClient(
 		apiClient,
 		scheduler,
 		retry,
+		throttleRetry,
 		numOfWorkers,
 		logger,
+		responseBudget,
 	}, nil
 }
 
@@ -154,6 +180,8 @@ func (apiClient *ApiAsyncClient) DoAsync(
 	handler plugin.ApiAsyncCallback,
 	retry int,
 ) {
+	// throttled requests are retried separately, they shouldn't use up the retries for real failures
+	throttled := 0
 	var request func() errors.Error
 	request = func() errors.Error {
 		var err error
@@ -161,6 +189,7 @@ func (apiClient *ApiAsyncClient) DoAsync(
 		var respBody []byte
 
 		apiClient.logger.Debug("endpoint: %s  method: %s  header: %s  body: %s query: %s", path, method, header, body, query)
+		startedAt := time.Now()
 		res, err = apiClient.Do(method, path, query, body, header)
 		if err == ErrIgnoreAndContinue {
 			// make sure defer func got be executed
@@ -176,6 +205,23 @@ func (apiClient *ApiAsyncClient) DoAsync(
 			respBody, err = io.ReadAll(res.Body)
 			if err == nil {
 				res.Body = io.NopCloser(bytes.NewBuffer(respBody))
+				apiClient.checkResponseBudget(method, path, len(respBody), time.Since(startedAt))
+			}
+		}
+
+		// back off when the server asks us to, and retry the throttled request afterwards
+		if err == nil {
+			if delay := throttleDelay(res, time.Now()); delay > 0 {
+				apiClient.logger.Warn(nil, "server asked to back off for %s after calling %s", delay, path)
+				apiClient.Pause(delay)
+				if throttled < apiClient.maxThrottleRetry {
+					throttled++
+					apiClient.NextTick(func() errors.Error {
+						apiClient.SubmitBlocking(request)
+						return nil
+					})
+					return nil
+				}
 			}
 		}
 
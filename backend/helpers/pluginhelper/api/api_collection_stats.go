@@ -0,0 +1,40 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// getMaxPages returns the number of pages the collectors may fetch for each input, 0 means unlimited
+func getMaxPages(ctx plugin.SubTaskContext) int {
+	taskCtx := ctx.TaskContext()
+	if taskCtx == nil || taskCtx.SyncPolicy() == nil {
+		return 0
+	}
+	return taskCtx.SyncPolicy().MaxPages
+}
+
+// recordApiRequests adds the requests made by a collector, and the ones it would make without the limit of pages,
+// to the statistics of the subtask
+func recordApiRequests(ctx plugin.SubTaskContext, requests int64, projectedRequests int64) {
+	if holder, ok := ctx.(plugin.ApiCollectionStatsHolder); ok {
+		holder.ApiCollectionStats().Requests.Add(requests)
+		holder.ApiCollectionStats().ProjectedRequests.Add(projectedRequests)
+	}
+}
@@ -65,6 +65,11 @@ type ApiCollectorArgs struct {
 	UrlTemplate string `comment:"GoTemplate for API url"`
 	// Query would be sent out as part of the request URL
 	Query func(reqData *RequestData) (url.Values, errors.Error)
+	// TimeWindow restricts the collection to the given range of time, it is filled by the ApiCollectorStateManager
+	// automatically when left nil. Has no effect without TimeWindowAdapter
+	TimeWindow *TimeWindow
+	// TimeWindowAdapter injects the TimeWindow into the Query in a provider-specific way
+	TimeWindowAdapter TimeWindowAdapter
 	// Header would be sent out along with request
 	Header func(reqData *RequestData) (http.Header, errors.Error)
 	// GetTotalPages is to tell `ApiCollector` total number of pages based on response of the first page.
@@ -94,8 +99,11 @@ type ApiCollectorArgs struct {
 // ApiCollector FIXME ...
 type ApiCollector struct {
 	*RawDataSubTask
-	args        *ApiCollectorArgs
-	urlTemplate *template.Template
+	args           *ApiCollectorArgs
+	urlTemplate    *template.Template
+	pageSize       int64
+	maxPages       int
+	responseBudget *ResponseBudget
 }
 
 // NewApiCollector allocates a new ApiCollector with the given args.
@@ -125,6 +133,10 @@ func NewApiCollector(args ApiCollectorArgs) (*ApiCollector, errors.Error) {
 		RawDataSubTask: rawDataSubTask,
 		args:           &args,
 		urlTemplate:    tpl,
+		pageSize:       int64(args.PageSize),
+	}
+	if provider, ok := args.ApiClient.(responseBudgetProvider); ok {
+		apiCollector.responseBudget = provider.GetResponseBudget()
 	}
 	if args.AfterResponse != nil {
 		apiCollector.SetAfterResponse(args.AfterResponse)
@@ -165,6 +177,7 @@ func (collector *ApiCollector) Execute() errors.Error {
 	if syncPolicy != nil && syncPolicy.FullSync {
 		isIncremental = false
 	}
+	collector.maxPages = getMaxPages(collector.args.Ctx)
 	// flush data if not incremental collection
 	if !isIncremental {
 		err = db.Delete(&RawData{}, dal.From(collector.table), dal.Where("params = ?", collector.params))
@@ -246,7 +259,7 @@ func (collector *ApiCollector) exec(input interface{}) {
 	reqData.InputJSON = inputJson
 	reqData.Pager = &Pager{
 		Page: 1,
-		Size: collector.args.PageSize,
+		Size: collector.currentPageSize(),
 	}
 	// fetch the detail
 	if collector.args.PageSize <= 0 {
@@ -268,7 +281,7 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 	var collect func() errors.Error
 	collect = func() errors.Error {
 		collector.fetchAsync(reqData, func(count int, body []byte, res *http.Response) errors.Error {
-			if count < collector.args.PageSize {
+			if count < reqData.Pager.Size || collector.exceedsMaxPages(reqData.Pager.Page+1) {
 				return nil
 			}
 			customData, err := collector.args.GetNextPageCustomData(reqData, res)
@@ -280,7 +293,7 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 				}
 			}
 			reqData.CustomData = customData
-			reqData.Pager.Skip += collector.args.PageSize
+			reqData.Pager.Skip += reqData.Pager.Size
 			reqData.Pager.Page += 1
 			collector.args.ApiClient.NextTick(collect)
 			return nil
@@ -294,18 +307,25 @@ func (collector *ApiCollector) fetchPagesSequentially(reqData *RequestData) {
 func (collector *ApiCollector) fetchPagesDetermined(reqData *RequestData) {
 	// fetch first page
 	collector.fetchAsync(reqData, func(count int, body []byte, res *http.Response) errors.Error {
-		totalPages, err := collector.args.GetTotalPages(res, collector.args)
+		// the page size might have been split, make sure total pages are calculated against the actual one
+		args := *collector.args
+		args.PageSize = reqData.Pager.Size
+		totalPages, err := collector.args.GetTotalPages(res, &args)
 		if err != nil {
 			return errors.Default.Wrap(err, "fetchPagesDetermined get totalPages failed")
 		}
+		if collector.exceedsMaxPages(totalPages) {
+			recordApiRequests(collector.args.Ctx, 0, int64(totalPages-collector.maxPages))
+			totalPages = collector.maxPages
+		}
 		// spawn a none blocking go routine to fetch other pages
 		collector.args.ApiClient.NextTick(func() errors.Error {
 			for page := 2; page <= totalPages; page++ {
 				reqDataTemp := &RequestData{
 					Pager: &Pager{
 						Page: page,
-						Skip: collector.args.PageSize * (page - 1),
-						Size: collector.args.PageSize,
+						Skip: reqData.Pager.Size * (page - 1),
+						Size: reqData.Pager.Size,
 					},
 					Input:     reqData.Input,
 					InputJSON: reqData.InputJSON,
@@ -328,6 +348,7 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 	// goroutine #2 fetches pages 2/5/8...
 	// goroutine #3 fetches pages 3/6/9...
 	apiClient := collector.args.ApiClient
+	pageSize := reqData.Pager.Size
 	concurrency := collector.args.Concurrency
 	if concurrency == 0 {
 		// normally when a multi-pages api depends on a another resource, like jira changelogs depend on issue ids
@@ -341,12 +362,15 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 			}
 		}
 	}
+	if collector.maxPages > 0 && concurrency > collector.maxPages {
+		concurrency = collector.maxPages
+	}
 	for i := 0; i < concurrency; i++ {
 		reqDataCopy := RequestData{
 			Pager: &Pager{
 				Page: i + 1,
-				Size: collector.args.PageSize,
-				Skip: collector.args.PageSize * (i),
+				Size: pageSize,
+				Skip: pageSize * (i),
 			},
 			Input:     reqData.Input,
 			InputJSON: reqData.InputJSON,
@@ -354,11 +378,11 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 		var collect func() errors.Error
 		collect = func() errors.Error {
 			collector.fetchAsync(&reqDataCopy, func(count int, body []byte, res *http.Response) errors.Error {
-				if count < collector.args.PageSize {
+				if count < pageSize || collector.exceedsMaxPages(reqDataCopy.Pager.Page+concurrency) {
 					return nil
 				}
 				apiClient.NextTick(func() errors.Error {
-					reqDataCopy.Pager.Skip += collector.args.PageSize * concurrency
+					reqDataCopy.Pager.Skip += pageSize * concurrency
 					reqDataCopy.Pager.Page += concurrency
 					return collect()
 				})
@@ -370,6 +394,11 @@ func (collector *ApiCollector) fetchPagesUndetermined(reqData *RequestData) {
 	}
 }
 
+// exceedsMaxPages tells whether the page is beyond the limit of SyncPolicy.MaxPages
+func (collector *ApiCollector) exceedsMaxPages(page int) bool {
+	return collector.maxPages > 0 && page > collector.maxPages
+}
+
 func (collector *ApiCollector) generateUrl(pager *Pager, input interface{}) (string, errors.Error) {
 	params := collector.args.Params
 	if collector.args.Options != nil {
@@ -416,6 +445,7 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 			panic(err)
 		}
 	}
+	apiQuery = collector.applyTimeWindow(apiQuery)
 	var reqBody interface{}
 	if collector.args.RequestBody != nil {
 		reqBody = collector.args.RequestBody(reqData)
@@ -443,6 +473,8 @@ func (collector *ApiCollector) fetchAsync(reqData *RequestData, handler func(int
 		}
 		res.Body.Close()
 		res.Body = io.NopCloser(bytes.NewBuffer(body))
+		recordApiRequests(collector.args.Ctx, 1, 1)
+		collector.splitPageSize(apiUrl, reqData.Pager.Size, len(body))
 		// convert body to array of RawJSON
 		items, err := collector.args.ResponseParser(res)
 		if err != nil {
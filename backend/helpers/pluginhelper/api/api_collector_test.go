@@ -25,9 +25,11 @@ import (
 	"testing"
 
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/unithelper"
 	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
 	mockapi "github.com/apache/incubator-devlake/mocks/helpers/pluginhelper/api"
 
 	"github.com/stretchr/testify/assert"
@@ -107,3 +109,71 @@ func TestFetchPageUndetermined(t *testing.T) {
 
 	mockDal.AssertExpectations(t)
 }
+
+type statsSubTaskContext struct {
+	*mockplugin.SubTaskContext
+	stats plugin.ApiCollectionStats
+}
+
+func (c *statsSubTaskContext) ApiCollectionStats() *plugin.ApiCollectionStats {
+	return &c.stats
+}
+
+func TestFetchPagesDeterminedWithMaxPages(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("AutoMigrate", mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Delete", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	mockDal.On("Create", mock.Anything, mock.Anything).Return(nil).Twice()
+
+	mockCtx := new(mockplugin.SubTaskContext)
+	mockCtx.On("GetDal").Return(mockDal)
+	mockCtx.On("GetLogger").Return(unithelper.DummyLogger())
+	mockCtx.On("SetProgress", mock.Anything, mock.Anything)
+	mockCtx.On("IncProgress", mock.Anything, mock.Anything)
+	mockTaskContext := new(mockplugin.TaskContext)
+	mockTaskContext.On("SyncPolicy").Return(&models.SyncPolicy{MaxPages: 2})
+	mockCtx.On("TaskContext").Return(mockTaskContext)
+	ctx := &statsSubTaskContext{SubTaskContext: mockCtx}
+
+	// the api has 5 pages, only the first 2 are fetched
+	mockApi := new(mockapi.RateLimitedApiClient)
+	mockApi.On("DoGetAsync", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		res := &http.Response{
+			Request: &http.Request{
+				URL: &url.URL{},
+			},
+			Body: io.NopCloser(bytes.NewBufferString("[1,2,3]")),
+		}
+		handler := args.Get(3).(plugin.ApiAsyncCallback)
+		assert.Nil(t, handler(res))
+	}).Twice()
+	mockApi.On("NextTick", mock.Anything).Run(func(args mock.Arguments) {
+		handler := args.Get(0).(func() errors.Error)
+		assert.Nil(t, handler())
+	}).Once()
+	mockApi.On("WaitAsync").Return(nil)
+	mockApi.On("SetAfterFunction", mock.Anything).Return()
+
+	collector, err := NewApiCollector(ApiCollectorArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:     ctx,
+			Table:   "whatever rawtable",
+			Options: &TestOpts{},
+		},
+		ApiClient:   mockApi,
+		UrlTemplate: "whatever url",
+		PageSize:    3,
+		GetTotalPages: func(res *http.Response, args *ApiCollectorArgs) (int, errors.Error) {
+			return 5, nil
+		},
+		ResponseParser: GetRawMessageArrayFromResponse,
+	})
+
+	assert.Nil(t, err)
+	assert.Nil(t, collector.Execute())
+	assert.Equal(t, int64(2), ctx.stats.Requests.Load())
+	assert.Equal(t, int64(5), ctx.stats.ProjectedRequests.Load())
+
+	mockDal.AssertExpectations(t)
+	mockApi.AssertExpectations(t)
+}
@@ -147,10 +147,21 @@ func NewStatefulApiCollector(args RawDataSubTaskArgs) (*ApiCollectorStateManager
 
 }
 
+// TimeWindow returns the range of time the embedded collectors should collect
+func (m *ApiCollectorStateManager) TimeWindow() *TimeWindow {
+	return &TimeWindow{
+		Since: m.Since,
+		Until: m.Before,
+	}
+}
+
 // InitCollector init the embedded collector
 func (m *ApiCollectorStateManager) InitCollector(args ApiCollectorArgs) errors.Error {
 	args.RawDataSubTaskArgs = m.RawDataSubTaskArgs
 	args.Incremental = args.Incremental || m.IsIncremental
+	if args.TimeWindow == nil {
+		args.TimeWindow = m.TimeWindow()
+	}
 	apiCollector, err := NewApiCollector(args)
 	if err != nil {
 		return err
@@ -180,6 +191,10 @@ func (m *ApiCollectorStateManager) Execute() errors.Error {
 		}
 	}
 
+	// a collection limited by SyncPolicy.MaxPages is partial, the next one must not start from it
+	if getMaxPages(m.Ctx) > 0 {
+		return nil
+	}
 	db := m.Ctx.GetDal()
 	return db.CreateOrUpdate(&m.newState)
 }
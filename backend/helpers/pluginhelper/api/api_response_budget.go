@@ -0,0 +1,106 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"sync/atomic"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/utils"
+)
+
+// ResponseBudget defines how large and how slow a single api response is expected to be, zero means unlimited.
+// Responses beyond the budget are reported as warnings, and when SplitPageSize is enabled the ApiCollector
+// halves the page size for the inputs that come after an oversized response
+type ResponseBudget struct {
+	MaxResponseSize  int
+	LatencyThreshold time.Duration
+	SplitPageSize    bool
+}
+
+// LoadResponseBudget reads API_MAX_RESPONSE_SIZE_MB, API_LATENCY_THRESHOLD and API_SPLIT_OVERSIZED_PAGES from the config
+func LoadResponseBudget(basicRes context.BasicRes) (*ResponseBudget, errors.Error) {
+	maxResponseSizeMb, err := utils.StrToIntOr(basicRes.GetConfig("API_MAX_RESPONSE_SIZE_MB"), 0)
+	if err != nil || maxResponseSizeMb < 0 {
+		return nil, errors.BadInput.New("API_MAX_RESPONSE_SIZE_MB must be a non-negative integer")
+	}
+	latencyThreshold, err := utils.StrToDurationOr(basicRes.GetConfig("API_LATENCY_THRESHOLD"), 0)
+	if err != nil || latencyThreshold < 0 {
+		return nil, errors.BadInput.New("API_LATENCY_THRESHOLD must be a duration like 30s")
+	}
+	splitPageSize, err := utils.StrToBoolOr(basicRes.GetConfig("API_SPLIT_OVERSIZED_PAGES"), false)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "failed to parse API_SPLIT_OVERSIZED_PAGES")
+	}
+	return &ResponseBudget{
+		MaxResponseSize:  maxResponseSizeMb * 1024 * 1024,
+		LatencyThreshold: latencyThreshold,
+		SplitPageSize:    splitPageSize,
+	}, nil
+}
+
+// SizeExceeded returns true if a response of `size` bytes is beyond the budget
+func (b *ResponseBudget) SizeExceeded(size int) bool {
+	return b != nil && b.MaxResponseSize > 0 && size > b.MaxResponseSize
+}
+
+// LatencyExceeded returns true if a response took longer than the budget
+func (b *ResponseBudget) LatencyExceeded(latency time.Duration) bool {
+	return b != nil && b.LatencyThreshold > 0 && latency > b.LatencyThreshold
+}
+
+func (apiClient *ApiAsyncClient) checkResponseBudget(method string, path string, size int, latency time.Duration) {
+	if apiClient.responseBudget.SizeExceeded(size) {
+		apiClient.logger.Warn(nil, "response of [%s %s] is %d bytes, exceeding API_MAX_RESPONSE_SIZE_MB", method, path, size)
+	}
+	if apiClient.responseBudget.LatencyExceeded(latency) {
+		apiClient.logger.Warn(nil, "response of [%s %s] took %s, exceeding API_LATENCY_THRESHOLD %s",
+			method, path, latency, apiClient.responseBudget.LatencyThreshold)
+	}
+}
+
+// GetResponseBudget returns the ResponseBudget loaded from the config, nil means unlimited
+func (apiClient *ApiAsyncClient) GetResponseBudget() *ResponseBudget {
+	return apiClient.responseBudget
+}
+
+// responseBudgetProvider is implemented by api clients that are aware of the ResponseBudget
+type responseBudgetProvider interface {
+	GetResponseBudget() *ResponseBudget
+}
+
+func (collector *ApiCollector) currentPageSize() int {
+	return int(atomic.LoadInt64(&collector.pageSize))
+}
+
+// splitPageSize halves the page size for the following inputs once a response of `pageSize` turned out
+// to be oversized. Pages of the current input keep their size, or the offsets would be messed up
+func (collector *ApiCollector) splitPageSize(apiUrl string, pageSize int, size int) {
+	budget := collector.responseBudget
+	if budget == nil || !budget.SplitPageSize || !budget.SizeExceeded(size) || pageSize <= 1 {
+		return
+	}
+	if atomic.CompareAndSwapInt64(&collector.pageSize, int64(pageSize), int64(pageSize/2)) {
+		collector.args.Ctx.GetLogger().Info(
+			"response of %s is %d bytes with page size %d, reducing page size to %d",
+			apiUrl, size, pageSize, pageSize/2,
+		)
+	}
+}
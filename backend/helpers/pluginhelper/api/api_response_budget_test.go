@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockcontext "github.com/apache/incubator-devlake/mocks/core/context"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLoadResponseBudget(t *testing.T) {
+	basicRes := new(mockcontext.BasicRes)
+	basicRes.On("GetConfig", "API_MAX_RESPONSE_SIZE_MB").Return("10")
+	basicRes.On("GetConfig", "API_LATENCY_THRESHOLD").Return("30s")
+	basicRes.On("GetConfig", "API_SPLIT_OVERSIZED_PAGES").Return("true")
+	budget, err := LoadResponseBudget(basicRes)
+	assert.Nil(t, err)
+	assert.Equal(t, 10*1024*1024, budget.MaxResponseSize)
+	assert.Equal(t, 30*time.Second, budget.LatencyThreshold)
+	assert.True(t, budget.SplitPageSize)
+
+	basicRes = new(mockcontext.BasicRes)
+	basicRes.On("GetConfig", "API_MAX_RESPONSE_SIZE_MB").Return("-1")
+	_, err = LoadResponseBudget(basicRes)
+	assert.NotNil(t, err)
+}
+
+func TestResponseBudgetExceeded(t *testing.T) {
+	var unlimited *ResponseBudget
+	assert.False(t, unlimited.SizeExceeded(1<<30))
+	assert.False(t, unlimited.LatencyExceeded(time.Hour))
+
+	budget := &ResponseBudget{MaxResponseSize: 100, LatencyThreshold: time.Second}
+	assert.False(t, budget.SizeExceeded(100))
+	assert.True(t, budget.SizeExceeded(101))
+	assert.False(t, budget.LatencyExceeded(time.Second))
+	assert.True(t, budget.LatencyExceeded(2*time.Second))
+}
+
+func TestApiCollectorSplitPageSize(t *testing.T) {
+	collector := &ApiCollector{
+		args: &ApiCollectorArgs{
+			RawDataSubTaskArgs: RawDataSubTaskArgs{Ctx: unithelper.DummySubTaskContext(nil)},
+		},
+		pageSize:       100,
+		responseBudget: &ResponseBudget{MaxResponseSize: 1000},
+	}
+	// splitting is disabled
+	collector.splitPageSize("url", 100, 2000)
+	assert.Equal(t, 100, collector.currentPageSize())
+
+	collector.responseBudget.SplitPageSize = true
+	collector.splitPageSize("url", 100, 1000)
+	assert.Equal(t, 100, collector.currentPageSize())
+	collector.splitPageSize("url", 100, 2000)
+	assert.Equal(t, 50, collector.currentPageSize())
+	// responses of the same page size arriving late must not split again
+	collector.splitPageSize("url", 100, 2000)
+	assert.Equal(t, 50, collector.currentPageSize())
+	collector.splitPageSize("url", 50, 2000)
+	assert.Equal(t, 25, collector.currentPageSize())
+}
@@ -0,0 +1,139 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// cachingTransport replays successful GET responses stored in `_devlake_api_response_cache` within the TTL,
+// requests are keyed by the pipeline, the final URL and headers (credentials included, hashed), so different
+// connections never share cached responses and a new run of the blueprint collects fresh data
+type cachingTransport struct {
+	next       http.RoundTripper
+	db         dal.Dal
+	ttl        time.Duration
+	pipelineId uint64
+	logger     log.Logger
+}
+
+// EnableResponseCache makes the ApiClient cache successful GET responses in the database for `ttl`, only the
+// reruns of the pipeline `pipelineId` are served from the cache, expired entries are purged on the way
+func (apiClient *ApiClient) EnableResponseCache(db dal.Dal, ttl time.Duration, pipelineId uint64, logger log.Logger) errors.Error {
+	err := db.Delete(&models.ApiResponseCache{}, dal.Where("expires_at < ?", time.Now()))
+	if err != nil {
+		return errors.Default.Wrap(err, "failed to purge expired api response cache")
+	}
+	next := apiClient.client.Transport
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	apiClient.client.Transport = &cachingTransport{
+		next:       next,
+		db:         db,
+		ttl:        ttl,
+		pipelineId: pipelineId,
+		logger:     logger,
+	}
+	return nil
+}
+
+func (t *cachingTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.Method != http.MethodGet {
+		return t.next.RoundTrip(req)
+	}
+	key := responseCacheKey(req, t.pipelineId)
+	cached := &models.ApiResponseCache{}
+	err := t.db.First(cached, dal.Where("cache_key = ? AND expires_at > ?", key, time.Now()))
+	if err == nil {
+		header := http.Header{}
+		if e := json.Unmarshal(cached.Header, &header); e == nil {
+			t.logger.Debug("replaying cached response for %s", cached.Url)
+			return &http.Response{
+				Status:        http.StatusText(cached.StatusCode),
+				StatusCode:    cached.StatusCode,
+				Proto:         "HTTP/1.1",
+				ProtoMajor:    1,
+				ProtoMinor:    1,
+				Header:        header,
+				Body:          io.NopCloser(bytes.NewReader(cached.Body)),
+				ContentLength: int64(len(cached.Body)),
+				Request:       req,
+			}, nil
+		}
+	} else if !t.db.IsErrorNotFound(err) {
+		t.logger.Warn(err, "failed to load cached response for %s", req.URL.String())
+	}
+
+	res, e := t.next.RoundTrip(req)
+	if e != nil || res.StatusCode < 200 || res.StatusCode >= 300 {
+		return res, e
+	}
+	body, e := io.ReadAll(res.Body)
+	res.Body.Close()
+	if e != nil {
+		return nil, e
+	}
+	res.Body = io.NopCloser(bytes.NewReader(body))
+	header, e := json.Marshal(res.Header)
+	if e != nil {
+		return res, nil
+	}
+	err = t.db.CreateOrUpdate(&models.ApiResponseCache{
+		CacheKey:   key,
+		Url:        req.URL.String(),
+		StatusCode: res.StatusCode,
+		Header:     header,
+		Body:       body,
+		ExpiresAt:  time.Now().Add(t.ttl),
+	})
+	if err != nil {
+		t.logger.Warn(err, "failed to cache response for %s", req.URL.String())
+	}
+	return res, nil
+}
+
+func responseCacheKey(req *http.Request, pipelineId uint64) string {
+	hash := sha256.New()
+	hash.Write([]byte(fmt.Sprintf("%d\n", pipelineId)))
+	hash.Write([]byte(req.Method + " " + req.URL.String() + "\n"))
+	names := make([]string, 0, len(req.Header))
+	for name := range req.Header {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		for _, value := range req.Header[name] {
+			hash.Write([]byte(name + ": " + value + "\n"))
+		}
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
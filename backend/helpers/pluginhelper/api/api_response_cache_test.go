@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestResponseCacheKey(t *testing.T) {
+	req1, _ := http.NewRequest(http.MethodGet, "https://example.com/api?page=1", nil)
+	req1.Header.Set("Authorization", "token a")
+	req2, _ := http.NewRequest(http.MethodGet, "https://example.com/api?page=1", nil)
+	req2.Header.Set("Authorization", "token a")
+	assert.Equal(t, responseCacheKey(req1, 1), responseCacheKey(req2, 1))
+
+	req2.Header.Set("Authorization", "token b")
+	assert.NotEqual(t, responseCacheKey(req1, 1), responseCacheKey(req2, 1))
+	req3, _ := http.NewRequest(http.MethodGet, "https://example.com/api?page=2", nil)
+	req3.Header.Set("Authorization", "token a")
+	assert.NotEqual(t, responseCacheKey(req1, 1), responseCacheKey(req3, 1))
+	// another pipeline doesn't get the cached responses
+	assert.NotEqual(t, responseCacheKey(req1, 1), responseCacheKey(req1, 2))
+}
+
+func TestCachingTransport(t *testing.T) {
+	var stored *models.ApiResponseCache
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(func(dst interface{}, clauses ...dal.Clause) errors.Error {
+		if stored == nil {
+			return errors.NotFound.New("not found")
+		}
+		*dst.(*models.ApiResponseCache) = *stored
+		return nil
+	})
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		stored = args.Get(0).(*models.ApiResponseCache)
+	}).Return(nil)
+
+	requested := 0
+	transport := &cachingTransport{
+		next: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+			requested++
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Header:     http.Header{"X-Total": []string{"3"}},
+				Body:       io.NopCloser(strings.NewReader("[1,2,3]")),
+			}, nil
+		}),
+		db:         mockDal,
+		ttl:        time.Hour,
+		pipelineId: 1,
+		logger:     unithelper.DummyLogger(),
+	}
+
+	for i := 0; i < 2; i++ {
+		req, _ := http.NewRequest(http.MethodGet, "https://example.com/api", nil)
+		res, err := transport.RoundTrip(req)
+		assert.Nil(t, err)
+		body, _ := io.ReadAll(res.Body)
+		assert.Equal(t, "[1,2,3]", string(body))
+		assert.Equal(t, "3", res.Header.Get("X-Total"))
+	}
+	assert.Equal(t, 1, requested)
+	assert.True(t, stored.ExpiresAt.After(time.Now()))
+
+	// non-GET requests are never cached
+	req, _ := http.NewRequest(http.MethodPost, "https://example.com/api", nil)
+	_, err := transport.RoundTrip(req)
+	assert.Nil(t, err)
+	assert.Equal(t, 2, requested)
+}
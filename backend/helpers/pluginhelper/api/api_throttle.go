@@ -0,0 +1,65 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+)
+
+const (
+	// defaultThrottleDelay is used when the server throttles us without telling how long to wait
+	defaultThrottleDelay = 30 * time.Second
+	// maxThrottleDelay caps the delay asked by the server, in case of a clock skew or a bogus header
+	maxThrottleDelay = time.Hour
+)
+
+// isThrottled returns true if the server refused the request due to throttling
+func isThrottled(res *http.Response) bool {
+	return res.StatusCode == http.StatusTooManyRequests ||
+		(res.StatusCode == http.StatusServiceUnavailable && res.Header.Get("Retry-After") != "")
+}
+
+// throttleDelay returns how long the server asks us to hold off further requests, zero means no need to wait.
+// Only throttled responses pause the client, the remaining quota of successful ones is left to the rate limiter
+// of the token. It honors `Retry-After` (seconds or http-date) and `X-RateLimit-Reset` (epoch seconds)
+func throttleDelay(res *http.Response, now time.Time) time.Duration {
+	if !isThrottled(res) {
+		return 0
+	}
+	var delay time.Duration
+	if retryAfter := res.Header.Get("Retry-After"); retryAfter != "" {
+		if seconds, err := strconv.Atoi(retryAfter); err == nil {
+			delay = time.Duration(seconds) * time.Second
+		} else if at, err := http.ParseTime(retryAfter); err == nil {
+			delay = at.Sub(now)
+		}
+	} else if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		if reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+			delay = time.Unix(reset, 0).Sub(now)
+		}
+	}
+	if delay <= 0 {
+		delay = defaultThrottleDelay
+	}
+	if delay > maxThrottleDelay {
+		delay = maxThrottleDelay
+	}
+	return delay
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestThrottleDelay(t *testing.T) {
+	now := time.Date(2023, 12, 19, 0, 0, 0, 0, time.UTC)
+	response := func(status int, headers map[string]string) *http.Response {
+		res := &http.Response{StatusCode: status, Header: http.Header{}}
+		for name, value := range headers {
+			res.Header.Set(name, value)
+		}
+		return res
+	}
+
+	assert.Zero(t, throttleDelay(response(http.StatusOK, nil), now))
+	assert.Equal(t, 10*time.Second, throttleDelay(response(http.StatusTooManyRequests, map[string]string{
+		"Retry-After": "10",
+	}), now))
+	assert.Equal(t, 2*time.Minute, throttleDelay(response(http.StatusServiceUnavailable, map[string]string{
+		"Retry-After": now.Add(2 * time.Minute).Format(http.TimeFormat),
+	}), now))
+	assert.Equal(t, defaultThrottleDelay, throttleDelay(response(http.StatusTooManyRequests, nil), now))
+	assert.Equal(t, maxThrottleDelay, throttleDelay(response(http.StatusTooManyRequests, map[string]string{
+		"Retry-After": "86400",
+	}), now))
+	assert.Equal(t, 5*time.Minute, throttleDelay(response(http.StatusTooManyRequests, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(now.Add(5*time.Minute).Unix(), 10),
+	}), now))
+	// the quota of successful responses is left to the rate limiter, even when it is used up
+	assert.Zero(t, throttleDelay(response(http.StatusOK, map[string]string{
+		"X-RateLimit-Remaining": "0",
+		"X-RateLimit-Reset":     strconv.FormatInt(now.Add(5*time.Minute).Unix(), 10),
+	}), now))
+	assert.Zero(t, throttleDelay(response(http.StatusServiceUnavailable, nil), now))
+}
+
+func TestIsThrottled(t *testing.T) {
+	assert.True(t, isThrottled(&http.Response{StatusCode: http.StatusTooManyRequests}))
+	assert.False(t, isThrottled(&http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{}}))
+	assert.True(t, isThrottled(&http.Response{StatusCode: http.StatusServiceUnavailable, Header: http.Header{"Retry-After": []string{"1"}}}))
+}
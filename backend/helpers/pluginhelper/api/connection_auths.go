@@ -93,7 +93,7 @@ func (ak *AppKey) GetAppKeyAuthenticator() plugin.ApiAuthenticator {
 
 // MultiAuth implements the MultiAuthenticator interface
 type MultiAuth struct {
-	AuthMethod       string `mapstructure:"authMethod" json:"authMethod" validate:"required,oneof=BasicAuth AccessToken AppKey"`
+	AuthMethod       string `mapstructure:"authMethod" json:"authMethod" validate:"required,oneof=BasicAuth AccessToken AppKey OAuth2"`
 	apiAuthenticator plugin.ApiAuthenticator
 }
 
@@ -126,6 +126,14 @@ func (ma *MultiAuth) GetApiAuthenticator(connection plugin.ApiConnection) (plugi
 		}
 		// check ae/models/connection.go:AeAppKey if you needed an example
 		ma.apiAuthenticator = appKey.GetAppKeyAuthenticator()
+	case plugin.AUTH_METHOD_OAUTH2:
+		// OAuth 2.0 requires exchanging tokens with the authorization server of the vendor,
+		// each Specific Connection should implement on its own.
+		oauth2, ok := connection.(plugin.OAuth2Authenticator)
+		if !ok {
+			return nil, errors.Default.New("connection doesn't support OAuth2 Authentication")
+		}
+		ma.apiAuthenticator = oauth2.GetOAuth2Authenticator()
 	default:
 		return nil, errors.Default.New("no Authentication Method was specified")
 	}
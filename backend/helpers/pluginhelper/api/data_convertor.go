@@ -43,13 +43,17 @@ type DataConverterArgs struct {
 	Input        dal.Rows
 	Convert      DataConvertHandler
 	BatchSize    int
+	// DetectChanges skips rows identical to the last conversion and deletes the ones no longer produced,
+	// instead of deleting and rewriting all rows of the scope. Only applies to types with a single string
+	// `Id` primary key (i.e. all DomainEntity), other types are rewritten as usual
+	DetectChanges bool
 }
 
 // DataConverter helps you convert Data from Tool Layer Tables to Domain Layer Tables
 // It reads rows from specified Iterator, and feed it into `Converter` handler
 // you can return arbitrary domain layer entities from this handler, ApiConverter would
 // first delete old data by their RawDataOrigin information, and then perform a
-// batch save operation for you.
+// batch save operation for you, or only save the changed rows when DetectChanges is enabled.
 type DataConverter struct {
 	*RawDataSubTask
 	args *DataConverterArgs
@@ -83,6 +87,14 @@ func (converter *DataConverter) Execute() errors.Error {
 	RAW_DATA_ORIGIN := "RawDataOrigin"
 	divider := NewBatchSaveDivider(converter.args.Ctx, converter.args.BatchSize, converter.table, converter.params)
 
+	// track row hashes so unchanged rows are not rewritten
+	var tracker *rowHashTracker
+	if converter.args.DetectChanges {
+		syncPolicy := converter.args.Ctx.TaskContext().SyncPolicy()
+		fullSync := syncPolicy != nil && syncPolicy.FullSync
+		tracker = newRowHashTracker(converter.args.Ctx, converter.table, converter.params, fullSync, converter.args.BatchSize)
+	}
+
 	// set progress
 	converter.args.Ctx.SetProgress(0, -1)
 
@@ -109,9 +121,22 @@ func (converter *DataConverter) Execute() errors.Error {
 
 		for _, result := range results {
 			// get the batch operator for the specific type
-			batch, err := divider.ForType(reflect.TypeOf(result))
-			if err != nil {
-				return errors.Default.Wrap(err, "error getting batch from result")
+			var batch *BatchSave
+			if tracker != nil {
+				var unchanged bool
+				batch, unchanged, err = tracker.track(result)
+				if err != nil {
+					return errors.Default.Wrap(err, "error tracking result")
+				}
+				if unchanged {
+					continue
+				}
+			}
+			if batch == nil {
+				batch, err = divider.ForType(reflect.TypeOf(result))
+				if err != nil {
+					return errors.Default.Wrap(err, "error getting batch from result")
+				}
 			}
 			// set raw data origin field
 			origin := reflect.ValueOf(result).Elem().FieldByName(RAW_DATA_ORIGIN)
@@ -128,6 +153,12 @@ func (converter *DataConverter) Execute() errors.Error {
 	}
 
 	// save the last batches
+	if tracker != nil {
+		err := tracker.close()
+		if err != nil {
+			return err
+		}
+	}
 	return divider.Close()
 }
 
@@ -0,0 +1,138 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	plugin "github.com/apache/incubator-devlake/core/plugin"
+)
+
+// DataReconcilerArgs includes the arguments about DataReconciler.
+//
+//	DataReconcilerArgs {
+//				RawDataSubTaskArgs: identifies the domain rows to reconcile by their raw data origin
+//				DomainTable:        domain table with an `is_deleted` column, i.e. &code.PullRequest{}
+//				AliveIds:           ids of the domain entities that still exist upstream
+//				BatchSize:          batch size of the update statements
+type DataReconcilerArgs struct {
+	RawDataSubTaskArgs
+	DomainTable dal.Tabler
+	AliveIds    []string
+	BatchSize   int
+}
+
+// DataReconciler propagates upstream deletions to the Domain Layer. Incremental collection only
+// fetches records updated since the last run, so records deleted (or moved away) upstream would linger
+// forever. DataReconciler compares the full id set collected from upstream against the domain rows
+// converted from the same raw data, marks the missing ones as deleted and restores the ones coming back.
+type DataReconciler struct {
+	*RawDataSubTask
+	args *DataReconcilerArgs
+}
+
+// NewDataReconciler function helps you create a DataReconciler using DataReconcilerArgs.
+// You can see the usage in plugins/gitlab/tasks/mr_reconciler.go
+func NewDataReconciler(args DataReconcilerArgs) (*DataReconciler, errors.Error) {
+	rawDataSubTask, err := NewRawDataSubTask(args.RawDataSubTaskArgs)
+	if err != nil {
+		return nil, err
+	}
+	if args.DomainTable == nil {
+		return nil, errors.Default.New("DomainTable is required")
+	}
+	if args.BatchSize == 0 {
+		args.BatchSize = 500
+	}
+	return &DataReconciler{
+		RawDataSubTask: rawDataSubTask,
+		args:           &args,
+	}, nil
+}
+
+// Execute function implements Subtask interface.
+func (reconciler *DataReconciler) Execute() errors.Error {
+	logger := reconciler.args.Ctx.GetLogger()
+	// an empty id set is far more likely to be a broken collection than everything being deleted
+	if len(reconciler.args.AliveIds) == 0 {
+		logger.Warn(nil, "no ids were collected for %s, skip reconciliation", reconciler.args.DomainTable.TableName())
+		return nil
+	}
+	alive := make(map[string]bool, len(reconciler.args.AliveIds))
+	for _, id := range reconciler.args.AliveIds {
+		alive[id] = true
+	}
+
+	deleted, err := reconciler.diff(false, func(id string) bool { return !alive[id] })
+	if err != nil {
+		return err
+	}
+	err = reconciler.markDeleted(deleted, true)
+	if err != nil {
+		return err
+	}
+	restored, err := reconciler.diff(true, func(id string) bool { return alive[id] })
+	if err != nil {
+		return err
+	}
+	err = reconciler.markDeleted(restored, false)
+	if err != nil {
+		return err
+	}
+	logger.Info("reconciled %s: %d marked as deleted, %d restored", reconciler.args.DomainTable.TableName(), len(deleted), len(restored))
+	return nil
+}
+
+// diff returns ids of the domain rows with the given is_deleted flag that satisfy the predicate
+func (reconciler *DataReconciler) diff(isDeleted bool, predicate func(id string) bool) ([]string, errors.Error) {
+	var ids []string
+	err := reconciler.args.Ctx.GetDal().Pluck(
+		"id",
+		&ids,
+		dal.From(reconciler.args.DomainTable),
+		dal.Where("_raw_data_table = ? AND _raw_data_params = ? AND is_deleted = ?", reconciler.table, reconciler.params, isDeleted),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error loading domain ids for reconciliation")
+	}
+	result := make([]string, 0)
+	for _, id := range ids {
+		if predicate(id) {
+			result = append(result, id)
+		}
+	}
+	return result, nil
+}
+
+func (reconciler *DataReconciler) markDeleted(ids []string, isDeleted bool) errors.Error {
+	db := reconciler.args.Ctx.GetDal()
+	for start := 0; start < len(ids); start += reconciler.args.BatchSize {
+		end := start + reconciler.args.BatchSize
+		if end > len(ids) {
+			end = len(ids)
+		}
+		err := db.UpdateColumn(reconciler.args.DomainTable, "is_deleted", isDeleted, dal.Where("id IN ?", ids[start:end]))
+		if err != nil {
+			return errors.Default.Wrap(err, "error updating is_deleted for reconciliation")
+		}
+	}
+	return nil
+}
+
+// Check if DataReconciler implements SubTask interface
+var _ plugin.SubTask = (*DataReconciler)(nil)
@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestDataReconciler(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	pluck := func(isDeleted bool, ids []string) {
+		mockDal.On("Pluck", "id", mock.Anything, mock.MatchedBy(func(clauses []dal.Clause) bool {
+			return clauses[1].Data.(dal.DalClause).Params[2] == isDeleted
+		})).Run(func(args mock.Arguments) {
+			*args.Get(1).(*[]string) = ids
+		}).Return(nil).Once()
+	}
+	pluck(false, []string{"pr:1", "pr:2", "pr:3"})
+	pluck(true, []string{"pr:4", "pr:5"})
+	updated := map[bool][]string{}
+	mockDal.On("UpdateColumn", mock.Anything, "is_deleted", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		ids := args.Get(3).([]dal.Clause)[0].Data.(dal.DalClause).Params[0].([]string)
+		updated[args.Bool(2)] = append(updated[args.Bool(2)], ids...)
+	}).Return(nil)
+
+	reconciler, err := NewDataReconciler(DataReconcilerArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:    unithelper.DummySubTaskContext(mockDal),
+			Table:  "whatever rawtable",
+			Params: &TestOpts{},
+		},
+		DomainTable: &code.PullRequest{},
+		AliveIds:    []string{"pr:1", "pr:3", "pr:4"},
+		BatchSize:   1,
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, reconciler.Execute())
+	assert.Equal(t, []string{"pr:2"}, updated[true])
+	assert.Equal(t, []string{"pr:4"}, updated[false])
+}
+
+func TestDataReconcilerSkipEmptyIds(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	reconciler, err := NewDataReconciler(DataReconcilerArgs{
+		RawDataSubTaskArgs: RawDataSubTaskArgs{
+			Ctx:    unithelper.DummySubTaskContext(mockDal),
+			Table:  "whatever rawtable",
+			Params: &TestOpts{},
+		},
+		DomainTable: &code.PullRequest{},
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, reconciler.Execute())
+	mockDal.AssertNotCalled(t, "Pluck", mock.Anything, mock.Anything, mock.Anything)
+}
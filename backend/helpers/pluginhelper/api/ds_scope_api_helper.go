@@ -95,6 +95,41 @@ func (scopeApi *DsScopeApiHelper[C, S, SC]) GetScopeLatestSyncState(input *plugi
 	}, nil
 }
 
+// PatchScopeLatestSyncState overrides the latest success start of the collectors of the scope by the body
+// `{"raw_data_table": "_raw_xxx", "latest_success_start": "2023-12-01T00:00:00Z"}`, all collectors of the scope
+// are overridden if `raw_data_table` is omitted
+func (scopeApi *DsScopeApiHelper[C, S, SC]) PatchScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	pkv, err := scopeApi.ExtractPkValues(input)
+	if err != nil {
+		return nil, err
+	}
+	override, err := decodeLatestSyncStateOverride(input)
+	if err != nil {
+		return nil, err
+	}
+	scopeLatestSyncStates, err := scopeApi.ScopeSrvHelper.SetScopeLatestSyncState(override, pkv...)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body: scopeLatestSyncStates,
+	}, nil
+}
+
+// DeleteScopeLatestSyncState clears the state of the collector of the `rawDataTable` query, or of all collectors
+// of the scope, so they collect everything on the next run
+func (scopeApi *DsScopeApiHelper[C, S, SC]) DeleteScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	pkv, err := scopeApi.ExtractPkValues(input)
+	if err != nil {
+		return nil, err
+	}
+	err = scopeApi.ScopeSrvHelper.DeleteScopeLatestSyncState(input.Query.Get("rawDataTable"), pkv...)
+	if err != nil {
+		return nil, err
+	}
+	return nil, nil
+}
+
 func (scopeApi *DsScopeApiHelper[C, S, SC]) PutMultiple(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	// fix data[].connectionId
 	connectionId, err := extractConnectionId(input)
@@ -88,6 +88,7 @@ type GraphqlCollector struct {
 	*RawDataSubTask
 	args         *GraphqlCollectorArgs
 	workerErrors []error
+	maxPages     int
 }
 
 // ErrFinishCollect is a error which will finish this collector
@@ -143,6 +144,7 @@ func (collector *GraphqlCollector) Execute() errors.Error {
 	if syncPolicy != nil && syncPolicy.FullSync {
 		isIncremental = false
 	}
+	collector.maxPages = getMaxPages(collector.args.Ctx)
 	// flush data if not incremental collection
 	if isIncremental {
 		// re extract data for new scope config
@@ -231,6 +233,7 @@ func (collector *GraphqlCollector) exec(divider *BatchSaveDivider, input interfa
 // fetchOneByOne fetches data of all pages for APIs that return paging information
 func (collector *GraphqlCollector) fetchOneByOne(divider *BatchSaveDivider, reqData *GraphqlRequestData) {
 	// fetch first page
+	page := 1
 	var fetchNextPage func(query interface{}) errors.Error
 	fetchNextPage = func(query interface{}) errors.Error {
 		pageInfo, err := collector.args.GetPageInfo(query, collector.args)
@@ -240,7 +243,8 @@ func (collector *GraphqlCollector) fetchOneByOne(divider *BatchSaveDivider, reqD
 		if pageInfo == nil {
 			return errors.Default.New("fetchPagesDetermined got pageInfo is nil")
 		}
-		if pageInfo.HasNextPage {
+		if pageInfo.HasNextPage && (collector.maxPages <= 0 || page < collector.maxPages) {
+			page++
 			collector.args.GraphqlClient.NextTick(func() errors.Error {
 				reqDataTemp := &GraphqlRequestData{
 					Pager: &CursorPager{
@@ -377,6 +381,7 @@ func (collector *GraphqlCollector) fetchAsync(divider *BatchSaveDivider, reqData
 
 	logger := collector.args.Ctx.GetLogger()
 	dataErrors, err := collector.args.GraphqlClient.Query(query, variables)
+	recordApiRequests(collector.args.Ctx, 1, 1)
 	if err != nil {
 		if err == context.Canceled {
 			// direct error message for error combine
@@ -0,0 +1,228 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// rowHashTracker implements the change detection of DataConverter. It remembers the hash of every row
+// produced by the last conversion in `_devlake_converter_row_hashes`, so rows that didn't change can be skipped
+// and rows that are no longer produced can be deleted, instead of deleting and rewriting all rows of the scope.
+// Only types with a single string `Id` primary key are tracked, i.e. all DomainEntity
+type rowHashTracker struct {
+	basicRes  context.BasicRes
+	db        dal.Dal
+	log       log.Logger
+	table     string
+	params    string
+	fullSync  bool
+	batchSize int
+	types     map[reflect.Type]*trackedType
+}
+
+type trackedType struct {
+	domainTable dal.Tabler
+	batch       *BatchSave
+	previous    map[string]string
+	current     map[string]string
+}
+
+func newRowHashTracker(basicRes context.BasicRes, table string, params string, fullSync bool, batchSize int) *rowHashTracker {
+	return &rowHashTracker{
+		basicRes:  basicRes,
+		db:        basicRes.GetDal(),
+		log:       basicRes.GetLogger().Nested("row hash tracker"),
+		table:     table,
+		params:    params,
+		fullSync:  fullSync,
+		batchSize: batchSize,
+		types:     make(map[reflect.Type]*trackedType),
+	}
+}
+
+// track returns the BatchSave for the row and whether the row is unchanged since the last conversion,
+// a nil BatchSave means the type is not tracked
+func (t *rowHashTracker) track(row interface{}) (*BatchSave, bool, errors.Error) {
+	rowType := reflect.TypeOf(row)
+	tracked, ok := t.types[rowType]
+	if !ok {
+		var err errors.Error
+		tracked, err = t.init(rowType)
+		if err != nil {
+			return nil, false, err
+		}
+		t.types[rowType] = tracked
+	}
+	if tracked == nil {
+		return nil, false, nil
+	}
+	id := reflect.ValueOf(row).Elem().FieldByName("Id").String()
+	hash, err := rowHash(row)
+	if err != nil {
+		return nil, false, err
+	}
+	tracked.current[id] = hash
+	return tracked.batch, tracked.previous[id] == hash, nil
+}
+
+func (t *rowHashTracker) init(rowType reflect.Type) (*trackedType, errors.Error) {
+	if rowType.Kind() != reflect.Ptr || rowType.Elem().Kind() != reflect.Struct {
+		return nil, nil
+	}
+	primaryKey := t.db.GetPrimaryKeyFields(rowType)
+	if len(primaryKey) != 1 || primaryKey[0].Name != "Id" || primaryKey[0].Type.Kind() != reflect.String {
+		return nil, nil
+	}
+	if field, ok := rowType.Elem().FieldByName("RawDataOrigin"); !ok || field.Type != reflect.TypeOf(common.RawDataOrigin{}) {
+		return nil, nil
+	}
+	domainTable, ok := reflect.New(rowType.Elem()).Interface().(dal.Tabler)
+	if !ok {
+		return nil, nil
+	}
+	batch, err := NewBatchSave(t.basicRes, rowType, t.batchSize)
+	if err != nil {
+		return nil, err
+	}
+	tracked := &trackedType{
+		domainTable: domainTable,
+		batch:       batch,
+		previous:    make(map[string]string),
+		current:     make(map[string]string),
+	}
+
+	// hashes are only trustworthy if the domain rows were not touched by others, i.e. deleted by a full sync
+	reset := t.fullSync
+	if !reset {
+		var hashes []models.ConverterRowHash
+		err = t.db.All(&hashes, dal.Where("domain_table = ? AND raw_data_params = ?", domainTable.TableName(), t.params))
+		if err != nil {
+			return nil, err
+		}
+		count, err := t.db.Count(dal.From(domainTable), t.originClause())
+		if err != nil {
+			return nil, err
+		}
+		if count == int64(len(hashes)) {
+			for _, hash := range hashes {
+				tracked.previous[hash.RowId] = hash.Hash
+			}
+		} else {
+			t.log.Info("%d hashes recorded for %d rows of %s, rewrite all rows", len(hashes), count, domainTable.TableName())
+			reset = true
+		}
+	}
+	if reset {
+		err = t.db.Delete(domainTable, t.originClause())
+		if err != nil {
+			return nil, err
+		}
+		err = t.db.Delete(&models.ConverterRowHash{}, dal.Where("domain_table = ? AND raw_data_params = ?", domainTable.TableName(), t.params))
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tracked, nil
+}
+
+func (t *rowHashTracker) originClause() dal.Clause {
+	return dal.Where("_raw_data_table = ? AND _raw_data_params = ?", t.table, t.params)
+}
+
+// close flushes the tracked rows, deletes the rows that are no longer produced and records the new hashes
+func (t *rowHashTracker) close() errors.Error {
+	for _, tracked := range t.types {
+		if tracked == nil {
+			continue
+		}
+		err := tracked.batch.Close()
+		if err != nil {
+			return err
+		}
+		tableName := tracked.domainTable.TableName()
+		var stale []string
+		for id := range tracked.previous {
+			if _, ok := tracked.current[id]; !ok {
+				stale = append(stale, id)
+			}
+		}
+		var changed []*models.ConverterRowHash
+		for id, hash := range tracked.current {
+			if tracked.previous[id] != hash {
+				changed = append(changed, &models.ConverterRowHash{
+					DomainTable:   tableName,
+					RowId:         id,
+					RawDataParams: t.params,
+					Hash:          hash,
+				})
+			}
+		}
+		for start := 0; start < len(stale); start += t.batchSize {
+			ids := stale[start:minInt(start+t.batchSize, len(stale))]
+			err = t.db.Delete(tracked.domainTable, dal.Where("id IN ?", ids), t.originClause())
+			if err != nil {
+				return err
+			}
+			err = t.db.Delete(&models.ConverterRowHash{}, dal.Where("domain_table = ? AND row_id IN ?", tableName, ids))
+			if err != nil {
+				return err
+			}
+		}
+		for start := 0; start < len(changed); start += t.batchSize {
+			err = t.db.CreateOrUpdate(changed[start:minInt(start+t.batchSize, len(changed))])
+			if err != nil {
+				return err
+			}
+		}
+		t.log.Info("%s: %d rows changed, %d unchanged, %d deleted", tableName, len(changed), len(tracked.current)-len(changed), len(stale))
+	}
+	return nil
+}
+
+// rowHash hashes all fields of the row except the NoPKModel, which holds timestamps and raw data origin
+func rowHash(row interface{}) (string, errors.Error) {
+	value := reflect.New(reflect.TypeOf(row).Elem()).Elem()
+	value.Set(reflect.ValueOf(row).Elem())
+	if noPKModel := value.FieldByName("NoPKModel"); noPKModel.IsValid() && noPKModel.CanSet() {
+		noPKModel.Set(reflect.Zero(noPKModel.Type()))
+	}
+	data, err := json.Marshal(value.Interface())
+	if err != nil {
+		return "", errors.Default.Wrap(err, "error hashing row")
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
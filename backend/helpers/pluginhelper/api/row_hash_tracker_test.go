@@ -0,0 +1,108 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRowHashIgnoresNoPKModel(t *testing.T) {
+	pr1 := &code.PullRequest{DomainEntity: domainlayer.NewDomainEntity("pr:1"), Title: "foo"}
+	pr2 := &code.PullRequest{DomainEntity: domainlayer.NewDomainEntity("pr:1"), Title: "foo"}
+	pr2.CreatedAt = time.Now().Add(time.Hour)
+	pr2.RawDataId = 42
+	hash1, err := rowHash(pr1)
+	assert.Nil(t, err)
+	hash2, err := rowHash(pr2)
+	assert.Nil(t, err)
+	assert.Equal(t, hash1, hash2)
+	// hashing must not touch the row itself
+	assert.Equal(t, uint64(42), pr2.RawDataId)
+
+	pr2.Title = "bar"
+	hash2, err = rowHash(pr2)
+	assert.Nil(t, err)
+	assert.NotEqual(t, hash1, hash2)
+}
+
+func TestRowHashTracker(t *testing.T) {
+	unchanged := &code.PullRequest{DomainEntity: domainlayer.NewDomainEntity("pr:1"), Title: "unchanged"}
+	unchangedHash, _ := rowHash(unchanged)
+	changed := &code.PullRequest{DomainEntity: domainlayer.NewDomainEntity("pr:2"), Title: "changed"}
+
+	mockDal := new(mockdal.Dal)
+	mockDal.On("GetPrimaryKeyFields", reflect.TypeOf(changed)).Return([]reflect.StructField{{Name: "Id", Type: reflect.TypeOf("")}})
+	mockDal.On("GetPrimaryKeyFields", reflect.TypeOf(&common.RawDataOrigin{})).Return([]reflect.StructField{})
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		*args.Get(0).(*[]models.ConverterRowHash) = []models.ConverterRowHash{
+			{DomainTable: "pull_requests", RowId: "pr:1", Hash: unchangedHash},
+			{DomainTable: "pull_requests", RowId: "pr:2", Hash: "outdated"},
+			{DomainTable: "pull_requests", RowId: "pr:3", Hash: "stale"},
+		}
+	}).Return(nil)
+	mockDal.On("Count", mock.Anything).Return(int64(3), nil)
+	var saved []*code.PullRequest
+	var hashes []*models.ConverterRowHash
+	mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		switch rows := args.Get(0).(type) {
+		case []*code.PullRequest:
+			saved = append(saved, rows...)
+		case []*models.ConverterRowHash:
+			hashes = append(hashes, rows...)
+		}
+	}).Return(nil)
+	var deletedIds []interface{}
+	mockDal.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		deletedIds = append(deletedIds, args.Get(1).([]dal.Clause)[0].Data.(dal.DalClause).Params...)
+	}).Return(nil)
+
+	tracker := newRowHashTracker(unithelper.DummySubTaskContext(mockDal), "_raw_table", "params", false, 10)
+	for _, row := range []*code.PullRequest{unchanged, changed} {
+		batch, skip, err := tracker.track(row)
+		assert.Nil(t, err)
+		assert.NotNil(t, batch)
+		assert.Equal(t, row == unchanged, skip)
+		if !skip {
+			assert.Nil(t, batch.Add(row))
+		}
+	}
+	// types without a single string Id are left to the BatchSaveDivider
+	batch, skip, err := tracker.track(&common.RawDataOrigin{})
+	assert.Nil(t, err)
+	assert.Nil(t, batch)
+	assert.False(t, skip)
+
+	assert.Nil(t, tracker.close())
+	assert.Equal(t, []*code.PullRequest{changed}, saved)
+	if assert.Len(t, hashes, 1) {
+		assert.Equal(t, "pr:2", hashes[0].RowId)
+	}
+	assert.Contains(t, deletedIds, []string{"pr:3"})
+}
@@ -18,6 +18,7 @@ limitations under the License.
 package api
 
 import (
+	"encoding/json"
 	"fmt"
 	"reflect"
 	"strconv"
@@ -34,6 +35,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/dbhelper"
 	serviceHelper "github.com/apache/incubator-devlake/helpers/pluginhelper/services"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
 	"github.com/go-playground/validator/v10"
 )
 
@@ -609,16 +611,44 @@ func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) GetScopeLatestSyncSta
 		return nil, err
 	}
 	params := plugin.MarshalScopeParams(scope.Scope.ScopeParams())
-	scopeSyncStates := []*models.LatestSyncState{}
-	if err := gs.db.All(
-		&scopeSyncStates,
-		dal.Select("raw_data_table, latest_success_start, raw_data_params"),
-		dal.From("_devlake_collector_latest_state"),
-		dal.Where("raw_data_params = ?", params),
-	); err != nil {
+	return srvhelper.FindLatestSyncStates(gs.db, params)
+}
+
+// SetScopeLatestSyncState overrides the latest success start of the collectors of the scope for remote plugins,
+// see srvhelper.SetLatestSyncStates
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) SetScopeLatestSyncState(input *plugin.ApiResourceInput) ([]*models.LatestSyncState, errors.Error) {
+	scope, err := gs.GetScope(input)
+	if err != nil {
+		return nil, err
+	}
+	override, err := decodeLatestSyncStateOverride(input)
+	if err != nil {
 		return nil, err
 	}
-	return scopeSyncStates, nil
+	return srvhelper.SetLatestSyncStates(gs.db, plugin.MarshalScopeParams(scope.Scope.ScopeParams()), override)
+}
+
+// DeleteScopeLatestSyncState clears the state of the collectors of the scope for remote plugins,
+// see srvhelper.DeleteLatestSyncStates
+func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) DeleteScopeLatestSyncState(input *plugin.ApiResourceInput) errors.Error {
+	scope, err := gs.GetScope(input)
+	if err != nil {
+		return err
+	}
+	return srvhelper.DeleteLatestSyncStates(gs.db, plugin.MarshalScopeParams(scope.Scope.ScopeParams()), input.Query.Get("rawDataTable"))
+}
+
+func decodeLatestSyncStateOverride(input *plugin.ApiResourceInput) (*models.LatestSyncState, errors.Error) {
+	blob, err := errors.Convert01(json.Marshal(input.Body))
+	if err != nil {
+		return nil, err
+	}
+	override := &models.LatestSyncState{}
+	err = errors.Convert(json.Unmarshal(blob, override))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid latest sync state")
+	}
+	return override, nil
 }
 
 func (gs *GenericScopeApiHelper[Conn, Scope, ScopeConfig]) getAffectedTables(pluginName string) ([]string, errors.Error) {
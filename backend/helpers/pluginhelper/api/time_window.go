@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/url"
+	"time"
+)
+
+// TimeWindow is the range of time a collector is interested in, a nil bound means unbounded
+type TimeWindow struct {
+	Since *time.Time
+	Until *time.Time
+}
+
+// TimeWindowAdapter translates the TimeWindow into provider-specific query params,
+// i.e. `updated_after`/`updated_before` for GitLab
+type TimeWindowAdapter func(query url.Values, window *TimeWindow)
+
+// NewTimeWindowAdapter creates a TimeWindowAdapter that formats the bounds with `layout` and sets them
+// to the `sinceParam` and `untilParam` query params, leave a param name empty to skip the bound
+func NewTimeWindowAdapter(sinceParam string, untilParam string, layout string) TimeWindowAdapter {
+	return func(query url.Values, window *TimeWindow) {
+		if sinceParam != "" && window.Since != nil {
+			query.Set(sinceParam, window.Since.Format(layout))
+		}
+		if untilParam != "" && window.Until != nil {
+			query.Set(untilParam, window.Until.Format(layout))
+		}
+	}
+}
+
+func (collector *ApiCollector) applyTimeWindow(query url.Values) url.Values {
+	if collector.args.TimeWindow == nil || collector.args.TimeWindowAdapter == nil {
+		return query
+	}
+	if query == nil {
+		query = url.Values{}
+	}
+	collector.args.TimeWindowAdapter(query, collector.args.TimeWindow)
+	return query
+}
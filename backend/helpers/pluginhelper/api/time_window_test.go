@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewTimeWindowAdapter(t *testing.T) {
+	since := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	until := time.Date(2023, 2, 3, 4, 5, 6, 0, time.UTC)
+	adapter := NewTimeWindowAdapter("updated_after", "updated_before", time.RFC3339)
+
+	query := url.Values{}
+	adapter(query, &TimeWindow{Since: &since, Until: &until})
+	assert.Equal(t, "2023-01-02T03:04:05Z", query.Get("updated_after"))
+	assert.Equal(t, "2023-02-03T04:05:06Z", query.Get("updated_before"))
+
+	query = url.Values{}
+	adapter(query, &TimeWindow{})
+	assert.Empty(t, query)
+
+	query = url.Values{}
+	NewTimeWindowAdapter("since", "", time.RFC3339)(query, &TimeWindow{Since: &since, Until: &until})
+	assert.Equal(t, url.Values{"since": []string{"2023-01-02T03:04:05Z"}}, query)
+}
+
+func TestApplyTimeWindow(t *testing.T) {
+	since := time.Date(2023, 1, 2, 3, 4, 5, 0, time.UTC)
+	collector := &ApiCollector{args: &ApiCollectorArgs{}}
+	assert.Nil(t, collector.applyTimeWindow(nil))
+
+	collector.args.TimeWindow = &TimeWindow{Since: &since}
+	collector.args.TimeWindowAdapter = NewTimeWindowAdapter("since", "", time.RFC3339)
+	assert.Equal(t, "2023-01-02T03:04:05Z", collector.applyTimeWindow(nil).Get("since"))
+}
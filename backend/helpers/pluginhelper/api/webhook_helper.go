@@ -0,0 +1,412 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha1" // #nosec G505 some servers, i.e. bitbucket server, still sign with sha1
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/log"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// WebhookEvent is a verified delivery handed over to the handler of its event type
+type WebhookEvent struct {
+	ConnectionId uint64
+	DeliveryId   string
+	EventType    string
+	Payload      []byte
+}
+
+// WebhookEventHandler converts the payload into tool layer and/or domain layer records, they are saved by
+// CreateOrUpdate in a single transaction, so handling the same payload twice leaves the same records
+type WebhookEventHandler func(event *WebhookEvent) ([]interface{}, errors.Error)
+
+// WebhookSignatureVerifier checks the request was sent by the remote server owning the secret
+type WebhookSignatureVerifier interface {
+	Verify(header http.Header, payload []byte, secret string) errors.Error
+}
+
+// HmacSignatureVerifier verifies the hex encoded HMAC of the payload carried by the header,
+// i.e. `X-Hub-Signature-256: sha256=<hmac>` for github and bitbucket server
+type HmacSignatureVerifier struct {
+	Header string
+	Prefix string
+	// Hash defaults to sha256.New
+	Hash func() hash.Hash
+}
+
+func (v *HmacSignatureVerifier) Verify(header http.Header, payload []byte, secret string) errors.Error {
+	signature := header.Get(v.Header)
+	if len(signature) < len(v.Prefix) || signature[:len(v.Prefix)] != v.Prefix {
+		return errors.Unauthorized.New(fmt.Sprintf("missing or malformed %s header", v.Header))
+	}
+	expected, err := hex.DecodeString(signature[len(v.Prefix):])
+	if err != nil {
+		return errors.Unauthorized.New(fmt.Sprintf("malformed %s header", v.Header))
+	}
+	hashFunc := v.Hash
+	if hashFunc == nil {
+		hashFunc = sha256.New
+	}
+	mac := hmac.New(hashFunc, []byte(secret))
+	mac.Write(payload)
+	if !hmac.Equal(mac.Sum(nil), expected) {
+		return errors.Unauthorized.New("signature mismatched")
+	}
+	return nil
+}
+
+// NewSha1HmacSignatureVerifier is for the servers still signing with sha1, i.e. `X-Hub-Signature: sha1=<hmac>`
+func NewSha1HmacSignatureVerifier(header string, prefix string) *HmacSignatureVerifier {
+	return &HmacSignatureVerifier{Header: header, Prefix: prefix, Hash: sha1.New}
+}
+
+// TokenSignatureVerifier verifies the header carries the secret as is, i.e. `X-Gitlab-Token` for gitlab
+type TokenSignatureVerifier struct {
+	Header string
+}
+
+func (v *TokenSignatureVerifier) Verify(header http.Header, _ []byte, secret string) errors.Error {
+	if subtle.ConstantTimeCompare([]byte(header.Get(v.Header)), []byte(secret)) != 1 {
+		return errors.Unauthorized.New(fmt.Sprintf("missing or mismatched %s header", v.Header))
+	}
+	return nil
+}
+
+// WebhookHelperArgs configures the webhook endpoints of a plugin
+type WebhookHelperArgs struct {
+	PluginName string
+	// Verifier is required unless the plugin accepts unsigned payloads
+	Verifier WebhookSignatureVerifier
+	// GetSecret returns the webhook secret configured on the connection
+	GetSecret func(connectionId uint64) (string, errors.Error)
+	// EventTypeHeader names the header carrying the event type, i.e. `X-GitHub-Event`, the `EventTypeField` of the
+	// payload is used when the header is absent, i.e. `webhookEvent` for jira
+	EventTypeHeader string
+	EventTypeField  string
+	// DeliveryIdHeader names the header identifying the delivery, i.e. `X-GitHub-Delivery`, the hash of the payload
+	// is used when the header is absent
+	DeliveryIdHeader string
+	// Handlers by event type, a payload of an event type without handler is recorded as ignored
+	Handlers map[string]WebhookEventHandler
+	// DeliveryRetention is how long the DONE and IGNORED deliveries are kept to detect the duplicates, it defaults
+	// to 7 days, the FAILED ones are kept until they are replayed
+	DeliveryRetention time.Duration
+}
+
+const (
+	defaultWebhookDeliveryRetention = 7 * 24 * time.Hour
+	// webhookPruneInterval throttles the pruning of the deliveries, which runs on the received payloads
+	webhookPruneInterval = time.Hour
+	// staleWebhookProcessing is when a delivery still PROCESSING is considered lost, i.e. by a restart
+	staleWebhookProcessing = 10 * time.Minute
+)
+
+// WebhookHelper implements the webhook endpoints shared by the plugins: it verifies the signature, routes the
+// payload to the handler of its event type, records the delivery in `_devlake_webhook_deliveries` so a delivery
+// sent twice is only processed once, and keeps the failed ones to be replayed
+type WebhookHelper struct {
+	basicRes context.BasicRes
+	db       dal.Dal
+	log      log.Logger
+	args     *WebhookHelperArgs
+
+	pruneLock sync.Mutex
+	prunedAt  time.Time
+}
+
+func NewWebhookHelper(basicRes context.BasicRes, args *WebhookHelperArgs) (*WebhookHelper, errors.Error) {
+	if args.PluginName == "" {
+		return nil, errors.Default.New("PluginName is required")
+	}
+	if args.Verifier != nil && args.GetSecret == nil {
+		return nil, errors.Default.New("GetSecret is required to verify the signature")
+	}
+	if len(args.Handlers) == 0 {
+		return nil, errors.Default.New("Handlers is required")
+	}
+	if args.DeliveryRetention == 0 {
+		args.DeliveryRetention = defaultWebhookDeliveryRetention
+	}
+	return &WebhookHelper{
+		basicRes: basicRes,
+		db:       basicRes.GetDal(),
+		log:      basicRes.GetLogger().Nested(fmt.Sprintf("%s webhook", args.PluginName)),
+		args:     args,
+	}, nil
+}
+
+// ApiResources returns the endpoints to be merged into the ApiResources of the plugin
+func (h *WebhookHelper) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
+	return map[string]map[string]plugin.ApiResourceHandler{
+		"connections/:connectionId/webhook": {
+			"POST": h.Post,
+		},
+		"connections/:connectionId/webhook/deliveries": {
+			"GET": h.GetDeliveries,
+		},
+		"connections/:connectionId/webhook/replay": {
+			"POST": h.Replay,
+		},
+	}
+}
+
+// Post receives a payload sent by the remote server, the event type is read from the header or the payload
+func (h *WebhookHelper) Post(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var header http.Header
+	if input.Request != nil {
+		header = input.Request.Header
+	}
+	return h.post(input, webhookEventType(header.Get(h.args.EventTypeHeader), h.args.EventTypeField, input.Body))
+}
+
+// PostEvent receives a payload of the event type, for the endpoints dedicated to an event type
+func (h *WebhookHelper) PostEvent(eventType string, input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return h.post(input, eventType)
+}
+
+func (h *WebhookHelper) post(input *plugin.ApiResourceInput, eventType string) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := parseWebhookConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	payload := input.RawBody
+	if len(payload) == 0 {
+		payload, err = errors.Convert01(json.Marshal(input.Body))
+		if err != nil {
+			return nil, err
+		}
+	}
+	var header http.Header
+	if input.Request != nil {
+		header = input.Request.Header
+	}
+	if h.args.Verifier != nil {
+		secret, err := h.args.GetSecret(connectionId)
+		if err != nil {
+			return nil, err
+		}
+		if secret == "" {
+			return nil, errors.Unauthorized.New("webhook secret is not configured on the connection")
+		}
+		err = h.args.Verifier.Verify(header, payload, secret)
+		if err != nil {
+			return nil, err
+		}
+	}
+	now := time.Now()
+	h.pruneDeliveries(now)
+	delivery := &models.WebhookDelivery{
+		Plugin:       h.args.PluginName,
+		ConnectionId: connectionId,
+		DeliveryId:   webhookDeliveryId(header.Get(h.args.DeliveryIdHeader), eventType, payload),
+		EventType:    eventType,
+		Payload:      payload,
+		Status:       models.WEBHOOK_DELIVERY_PROCESSING,
+		ReceivedAt:   now,
+	}
+	// the primary key claims the delivery, so a duplicate received concurrently is not processed twice
+	err = h.db.Create(delivery)
+	if err != nil {
+		if !h.db.IsDuplicationError(err) {
+			return nil, err
+		}
+		previous := &models.WebhookDelivery{}
+		err = h.db.First(previous, dal.Where(
+			"plugin = ? AND connection_id = ? AND delivery_id = ?",
+			delivery.Plugin, delivery.ConnectionId, delivery.DeliveryId,
+		))
+		if err != nil {
+			return nil, err
+		}
+		if previous.Status != models.WEBHOOK_DELIVERY_FAILED {
+			return &plugin.ApiResourceOutput{Body: previous}, nil
+		}
+		// a failed delivery sent again is retried, like Replay does
+		delivery.Attempts = previous.Attempts
+	}
+	err = h.process(delivery)
+	if err != nil {
+		return &plugin.ApiResourceOutput{Body: delivery}, err
+	}
+	return &plugin.ApiResourceOutput{Body: delivery}, nil
+}
+
+// GetDeliveries lists the recent deliveries of the connection, the `status` query filters them, i.e. FAILED
+func (h *WebhookHelper) GetDeliveries(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := parseWebhookConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	clauses := []dal.Clause{
+		dal.Where("plugin = ? AND connection_id = ?", h.args.PluginName, connectionId),
+	}
+	if status := input.Query.Get("status"); status != "" {
+		clauses = append(clauses, dal.Where("status = ?", status))
+	}
+	limit, offset := GetLimitOffset(input.Query, "pageSize", "page")
+	clauses = append(clauses, dal.Orderby("received_at DESC"), dal.Limit(limit), dal.Offset(offset))
+	var deliveries []*models.WebhookDelivery
+	err = h.db.All(&deliveries, clauses...)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: deliveries}, nil
+}
+
+// Replay processes the failed deliveries of the connection again in the order they were received,
+// i.e. once the handler is fixed, the deliveries lost while PROCESSING are replayed as well
+func (h *WebhookHelper) Replay(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	connectionId, err := parseWebhookConnectionId(input)
+	if err != nil {
+		return nil, err
+	}
+	var deliveries []*models.WebhookDelivery
+	err = h.db.All(&deliveries,
+		dal.Where(
+			"plugin = ? AND connection_id = ? AND (status = ? OR (status = ? AND received_at < ?))",
+			h.args.PluginName, connectionId, models.WEBHOOK_DELIVERY_FAILED,
+			models.WEBHOOK_DELIVERY_PROCESSING, time.Now().Add(-staleWebhookProcessing),
+		),
+		dal.Orderby("received_at"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, delivery := range deliveries {
+		// the failures are recorded in the deliveries
+		_ = h.process(delivery)
+	}
+	return &plugin.ApiResourceOutput{Body: deliveries}, nil
+}
+
+// process hands the delivery over to its handler and records the outcome
+func (h *WebhookHelper) process(delivery *models.WebhookDelivery) errors.Error {
+	delivery.Attempts++
+	delivery.Message = ""
+	handler, ok := h.args.Handlers[delivery.EventType]
+	var err errors.Error
+	if !ok {
+		delivery.Status = models.WEBHOOK_DELIVERY_IGNORED
+		delivery.Message = fmt.Sprintf("no handler for event type %s", delivery.EventType)
+	} else {
+		err = h.handle(handler, delivery)
+		if err != nil {
+			h.log.Error(err, "failed to process the delivery %s", delivery.DeliveryId)
+			delivery.Status = models.WEBHOOK_DELIVERY_FAILED
+			delivery.Message = err.Error()
+		} else {
+			delivery.Status = models.WEBHOOK_DELIVERY_DONE
+		}
+	}
+	now := time.Now()
+	delivery.ProcessedAt = &now
+	if saveErr := h.db.CreateOrUpdate(delivery); saveErr != nil {
+		return saveErr
+	}
+	return err
+}
+
+func (h *WebhookHelper) handle(handler WebhookEventHandler, delivery *models.WebhookDelivery) (err errors.Error) {
+	records, err := handler(&WebhookEvent{
+		ConnectionId: delivery.ConnectionId,
+		DeliveryId:   delivery.DeliveryId,
+		EventType:    delivery.EventType,
+		Payload:      delivery.Payload,
+	})
+	if err != nil {
+		return err
+	}
+	tx := h.db.Begin()
+	defer func() {
+		if err != nil {
+			_ = tx.Rollback()
+		}
+	}()
+	for _, record := range records {
+		err = tx.CreateOrUpdate(record)
+		if err != nil {
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// pruneDeliveries deletes the DONE and IGNORED deliveries older than the retention, at most once per interval
+func (h *WebhookHelper) pruneDeliveries(now time.Time) {
+	h.pruneLock.Lock()
+	defer h.pruneLock.Unlock()
+	if now.Sub(h.prunedAt) < webhookPruneInterval {
+		return
+	}
+	h.prunedAt = now
+	err := h.db.Delete(&models.WebhookDelivery{}, dal.Where(
+		"plugin = ? AND status IN ? AND received_at < ?",
+		h.args.PluginName,
+		[]string{models.WEBHOOK_DELIVERY_DONE, models.WEBHOOK_DELIVERY_IGNORED},
+		now.Add(-h.args.DeliveryRetention),
+	))
+	if err != nil {
+		h.log.Warn(err, "failed to prune the webhook deliveries")
+	}
+}
+
+func parseWebhookConnectionId(input *plugin.ApiResourceInput) (uint64, errors.Error) {
+	connectionId, err := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if err != nil || connectionId == 0 {
+		return 0, errors.BadInput.New("invalid connectionId")
+	}
+	return connectionId, nil
+}
+
+// webhookDeliveryId falls back to the hash of the event type and the payload, the same payload posted to
+// different event types are different deliveries
+func webhookDeliveryId(headerValue string, eventType string, payload []byte) string {
+	if headerValue != "" {
+		return headerValue
+	}
+	hash := sha256.New()
+	hash.Write([]byte(eventType + "\n"))
+	hash.Write(payload)
+	return hex.EncodeToString(hash.Sum(nil))
+}
+
+func webhookEventType(headerValue string, field string, body map[string]interface{}) string {
+	if headerValue != "" || field == "" {
+		return headerValue
+	}
+	if value, ok := body[field].(string); ok {
+		return value
+	}
+	return ""
+}
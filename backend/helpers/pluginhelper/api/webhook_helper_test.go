@@ -0,0 +1,179 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHmacSignatureVerifier(t *testing.T) {
+	payload := []byte(`{"action":"opened"}`)
+	mac := hmac.New(sha256.New, []byte("secret"))
+	mac.Write(payload)
+	signature := "sha256=" + hex.EncodeToString(mac.Sum(nil))
+	verifier := &HmacSignatureVerifier{Header: "X-Hub-Signature-256", Prefix: "sha256="}
+
+	assert.Nil(t, verifier.Verify(http.Header{"X-Hub-Signature-256": {signature}}, payload, "secret"))
+	assert.NotNil(t, verifier.Verify(http.Header{"X-Hub-Signature-256": {signature}}, payload, "other"))
+	assert.NotNil(t, verifier.Verify(http.Header{"X-Hub-Signature-256": {signature}}, []byte(`{}`), "secret"))
+	assert.NotNil(t, verifier.Verify(http.Header{"X-Hub-Signature-256": {"sha256=zz"}}, payload, "secret"))
+	assert.NotNil(t, verifier.Verify(http.Header{}, payload, "secret"))
+}
+
+func TestTokenSignatureVerifier(t *testing.T) {
+	verifier := &TokenSignatureVerifier{Header: "X-Gitlab-Token"}
+	assert.Nil(t, verifier.Verify(http.Header{"X-Gitlab-Token": {"secret"}}, nil, "secret"))
+	assert.NotNil(t, verifier.Verify(http.Header{"X-Gitlab-Token": {"other"}}, nil, "secret"))
+	assert.NotNil(t, verifier.Verify(http.Header{}, nil, "secret"))
+}
+
+func TestWebhookEventTypeAndDeliveryId(t *testing.T) {
+	assert.Equal(t, "issues", webhookEventType("issues", "webhookEvent", map[string]interface{}{"webhookEvent": "jira:issue_updated"}))
+	assert.Equal(t, "jira:issue_updated", webhookEventType("", "webhookEvent", map[string]interface{}{"webhookEvent": "jira:issue_updated"}))
+	assert.Equal(t, "", webhookEventType("", "", nil))
+
+	assert.Equal(t, "abc", webhookDeliveryId("abc", "issues", []byte(`{}`)))
+	assert.Equal(t, webhookDeliveryId("", "issues", []byte(`{"a":1}`)), webhookDeliveryId("", "issues", []byte(`{"a":1}`)))
+	assert.NotEqual(t, webhookDeliveryId("", "issues", []byte(`{"a":1}`)), webhookDeliveryId("", "issues", []byte(`{"a":2}`)))
+	assert.NotEqual(t, webhookDeliveryId("", "issues", []byte(`{"a":1}`)), webhookDeliveryId("", "deployments", []byte(`{"a":1}`)))
+}
+
+type webhookTestRecord struct {
+	Id string
+}
+
+func TestWebhookHelperPost(t *testing.T) {
+	deliveries := make(map[string]*models.WebhookDelivery)
+	var saved []interface{}
+	pruned := 0
+	basicRes := unithelper.DummyBasicRes(func(mockDal *mockdal.Dal) {
+		// the primary key rejects a delivery received before
+		mockDal.On("Create", mock.Anything, mock.Anything).Return(func(entity interface{}, clauses ...dal.Clause) errors.Error {
+			delivery := *entity.(*models.WebhookDelivery)
+			if _, ok := deliveries[delivery.DeliveryId]; ok {
+				return errors.BadInput.New("duplicate key")
+			}
+			deliveries[delivery.DeliveryId] = &delivery
+			return nil
+		})
+		mockDal.On("IsDuplicationError", mock.Anything).Return(true)
+		mockDal.On("Delete", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			pruned++
+		}).Return(nil)
+		mockDal.On("First", mock.Anything, mock.Anything).Return(func(dst interface{}, clauses ...dal.Clause) errors.Error {
+			deliveryId := clauses[0].Data.(dal.DalClause).Params[2].(string)
+			if delivery, ok := deliveries[deliveryId]; ok {
+				*dst.(*models.WebhookDelivery) = *delivery
+				return nil
+			}
+			return errors.NotFound.New("not found")
+		})
+		mockDal.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			delivery := *args.Get(0).(*models.WebhookDelivery)
+			deliveries[delivery.DeliveryId] = &delivery
+		}).Return(nil)
+		tx := new(mockdal.Transaction)
+		tx.On("CreateOrUpdate", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+			saved = append(saved, args.Get(0))
+		}).Return(nil)
+		tx.On("Commit").Return(nil)
+		mockDal.On("Begin").Return(tx)
+	})
+
+	handled := 0
+	failing := true
+	helper, err := NewWebhookHelper(basicRes, &WebhookHelperArgs{
+		PluginName:       "github",
+		Verifier:         &TokenSignatureVerifier{Header: "X-Token"},
+		GetSecret:        func(connectionId uint64) (string, errors.Error) { return "secret", nil },
+		EventTypeHeader:  "X-Event",
+		DeliveryIdHeader: "X-Delivery",
+		Handlers: map[string]WebhookEventHandler{
+			"issues": func(event *WebhookEvent) ([]interface{}, errors.Error) {
+				handled++
+				if failing {
+					return nil, errors.Default.New("boom")
+				}
+				return []interface{}{&webhookTestRecord{Id: event.DeliveryId}}, nil
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	post := func(event string, delivery string, token string) (*plugin.ApiResourceOutput, errors.Error) {
+		request, _ := http.NewRequest(http.MethodPost, "/", nil)
+		request.Header.Set("X-Event", event)
+		request.Header.Set("X-Delivery", delivery)
+		request.Header.Set("X-Token", token)
+		return helper.Post(&plugin.ApiResourceInput{
+			Params:  map[string]string{"connectionId": "1"},
+			RawBody: []byte(`{"action":"opened"}`),
+			Request: request,
+		})
+	}
+
+	_, err = post("issues", "1", "wrong")
+	assert.NotNil(t, err)
+	assert.Equal(t, 0, handled)
+
+	// a failure is recorded to be replayed
+	_, err = post("issues", "1", "secret")
+	assert.NotNil(t, err)
+	assert.Equal(t, models.WEBHOOK_DELIVERY_FAILED, deliveries["1"].Status)
+	assert.Equal(t, []byte(`{"action":"opened"}`), deliveries["1"].Payload)
+
+	// the same delivery sent again is retried
+	failing = false
+	_, err = post("issues", "1", "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, models.WEBHOOK_DELIVERY_DONE, deliveries["1"].Status)
+	assert.Equal(t, 2, deliveries["1"].Attempts)
+	assert.Equal(t, []interface{}{&webhookTestRecord{Id: "1"}}, saved)
+
+	// and processed only once
+	_, err = post("issues", "1", "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, handled)
+
+	// a delivery still processing is not processed again
+	deliveries["3"] = &models.WebhookDelivery{DeliveryId: "3", Status: models.WEBHOOK_DELIVERY_PROCESSING}
+	_, err = post("issues", "3", "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, 2, handled)
+
+	_, err = post("ping", "2", "secret")
+	assert.Nil(t, err)
+	assert.Equal(t, models.WEBHOOK_DELIVERY_IGNORED, deliveries["2"].Status)
+	assert.Equal(t, 2, handled)
+
+	// the old deliveries are pruned at most once per interval
+	assert.Equal(t, 1, pruned)
+}
@@ -53,6 +53,7 @@ type WorkerScheduler struct {
 	counter      int32
 	logger       log.Logger
 	tickInterval time.Duration
+	pausedUntil  int64
 }
 
 //var callframeEnabled = os.Getenv("ASYNC_CF") == "true"
@@ -108,6 +109,9 @@ func (s *WorkerScheduler) SubmitBlocking(task func() errors.Error) {
 		case <-s.ctx.Done():
 			panic(s.ctx.Err())
 		case <-s.ticker.C:
+			if err := s.waitForPause(); err != nil {
+				panic(err)
+			}
 			err := task()
 			if err != nil {
 				panic(err)
@@ -181,6 +185,34 @@ func (s *WorkerScheduler) Reset(interval time.Duration) {
 	s.ticker.Reset(interval)
 }
 
+// Pause holds off all tasks that haven't started yet for the specified duration, i.e. when the server
+// asks us to back off. Overlapping pauses are merged, the latest end wins
+func (s *WorkerScheduler) Pause(duration time.Duration) {
+	until := time.Now().Add(duration).UnixNano()
+	for {
+		current := atomic.LoadInt64(&s.pausedUntil)
+		if until <= current || atomic.CompareAndSwapInt64(&s.pausedUntil, current, until) {
+			return
+		}
+	}
+}
+
+func (s *WorkerScheduler) waitForPause() error {
+	for {
+		wait := time.Until(time.Unix(0, atomic.LoadInt64(&s.pausedUntil)))
+		if wait <= 0 {
+			return nil
+		}
+		timer := time.NewTimer(wait)
+		select {
+		case <-s.ctx.Done():
+			timer.Stop()
+			return s.ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
 // GetTickInterval returns current tick interval of the WorkScheduler
 func (s *WorkerScheduler) GetTickInterval() time.Duration {
 	return s.tickInterval
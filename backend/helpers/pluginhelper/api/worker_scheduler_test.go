@@ -64,3 +64,19 @@ func TestWorkerSchedulerQpsControl(t *testing.T) {
 	}
 	cancel()
 }
+
+func TestWorkerSchedulerPause(t *testing.T) {
+	s, _ := NewWorkerScheduler(context.Background(), 1, time.Millisecond, unithelper.DummyLogger())
+	defer s.Release()
+	s.Pause(300 * time.Millisecond)
+	// a shorter pause must not cut the longer one short
+	s.Pause(time.Millisecond)
+	startedAt := time.Now()
+	var executedAt time.Time
+	s.SubmitBlocking(func() errors.Error {
+		executedAt = time.Now()
+		return nil
+	})
+	assert.Nil(t, s.WaitAsync())
+	assert.GreaterOrEqual(t, executedAt.Sub(startedAt), 250*time.Millisecond)
+}
@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+)
+
+// FindLatestSyncStates returns the states of the stateful collectors of a scope, the latest success start is the
+// `since` of their next incremental collection
+func FindLatestSyncStates(db dal.Dal, rawDataParams string) ([]*models.LatestSyncState, errors.Error) {
+	states := []*models.LatestSyncState{}
+	err := db.All(
+		&states,
+		dal.Select("raw_data_table, latest_success_start, time_after, raw_data_params"),
+		dal.From(models.CollectorLatestState{}.TableName()),
+		dal.Where("raw_data_params = ?", rawDataParams),
+	)
+	return states, err
+}
+
+// SetLatestSyncStates overrides the latest success start of the collector of the raw table of the scope, or of all
+// its collectors when no raw table is given, so the next run collects the data updated after it,
+// a nil latest success start makes the next run collect everything
+func SetLatestSyncStates(db dal.Dal, rawDataParams string, override *models.LatestSyncState) ([]*models.LatestSyncState, errors.Error) {
+	if override.RawDataTable != "" && !strings.HasPrefix(override.RawDataTable, "_raw_") {
+		return nil, errors.BadInput.New("raw_data_table must be a raw table")
+	}
+	if override.LatestSuccessStart != nil && override.LatestSuccessStart.After(time.Now()) {
+		return nil, errors.BadInput.New("latest_success_start must not be in the future")
+	}
+	if override.RawDataTable == "" {
+		err := db.UpdateColumn(
+			&models.CollectorLatestState{},
+			"latest_success_start", override.LatestSuccessStart,
+			dal.Where("raw_data_params = ?", rawDataParams),
+		)
+		if err != nil {
+			return nil, err
+		}
+		return FindLatestSyncStates(db, rawDataParams)
+	}
+	state := &models.CollectorLatestState{}
+	err := db.First(state, dal.Where("raw_data_table = ? AND raw_data_params = ?", override.RawDataTable, rawDataParams))
+	if err == nil {
+		err = db.UpdateColumn(
+			&models.CollectorLatestState{},
+			"latest_success_start", override.LatestSuccessStart,
+			dal.Where("raw_data_table = ? AND raw_data_params = ?", override.RawDataTable, rawDataParams),
+		)
+	} else if db.IsErrorNotFound(err) {
+		// the collector never ran for the scope, the state is created so its first run starts from there
+		err = db.Create(&models.CollectorLatestState{
+			RawDataTable:       override.RawDataTable,
+			RawDataParams:      rawDataParams,
+			LatestSuccessStart: override.LatestSuccessStart,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+	return FindLatestSyncStates(db, rawDataParams)
+}
+
+// DeleteLatestSyncStates clears the state of the collector of the raw table of the scope, or of all its collectors
+// when no raw table is given, so the next run collects everything again from the time after of the sync policy
+func DeleteLatestSyncStates(db dal.Dal, rawDataParams string, rawDataTable string) errors.Error {
+	clauses := []dal.Clause{dal.Where("raw_data_params = ?", rawDataParams)}
+	if rawDataTable != "" {
+		clauses = append(clauses, dal.Where("raw_data_table = ?", rawDataTable))
+	}
+	return db.Delete(&models.CollectorLatestState{}, clauses...)
+}
@@ -0,0 +1,66 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package srvhelper
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestSetLatestSyncStates(t *testing.T) {
+	future := time.Now().Add(time.Hour)
+	_, err := SetLatestSyncStates(new(mockdal.Dal), "{}", &models.LatestSyncState{LatestSuccessStart: &future})
+	assert.NotNil(t, err)
+	_, err = SetLatestSyncStates(new(mockdal.Dal), "{}", &models.LatestSyncState{RawDataTable: "_tool_github_issues"})
+	assert.NotNil(t, err)
+
+	// the state is created for a collector that never ran
+	since := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	var created *models.CollectorLatestState
+	mockDal := new(mockdal.Dal)
+	mockDal.On("First", mock.Anything, mock.Anything).Return(errors.NotFound.New("not found"))
+	mockDal.On("IsErrorNotFound", mock.Anything).Return(true)
+	mockDal.On("Create", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		created = args.Get(0).(*models.CollectorLatestState)
+	}).Return(nil)
+	mockDal.On("All", mock.Anything, mock.Anything).Return(nil)
+	_, err = SetLatestSyncStates(mockDal, "{\"ConnectionId\":1}", &models.LatestSyncState{
+		RawDataTable:       "_raw_github_api_issues",
+		LatestSuccessStart: &since,
+	})
+	assert.Nil(t, err)
+	assert.Equal(t, &models.CollectorLatestState{
+		RawDataTable:       "_raw_github_api_issues",
+		RawDataParams:      "{\"ConnectionId\":1}",
+		LatestSuccessStart: &since,
+	}, created)
+
+	// all collectors of the scope are overridden when no raw table is given
+	mockDal = new(mockdal.Dal)
+	mockDal.On("UpdateColumn", mock.Anything, "latest_success_start", (*time.Time)(nil), mock.Anything).Return(nil).Once()
+	mockDal.On("All", mock.Anything, mock.Anything).Return(nil)
+	_, err = SetLatestSyncStates(mockDal, "{\"ConnectionId\":1}", &models.LatestSyncState{})
+	assert.Nil(t, err)
+	mockDal.AssertExpectations(t)
+}
@@ -29,6 +29,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/domaininfo"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/utils"
 )
 
 type ScopePagination struct {
@@ -102,13 +103,8 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) GetScopeLatestSyncState(pkv ...interfa
 	s := *scope
 	params := plugin.MarshalScopeParams(s.ScopeParams())
 	scopeSrv.log.Debug("scope: %#+v, params: %+v", s, params)
-	scopeSyncStates := []*models.LatestSyncState{}
-	if err := scopeSrv.db.All(
-		&scopeSyncStates,
-		dal.Select("raw_data_table, latest_success_start, raw_data_params"),
-		dal.From("_devlake_collector_latest_state"),
-		dal.Where("raw_data_params = ?", params),
-	); err != nil {
+	scopeSyncStates, err := FindLatestSyncStates(scopeSrv.db, params)
+	if err != nil {
 		return nil, err
 	}
 	scopeSrv.log.Debug("param: %+v, resp: %+v", scopeSyncStates)
@@ -121,6 +117,24 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) GetScopeLatestSyncState(pkv ...interfa
 	return scopeSyncStates, nil
 }
 
+// SetScopeLatestSyncState overrides the latest success start of the collectors of the scope, see SetLatestSyncStates
+func (scopeSrv *ScopeSrvHelper[C, S, SC]) SetScopeLatestSyncState(override *models.LatestSyncState, pkv ...interface{}) ([]*models.LatestSyncState, errors.Error) {
+	scope, err := scopeSrv.ModelSrvHelper.FindByPk(pkv...)
+	if err != nil {
+		return nil, err
+	}
+	return SetLatestSyncStates(scopeSrv.db, plugin.MarshalScopeParams((*scope).ScopeParams()), override)
+}
+
+// DeleteScopeLatestSyncState clears the state of the collectors of the scope, see DeleteLatestSyncStates
+func (scopeSrv *ScopeSrvHelper[C, S, SC]) DeleteScopeLatestSyncState(rawDataTable string, pkv ...interface{}) errors.Error {
+	scope, err := scopeSrv.ModelSrvHelper.FindByPk(pkv...)
+	if err != nil {
+		return err
+	}
+	return DeleteLatestSyncStates(scopeSrv.db, plugin.MarshalScopeParams((*scope).ScopeParams()), rawDataTable)
+}
+
 // MapScopeDetails returns scope details (scope and scopeConfig) for the given blueprint scopes
 func (scopeSrv *ScopeSrvHelper[C, S, SC]) MapScopeDetails(connectionId uint64, bpScopes []*models.BlueprintScope) ([]*ScopeDetail[S, SC], errors.Error) {
 	var err errors.Error
@@ -133,6 +147,10 @@ func (scopeSrv *ScopeSrvHelper[C, S, SC]) MapScopeDetails(connectionId uint64, b
 		if scopeDetails[i].ScopeConfig == nil {
 			scopeDetails[i].ScopeConfig = new(SC)
 		}
+		err = ApplyScopeConfigOverride(bpScope, scopeDetails[i].ScopeConfig)
+		if err != nil {
+			return nil, err
+		}
 		setDefaultEntities(scopeDetails[i].ScopeConfig)
 	}
 	return scopeDetails, nil
@@ -321,6 +339,20 @@ func reflectType(obj any) reflect.Type {
 	return typ
 }
 
+// ApplyScopeConfigOverride patches the ScopeConfigOverride of the blueprint scope, if any, onto the scope config
+// loaded for it, the entities default to all domain types when the patch empties them
+func ApplyScopeConfigOverride(bpScope *models.BlueprintScope, scopeConfig interface{}) errors.Error {
+	if bpScope.ScopeConfigOverride == nil {
+		return nil
+	}
+	err := utils.DecodeMapStruct(bpScope.ScopeConfigOverride, scopeConfig, true)
+	if err != nil {
+		return errors.BadInput.Wrap(err, "invalid scope config")
+	}
+	setDefaultEntities(scopeConfig)
+	return nil
+}
+
 func setDefaultEntities(sc interface{}) {
 	v := reflect.ValueOf(sc)
 	if v.Kind() != reflect.Pointer {
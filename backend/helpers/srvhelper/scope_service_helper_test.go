@@ -20,6 +20,7 @@ package srvhelper
 import (
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/magiconair/properties/assert"
@@ -53,3 +54,29 @@ func Test_setDefaultEntities(t *testing.T) {
 	setDefaultEntities(sc3)
 	assert.Equal(t, sc3.Entities, []string{plugin.DOMAIN_TYPE_CICD})
 }
+
+func TestApplyScopeConfigOverride(t *testing.T) {
+	sc := &struct {
+		common.ScopeConfig `mapstructure:",squash"`
+		DeploymentPattern  string
+		ProductionPattern  string
+	}{
+		ScopeConfig:       common.ScopeConfig{Entities: []string{plugin.DOMAIN_TYPE_CICD}},
+		DeploymentPattern: "deploy",
+		ProductionPattern: "prod",
+	}
+	// nothing to override
+	err := ApplyScopeConfigOverride(&models.BlueprintScope{ScopeId: "1"}, sc)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, sc.DeploymentPattern, "deploy")
+
+	// the override is patched onto the saved scope config
+	err = ApplyScopeConfigOverride(&models.BlueprintScope{
+		ScopeId:             "1",
+		ScopeConfigOverride: map[string]interface{}{"deploymentPattern": "release", "entities": []string{}},
+	}, sc)
+	assert.Equal(t, err, nil)
+	assert.Equal(t, sc.DeploymentPattern, "release")
+	assert.Equal(t, sc.ProductionPattern, "prod")
+	assert.Equal(t, sc.Entities, plugin.DOMAIN_TYPES)
+}
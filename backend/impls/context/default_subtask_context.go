@@ -29,6 +29,7 @@ type DefaultSubTaskContext struct {
 	*defaultExecContext
 	taskCtx          *DefaultTaskContext
 	LastProgressTime time.Time
+	apiStats         plugin.ApiCollectionStats
 }
 
 // SetProgress FIXME ...
@@ -58,6 +59,11 @@ func (c *DefaultSubTaskContext) TaskContext() plugin.TaskContext {
 	return c.taskCtx
 }
 
+// ApiCollectionStats returns the api requests made by the collectors of the subtask
+func (c *DefaultSubTaskContext) ApiCollectionStats() *plugin.ApiCollectionStats {
+	return &c.apiStats
+}
+
 // NewStandaloneSubTaskContext returns a stand-alone plugin.SubTaskContext,
 // not attached to any plugin.TaskContext.
 // Use this if you need to run/debug a subtask without
@@ -69,10 +75,9 @@ func NewStandaloneSubTaskContext(
 	data interface{},
 ) plugin.SubTaskContext {
 	return &DefaultSubTaskContext{
-		newDefaultExecContext(ctx, basicRes, name, data, nil),
-		nil,
-		time.Time{},
+		defaultExecContext: newDefaultExecContext(ctx, basicRes, name, data, nil),
 	}
 }
 
 var _ plugin.SubTaskContext = (*DefaultSubTaskContext)(nil)
+var _ plugin.ApiCollectionStatsHolder = (*DefaultSubTaskContext)(nil)
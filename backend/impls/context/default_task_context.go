@@ -20,7 +20,6 @@ package context
 import (
 	gocontext "context"
 	"fmt"
-	"time"
 
 	"github.com/apache/incubator-devlake/core/context"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -34,6 +33,7 @@ type DefaultTaskContext struct {
 	subtasks    map[string]bool
 	subtaskCtxs map[string]*DefaultSubTaskContext
 	syncPolicy  *models.SyncPolicy
+	pipelineId  uint64
 }
 
 // SetProgress FIXME ...
@@ -56,6 +56,14 @@ func (c *DefaultTaskContext) SyncPolicy() *models.SyncPolicy {
 	return c.syncPolicy
 }
 
+func (c *DefaultTaskContext) SetPipelineId(pipelineId uint64) {
+	c.pipelineId = pipelineId
+}
+
+func (c *DefaultTaskContext) PipelineId() uint64 {
+	return c.pipelineId
+}
+
 // SubTaskContext FIXME ...
 func (c *DefaultTaskContext) SubTaskContext(subtask string) (plugin.SubTaskContext, errors.Error) {
 	// no need to lock at this point because subtasks is written only once
@@ -65,9 +73,8 @@ func (c *DefaultTaskContext) SubTaskContext(subtask string) (plugin.SubTaskConte
 			c.defaultExecContext.mu.Lock()
 			if c.subtaskCtxs[subtask] == nil {
 				c.subtaskCtxs[subtask] = &DefaultSubTaskContext{
-					c.defaultExecContext.fork(subtask),
-					c,
-					time.Time{},
+					defaultExecContext: c.defaultExecContext.fork(subtask),
+					taskCtx:            c,
 				}
 			}
 			c.defaultExecContext.mu.Unlock()
@@ -98,6 +105,7 @@ func NewDefaultTaskContext(
 		subtasks,
 		make(map[string]*DefaultSubTaskContext),
 		nil,
+		0,
 	}
 }
 
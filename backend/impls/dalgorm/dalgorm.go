@@ -316,7 +316,11 @@ func (d *Dalgorm) AddColumn(table, columnName string, columnType dal.ColumnType)
 	defer func() {
 		_ = d.Exec("SELECT * FROM ? LIMIT 1", clause.Table{Name: table})
 	}()
-	return d.Exec("ALTER TABLE ? ADD ? ?", clause.Table{Name: table}, clause.Column{Name: columnName}, clause.Expr{SQL: columnType.String()})
+	sqlType := columnType.String()
+	if d.db.Dialector.Name() == "sqlserver" {
+		sqlType = SqlServerColumnType(sqlType)
+	}
+	return d.Exec("ALTER TABLE ? ADD ? ?", clause.Table{Name: table}, clause.Column{Name: columnName}, clause.Expr{SQL: sqlType})
 }
 
 // DropColumns drop one column from the table
@@ -327,6 +331,13 @@ func (d *Dalgorm) DropColumns(table string, columnNames ...string) errors.Error
 		_ = d.Exec("SELECT * FROM ? LIMIT 1", clause.Table{Name: table})
 	}()
 	for _, columnName := range columnNames {
+		if d.db.Dialector.Name() == "sqlite" {
+			// sqlite refuses to drop an indexed column
+			err := d.dropSqliteIndexesOfColumn(table, columnName)
+			if err != nil {
+				return err
+			}
+		}
 		err := d.Exec("ALTER TABLE ? DROP COLUMN ?", clause.Table{Name: table}, clause.Column{Name: columnName})
 		// err := d.db.Migrator().DropColumn(table, columnName)
 		if err != nil {
@@ -336,6 +347,24 @@ func (d *Dalgorm) DropColumns(table string, columnNames ...string) errors.Error
 	return nil
 }
 
+func (d *Dalgorm) dropSqliteIndexesOfColumn(table, columnName string) errors.Error {
+	var indexNames []string
+	err := d.db.Raw(
+		"SELECT DISTINCT il.name FROM pragma_index_list(?) il, pragma_index_info(il.name) ii WHERE ii.name = ? AND il.origin = 'c'",
+		table, columnName,
+	).Scan(&indexNames).Error
+	if err != nil {
+		return d.convertGormError(err)
+	}
+	for _, indexName := range indexNames {
+		err := d.Exec("DROP INDEX ?", clause.Column{Name: indexName})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // GetPrimaryKeyFields get the PrimaryKey from `gorm` tag
 func (d *Dalgorm) GetPrimaryKeyFields(t reflect.Type) []reflect.StructField {
 	return utils.WalkFields(t, func(field *reflect.StructField) bool {
@@ -350,6 +379,10 @@ func (d *Dalgorm) RenameColumn(table, oldColumnName, newColumnName string) error
 	defer func() {
 		_ = d.Exec("SELECT * FROM ? LIMIT 1", clause.Table{Name: table})
 	}()
+	if d.db.Dialector.Name() == "sqlserver" {
+		// sql server renames columns by sp_rename
+		return d.convertGormError(d.db.Migrator().RenameColumn(table, oldColumnName, newColumnName))
+	}
 	return d.Exec(
 		"ALTER TABLE ? RENAME COLUMN ? TO ?",
 		clause.Table{Name: table},
@@ -361,9 +394,14 @@ func (d *Dalgorm) RenameColumn(table, oldColumnName, newColumnName string) error
 // AllTables returns all tables in the database
 func (d *Dalgorm) AllTables() ([]string, errors.Error) {
 	var tableSql string
-	if d.db.Dialector.Name() == "mysql" {
+	switch d.db.Dialector.Name() {
+	case "mysql":
 		tableSql = "show tables"
-	} else {
+	case "sqlserver":
+		tableSql = "select table_name from information_schema.tables where table_type = 'BASE TABLE' and table_schema = schema_name()"
+	case "sqlite":
+		tableSql = "select name from sqlite_master where type = 'table' and name not like 'sqlite_%'"
+	default:
 		tableSql = "select table_name from information_schema.tables where table_schema = 'public' and table_name not like '_devlake%'"
 	}
 	var tables []string
@@ -415,7 +453,45 @@ func (d *Dalgorm) HasColumn(table interface{}, columnName string) bool {
 // RenameTable renames table name
 func (d *Dalgorm) RenameTable(oldName, newName string) errors.Error {
 	err := d.db.Migrator().RenameTable(oldName, newName)
-	return d.convertGormError(err)
+	if err != nil {
+		return d.convertGormError(err)
+	}
+	if d.db.Dialector.Name() == "sqlite" {
+		return d.renameSqliteIndexes(oldName, newName)
+	}
+	return nil
+}
+
+// renameSqliteIndexes recreates the indexes of the renamed table under names of the new table, the index names are
+// unique across the sqlite database and the indexes keep their names when the table is renamed, which would
+// prevent creating the indexes of a new table under the old name
+func (d *Dalgorm) renameSqliteIndexes(oldName, newName string) errors.Error {
+	var indexes []struct {
+		Name string
+		Sql  string
+	}
+	err := d.db.Raw(
+		"SELECT name, sql FROM sqlite_master WHERE type = 'index' AND tbl_name = ? AND sql IS NOT NULL",
+		newName,
+	).Scan(&indexes).Error
+	if err != nil {
+		return d.convertGormError(err)
+	}
+	for _, index := range indexes {
+		indexName := strings.Replace(index.Name, oldName, newName, 1)
+		if indexName == index.Name {
+			indexName = fmt.Sprintf("%s_%s", index.Name, newName)
+		}
+		err := d.Exec("DROP INDEX ?", clause.Column{Name: index.Name})
+		if err != nil {
+			return err
+		}
+		err = d.Exec(strings.Replace(index.Sql, index.Name, indexName, 1))
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
 
 // DropIndexes drops indexes for specified table
@@ -453,9 +529,10 @@ func (d *Dalgorm) IsErrorNotFound(err error) bool {
 	return errors.Is(err, gorm.ErrRecordNotFound)
 }
 
-// IsDuplicationError checking if the sql error is not found.
+// IsDuplicationError checking if the sql error is a duplicate key, sqlite reports it as a failed unique constraint
 func (d *Dalgorm) IsDuplicationError(err error) bool {
-	return strings.Contains(strings.ToLower(err.Error()), "duplicate")
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "duplicate") || strings.Contains(message, "unique constraint failed")
 }
 
 // IsCachedPlanError checks if the error is related to postgres cached query plan
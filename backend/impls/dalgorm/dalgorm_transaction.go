@@ -82,6 +82,22 @@ func (t *DalgormTransaction) LockTables(lockTables dal.LockTables) errors.Error
 			}
 		}
 		return nil
+	case "sqlserver":
+		// sql server holds the table locks taken by the hints until the end of the transaction
+		for _, lockTable := range lockTables {
+			hint := "TABLOCK, HOLDLOCK"
+			if lockTable.Exclusive {
+				hint = "TABLOCKX, HOLDLOCK"
+			}
+			err := t.Exec(fmt.Sprintf("SELECT TOP 0 * FROM %s WITH (%s)", lockTable.TableName(), hint))
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	case "sqlite":
+		// sqlite locks the whole database for the transaction, there is no table lock
+		return nil
 	default:
 		panic(fmt.Errorf("unknown dialect %s", t.Dialect()))
 	}
@@ -93,8 +109,8 @@ func (t *DalgormTransaction) UnlockTables() errors.Error {
 		// mysql would not release lock automatically on Rollback
 		// according to https://dev.mysql.com/doc/refman/8.0/en/lock-tables.html
 		return t.Exec("UNLOCK TABLES")
-	case "postgres":
-		// pg has no unlock tables
+	case "postgres", "sqlserver", "sqlite":
+		// pg, sql server and sqlite have no unlock tables, the locks are released by the end of the transaction
 		return nil
 	default:
 		panic(fmt.Errorf("unknown dialect %s", t.Dialect()))
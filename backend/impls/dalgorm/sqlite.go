@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package dalgorm
+
+import (
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+	"gorm.io/gorm/migrator"
+)
+
+// sqliteDialector wraps the sqlite dialector to tolerate the index names shared by the tables of some plugins
+type sqliteDialector struct {
+	sqlite.Dialector
+}
+
+// NewSqliteDialector creates the gorm dialector of the sqlite database
+func NewSqliteDialector(dsn string) gorm.Dialector {
+	return &sqliteDialector{
+		Dialector: *sqlite.Open(dsn).(*sqlite.Dialector),
+	}
+}
+
+func (dialector sqliteDialector) Migrator(db *gorm.DB) gorm.Migrator {
+	return sqliteMigrator{sqlite.Migrator{Migrator: migrator.Migrator{Config: migrator.Config{
+		DB:                          db,
+		Dialector:                   dialector,
+		CreateIndexAfterCreateTable: true,
+	}}}}
+}
+
+type sqliteMigrator struct {
+	sqlite.Migrator
+}
+
+// CreateIndex skips the index if its name is taken, which is what `CREATE INDEX IF NOT EXISTS` does for postgres,
+// the index names are unique across the whole database for both
+func (m sqliteMigrator) CreateIndex(value interface{}, name string) error {
+	return m.RunWithValue(value, func(stmt *gorm.Statement) error {
+		indexName := name
+		if stmt.Schema != nil {
+			if idx := stmt.Schema.LookIndex(name); idx != nil {
+				indexName = idx.Name
+			}
+		}
+		var count int64
+		err := m.DB.Raw("SELECT count(*) FROM sqlite_master WHERE type = 'index' AND name = ?", indexName).Scan(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return nil
+		}
+		return m.Migrator.CreateIndex(value, name)
+	})
+}
@@ -30,6 +30,7 @@ import (
 	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
 )
 
 func MakePipelinePlanV200(
@@ -68,6 +69,10 @@ func makeScopeV200(connectionId uint64, scopes []*coreModels.BlueprintScope) ([]
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(scope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 
 		// add cicd_scope to scopes
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CICD) {
@@ -94,6 +99,10 @@ func makePipelinePlanV200(
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(scope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 
 		// bamboo main part
 		options := make(map[string]interface{})
@@ -232,7 +232,9 @@ func (p Bamboo) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"PUT": api.PutScope,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
-			"GET": api.GetScopeLatestSyncState,
+			"GET":    api.GetScopeLatestSyncState,
+			"PATCH":  api.PatchScopeLatestSyncState,
+			"DELETE": api.DeleteScopeLatestSyncState,
 		},
 		"connections/:connectionId/remote-scopes": {
 			"GET": api.RemoteScopes,
@@ -30,6 +30,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
 	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
 	"github.com/apache/incubator-devlake/plugins/bitbucket/tasks"
 )
@@ -75,6 +76,10 @@ func makeDataSourcePipelinePlanV200(
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(bpScope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 		// refdiff
 		if scopeConfig != nil && scopeConfig.Refdiff != nil {
 			// add a new task to next stage
@@ -146,6 +151,10 @@ func makeScopesV200(bpScopes []*coreModels.BlueprintScope, connection *models.Bi
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(bpScope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE_REVIEW) ||
 			utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CODE) ||
 			utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CROSS) {
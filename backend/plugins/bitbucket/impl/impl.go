@@ -77,6 +77,7 @@ func (p Bitbucket) GetTablesInfo() []dal.Tabler {
 		&models.BitbucketPipeline{},
 		&models.BitbucketRepo{},
 		&models.BitbucketRepoCommit{},
+		&models.BitbucketBranchHead{},
 		&models.BitbucketDeployment{},
 		&models.BitbucketPipelineStep{},
 		&models.BitbucketPrCommit{},
@@ -215,9 +216,10 @@ func (p Bitbucket) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 			// GetScopeLatestSyncState "connections/:connectionId/scopes/:scopeId/latest-sync-state"
 			// GetScope "connections/:connectionId/scopes/:scopeId"
 			// Because there may be slash in scopeId, so we handle it manually.
+			// PATCH and DELETE of "connections/:connectionId/scopes/:scopeId/latest-sync-state" are dispatched the same way.
 			"GET":    api.GetScopeDispatcher,
-			"PATCH":  api.UpdateScope,
-			"DELETE": api.DeleteScope,
+			"PATCH":  api.PatchScopeDispatcher,
+			"DELETE": api.DeleteScopeDispatcher,
 		},
 		"connections/:connectionId/remote-scopes": {
 			"GET": api.RemoteScopes,
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBranchHeads)(nil)
+
+type branchHead20231222 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       string `gorm:"primaryKey;type:varchar(255)"`
+	BranchName   string `gorm:"primaryKey;type:varchar(255)"`
+	HeadSha      string `gorm:"type:varchar(40)"`
+	archived.NoPKModel
+}
+
+func (branchHead20231222) TableName() string {
+	return "_tool_bitbucket_branch_heads"
+}
+
+type addBranchHeads struct{}
+
+func (*addBranchHeads) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&branchHead20231222{},
+	)
+}
+
+func (*addBranchHeads) Version() uint64 {
+	return 20231222000001
+}
+
+func (*addBranchHeads) Name() string {
+	return "add _tool_bitbucket_branch_heads"
+}
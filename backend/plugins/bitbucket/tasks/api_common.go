@@ -92,8 +92,9 @@ func GetQuery(reqData *api.RequestData) (url.Values, errors.Error) {
 	return query, nil
 }
 
-// GetQueryCreatedAndUpdated is a common GeyQuery for timeFilter and incremental
-func GetQueryCreatedAndUpdated(fields string, collectorWithState *api.ApiCollectorStateManager) func(reqData *api.RequestData) (url.Values, errors.Error) {
+// GetQuerySortedByCreated is a common GetQuery for collectors sorting records by creation, use it along with
+// BitbucketTimeWindowAdapter for timeFilter and incremental
+func GetQuerySortedByCreated(fields string) func(reqData *api.RequestData) (url.Values, errors.Error) {
 	return func(reqData *api.RequestData) (url.Values, errors.Error) {
 		query, err := GetQuery(reqData)
 		if err != nil {
@@ -101,14 +102,17 @@ func GetQueryCreatedAndUpdated(fields string, collectorWithState *api.ApiCollect
 		}
 		query.Set("fields", fields)
 		query.Set("sort", "created_on")
-
-		if collectorWithState.Since != nil {
-			query.Set("q", fmt.Sprintf("updated_on>=%s", collectorWithState.Since.Format(time.RFC3339)))
-		}
 		return query, nil
 	}
 }
 
+// BitbucketTimeWindowAdapter filters records by `updated_on` with the `q` param
+func BitbucketTimeWindowAdapter(query url.Values, window *api.TimeWindow) {
+	if window.Since != nil {
+		query.Set("q", fmt.Sprintf("updated_on>=%s", window.Since.Format(time.RFC3339)))
+	}
+}
+
 func GetQueryFields(fields string) func(reqData *api.RequestData) (url.Values, errors.Error) {
 	return func(reqData *api.RequestData) (url.Values, errors.Error) {
 		query, err := GetQuery(reqData)
@@ -18,9 +18,15 @@ limitations under the License.
 package tasks
 
 import (
+	"fmt"
+	"net/url"
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	plugin "github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
 )
 
 const RAW_COMMIT_TABLE = "bitbucket_api_commits"
@@ -30,27 +36,141 @@ var CollectApiCommitsMeta = plugin.SubTaskMeta{
 	EntryPoint:       CollectApiCommits,
 	EnabledByDefault: false,
 	Required:         false,
-	Description:      "Collect commits data from Bitbucket api",
+	Description:      "Collect commits data from Bitbucket api, supports diffSync by the heads of branches.",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 }
 
 func CollectApiCommits(taskCtx plugin.SubTaskContext) errors.Error {
 	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_COMMIT_TABLE)
+	db := taskCtx.GetDal()
+	logger := taskCtx.GetLogger()
 
-	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
-		RawDataSubTaskArgs: *rawDataSubTaskArgs,
-		ApiClient:          data.ApiClient,
-		PageSize:           100,
-		Incremental:        false,
-		UrlTemplate:        "repositories/{{ .Params.FullName }}/commits",
-		Query:              GetQuery,
-		GetTotalPages:      GetTotalPagesFromResponse,
-		ResponseParser:     GetRawMessageFromResponse,
+	collectorWithState, err := helper.NewStatefulApiCollector(*rawDataSubTaskArgs)
+	if err != nil {
+		return err
+	}
+
+	heads, err := fetchBranchHeads(data)
+	if err != nil {
+		return err
+	}
+	var lastHeads []models.BitbucketBranchHead
+	if collectorWithState.IsIncremental {
+		err = db.All(&lastHeads, dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.FullName))
+		if err != nil {
+			return err
+		}
+	}
+	// walk all commits from scratch unless we know where the branches were last time
+	collectorWithState.IsIncremental = collectorWithState.IsIncremental && len(lastHeads) > 0
+	include, exclude := diffBranchHeads(heads, lastHeads)
+	if collectorWithState.IsIncremental && len(include) == 0 {
+		logger.Info("no branch of %s has moved since last collection, skip collecting commits", data.Options.FullName)
+		return nil
+	}
+
+	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
+		ApiClient:   data.ApiClient,
+		PageSize:    100,
+		UrlTemplate: "repositories/{{ .Params.FullName }}/commits",
+		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
+			query, err := GetQuery(reqData)
+			if err != nil {
+				return nil, err
+			}
+			if collectorWithState.IsIncremental {
+				// commits reachable from the new heads but not from the old ones, same as `git log new ^old`
+				query["include"] = include
+				query["exclude"] = exclude
+			}
+			return query, nil
+		},
+		GetTotalPages:  GetTotalPagesFromResponse,
+		ResponseParser: GetRawMessageFromResponse,
 	})
+	if err != nil {
+		return err
+	}
 
+	err = collectorWithState.Execute()
 	if err != nil {
 		return err
 	}
+	return saveBranchHeads(db, data, heads)
+}
 
-	return collector.Execute()
+// fetchBranchHeads returns the head commit sha of every branch of the repo, keyed by the branch name
+func fetchBranchHeads(data *BitbucketTaskData) (map[string]string, errors.Error) {
+	heads := make(map[string]string)
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page", fmt.Sprintf("%v", page))
+		query.Set("pagelen", "100")
+		query.Set("fields", "next,values.name,values.target.hash")
+		res, err := data.ApiClient.Get(fmt.Sprintf("repositories/%s/refs/branches", data.Options.FullName), query, nil)
+		if err != nil {
+			return nil, err
+		}
+		var body struct {
+			Values []struct {
+				Name   string `json:"name"`
+				Target struct {
+					Hash string `json:"hash"`
+				} `json:"target"`
+			} `json:"values"`
+			Next string `json:"next"`
+		}
+		err = decodeResponse(res, &body)
+		if err != nil {
+			return nil, err
+		}
+		for _, branch := range body.Values {
+			heads[branch.Name] = branch.Target.Hash
+		}
+		if body.Next == "" {
+			return heads, nil
+		}
+	}
+}
+
+// diffBranchHeads returns the heads which have moved since last collection and the heads collected last time
+func diffBranchHeads(heads map[string]string, lastHeads []models.BitbucketBranchHead) (include []string, exclude []string) {
+	collected := make(map[string]bool, len(lastHeads))
+	for _, head := range lastHeads {
+		if !collected[head.HeadSha] {
+			collected[head.HeadSha] = true
+			exclude = append(exclude, head.HeadSha)
+		}
+	}
+	for _, sha := range heads {
+		if !collected[sha] {
+			collected[sha] = true
+			include = append(include, sha)
+		}
+	}
+	sort.Strings(include)
+	sort.Strings(exclude)
+	return include, exclude
+}
+
+func saveBranchHeads(db dal.Dal, data *BitbucketTaskData, heads map[string]string) errors.Error {
+	err := db.Delete(
+		&models.BitbucketBranchHead{},
+		dal.Where("connection_id = ? AND repo_id = ?", data.Options.ConnectionId, data.Options.FullName),
+	)
+	if err != nil {
+		return err
+	}
+	for name, sha := range heads {
+		err = db.CreateOrUpdate(&models.BitbucketBranchHead{
+			ConnectionId: data.Options.ConnectionId,
+			RepoId:       data.Options.FullName,
+			BranchName:   name,
+			HeadSha:      sha,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
 }
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/bitbucket/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffBranchHeads(t *testing.T) {
+	heads := map[string]string{"main": "c3", "feature": "b1", "release": "a2", "hotfix": "a2"}
+	lastHeads := []models.BitbucketBranchHead{
+		{BranchName: "main", HeadSha: "a1"},
+		{BranchName: "feature", HeadSha: "b1"},
+		{BranchName: "release", HeadSha: "a1"},
+	}
+	include, exclude := diffBranchHeads(heads, lastHeads)
+	assert.Equal(t, []string{"a2", "c3"}, include)
+	assert.Equal(t, []string{"a1", "b1"}, exclude)
+
+	include, exclude = diffBranchHeads(map[string]string{"main": "a1"}, []models.BitbucketBranchHead{{BranchName: "main", HeadSha: "a1"}})
+	assert.Empty(t, include)
+	assert.Equal(t, []string{"a1"}, exclude)
+}
@@ -44,14 +44,14 @@ func CollectApiIssues(taskCtx plugin.SubTaskContext) errors.Error {
 		ApiClient:   data.ApiClient,
 		PageSize:    100,
 		UrlTemplate: "repositories/{{ .Params.FullName }}/issues",
-		Query: GetQueryCreatedAndUpdated(
-			`values.type,values.id,values.links.self,`+
-				`values.title,values.content.raw,values.reporter,values.assignee,`+
-				`values.state,values.milestone.id,values.component,values.priority,values.created_on,values.updated_on,`+
-				`page,pagelen,size`,
-			collectorWithState),
-		GetTotalPages:  GetTotalPagesFromResponse,
-		ResponseParser: GetRawMessageFromResponse,
+		Query: GetQuerySortedByCreated(
+			`values.type,values.id,values.links.self,` +
+				`values.title,values.content.raw,values.reporter,values.assignee,` +
+				`values.state,values.milestone.id,values.component,values.priority,values.created_on,values.updated_on,` +
+				`page,pagelen,size`),
+		TimeWindowAdapter: BitbucketTimeWindowAdapter,
+		GetTotalPages:     GetTotalPagesFromResponse,
+		ResponseParser:    GetRawMessageFromResponse,
 		// some repo have no issue tracker
 		AfterResponse: ignoreHTTPStatus404,
 	})
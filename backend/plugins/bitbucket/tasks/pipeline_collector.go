@@ -44,14 +44,14 @@ func CollectApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
 		ApiClient:   data.ApiClient,
 		PageSize:    50,
 		UrlTemplate: "repositories/{{ .Params.FullName }}/pipelines/",
-		Query: GetQueryCreatedAndUpdated(
-			`values.uuid,values.type,values.state.name,values.state.result.name,values.state.result.type,values.state.stage.name,values.state.stage.type,`+
-				`values.target.ref_name,values.target.commit.hash,`+
-				`values.created_on,values.completed_on,values.duration_in_seconds,values.build_number,values.links.self,`+
-				`page,pagelen,size`,
-			collectorWithState),
-		ResponseParser: GetRawMessageFromResponse,
-		GetTotalPages:  GetTotalPagesFromResponse,
+		Query: GetQuerySortedByCreated(
+			`values.uuid,values.type,values.state.name,values.state.result.name,values.state.result.type,values.state.stage.name,values.state.stage.type,` +
+				`values.target.ref_name,values.target.commit.hash,` +
+				`values.created_on,values.completed_on,values.duration_in_seconds,values.build_number,values.links.self,` +
+				`page,pagelen,size`),
+		TimeWindowAdapter: BitbucketTimeWindowAdapter,
+		ResponseParser:    GetRawMessageFromResponse,
+		GetTotalPages:     GetTotalPagesFromResponse,
 	})
 	if err != nil {
 		return err
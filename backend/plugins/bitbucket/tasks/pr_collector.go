@@ -47,15 +47,15 @@ func CollectApiPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
 		ApiClient:   data.ApiClient,
 		PageSize:    50,
 		UrlTemplate: "repositories/{{ .Params.FullName }}/pullrequests",
-		Query: GetQueryCreatedAndUpdated(
-			`values.id,values.comment_count,values.type,values.state,values.title,values.description,`+
-				`values.merge_commit.hash,values.merge_commit.date,values.links.html,values.author,values.created_on,values.updated_on,`+
-				`values.destination.branch.name,values.destination.commit.hash,values.destination.repository.full_name,`+
-				`values.source.branch.name,values.source.commit.hash,values.source.repository.full_name,`+
-				`page,pagelen,size`,
-			collectorWithState),
-		GetTotalPages:  GetTotalPagesFromResponse,
-		ResponseParser: GetRawMessageFromResponse,
+		Query: GetQuerySortedByCreated(
+			`values.id,values.comment_count,values.type,values.state,values.title,values.description,` +
+				`values.merge_commit.hash,values.merge_commit.date,values.links.html,values.author,values.created_on,values.updated_on,` +
+				`values.destination.branch.name,values.destination.commit.hash,values.destination.repository.full_name,` +
+				`values.source.branch.name,values.source.commit.hash,values.source.repository.full_name,` +
+				`page,pagelen,size`),
+		TimeWindowAdapter: BitbucketTimeWindowAdapter,
+		GetTotalPages:     GetTotalPagesFromResponse,
+		ResponseParser:    GetRawMessageFromResponse,
 	})
 	if err != nil {
 		return err
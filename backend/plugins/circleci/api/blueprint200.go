@@ -31,6 +31,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
 )
 
 func MakeDataSourcePipelinePlanV200(
@@ -76,6 +77,10 @@ func makeDataSourcePipelinePlanV200(
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(bpScope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 
 		subtasks, err := helper.MakePipelinePlanSubtasks(subtaskMetas, scopeConfig.Entities)
 		if err != nil {
@@ -108,6 +113,10 @@ func makeScopesV200(bpScopes []*coreModels.BlueprintScope, connectionId uint64)
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(bpScope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 
 		// add board to scopes
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_CICD) {
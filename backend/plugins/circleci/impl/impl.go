@@ -192,7 +192,9 @@ func (p Circleci) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
-			"GET": api.GetScopeLatestSyncState,
+			"GET":    api.GetScopeLatestSyncState,
+			"PATCH":  api.PatchScopeLatestSyncState,
+			"DELETE": api.DeleteScopeLatestSyncState,
 		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopeList,
@@ -19,6 +19,7 @@ package impl
 
 import (
 	"encoding/json"
+	"regexp"
 
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
@@ -89,12 +90,18 @@ func (p Dora) Settings() interface{} {
 
 func (p Dora) SubTaskMetas() []plugin.SubTaskMeta {
 	return []plugin.SubTaskMeta{
+		tasks.EnrichBotAccountsMeta,
 		tasks.DeploymentGeneratorMeta,
+		tasks.EnrichRollbackDeploymentsMeta,
 		tasks.DeploymentCommitsGeneratorMeta,
 		tasks.EnrichPrevSuccessDeploymentCommitMeta,
 		tasks.EnrichTaskEnvMeta,
 		tasks.CalculateChangeLeadTimeMeta,
 		tasks.ConnectIncidentToDeploymentMeta,
+		tasks.CalculateDeploymentFrequencyMeta,
+		tasks.CalculateIssueReopensMeta,
+		tasks.CalculateIssueTraceabilityMeta,
+		tasks.CalculateProjectHealthScoreMeta,
 	}
 }
 
@@ -103,9 +110,16 @@ func (p Dora) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]int
 	if err != nil {
 		return nil, err
 	}
-	return &tasks.DoraTaskData{
+	taskData := &tasks.DoraTaskData{
 		Options: op,
-	}, nil
+	}
+	if op.RollbackPattern != "" {
+		taskData.RollbackRegex, err = errors.Convert01(regexp.Compile(op.RollbackPattern))
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid rollbackPattern")
+		}
+	}
+	return taskData, nil
 }
 
 // RootPkgPath information lost when compiled as plugin(.so)
@@ -123,15 +137,27 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 	if err != nil {
 		return nil, errors.Default.WrapRaw(err)
 	}
+	doraOptions := map[string]interface{}{
+		"projectName": projectName,
+	}
+	if op.RollbackPattern != "" {
+		doraOptions["rollbackPattern"] = op.RollbackPattern
+	}
+	if op.HealthScoreWeights != nil {
+		doraOptions["healthScoreWeights"] = op.HealthScoreWeights
+	}
+	if op.ExcludeBotAccounts {
+		doraOptions["excludeBotAccounts"] = true
+	}
 	plan := coreModels.PipelinePlan{
 		{
 			{
-				Plugin: "dora",
-				Options: map[string]interface{}{
-					"projectName": projectName,
-				},
+				Plugin:  "dora",
+				Options: doraOptions,
 				Subtasks: []string{
+					"enrichBotAccounts",
 					"generateDeployments",
+					"enrichRollbackDeployments",
 					"generateDeploymentCommits",
 					"enrichPrevSuccessDeploymentCommits",
 				},
@@ -150,13 +176,15 @@ func (p Dora) MakeMetricPluginPipelinePlanV200(projectName string, options json.
 		},
 		{
 			{
-				Plugin: "dora",
-				Options: map[string]interface{}{
-					"projectName": projectName,
-				},
+				Plugin:  "dora",
+				Options: doraOptions,
 				Subtasks: []string{
 					"calculateChangeLeadTime",
 					"ConnectIncidentToDeployment",
+					"calculateDeploymentFrequency",
+					"calculateIssueReopens",
+					"calculateIssueTraceability",
+					"calculateProjectHealthScore",
 				},
 			},
 		},
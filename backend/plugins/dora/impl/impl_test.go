@@ -41,7 +41,9 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 			{
 				Plugin: "dora",
 				Subtasks: []string{
+					"enrichBotAccounts",
 					"generateDeployments",
+					"enrichRollbackDeployments",
 					"generateDeploymentCommits",
 					"enrichPrevSuccessDeploymentCommits",
 				},
@@ -61,6 +63,10 @@ func TestMakeMetricPluginPipelinePlanV200(t *testing.T) {
 				Subtasks: []string{
 					"calculateChangeLeadTime",
 					"ConnectIncidentToDeployment",
+					"calculateDeploymentFrequency",
+					"calculateIssueReopens",
+					"calculateIssueTraceability",
+					"calculateProjectHealthScore",
 				},
 				Options: map[string]interface{}{"projectName": projectName},
 			},
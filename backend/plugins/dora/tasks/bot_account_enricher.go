@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var EnrichBotAccountsMeta = plugin.SubTaskMeta{
+	Name:             "enrichBotAccounts",
+	EntryPoint:       EnrichBotAccounts,
+	EnabledByDefault: true,
+	Description:      "Flag the accounts of bots and CI users by the bot_account_rules and built-in heuristics",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// EnrichBotAccounts updates accounts.is_bot, the accounts are not scoped to projects so all of them are evaluated,
+// only the ones whose flag changed get saved
+func EnrichBotAccounts(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	var rules []crossdomain.BotAccountRule
+	err := db.All(&rules)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(dal.From(&crossdomain.Account{}))
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	enricher, err := api.NewDataEnricher(api.DataEnricherArgs[crossdomain.Account]{
+		Ctx:   taskCtx,
+		Name:  "bot_account_enricher",
+		Input: cursor,
+		Enrich: func(account *crossdomain.Account) ([]interface{}, errors.Error) {
+			isBot := crossdomain.IsBotAccount(account, rules)
+			if isBot == account.IsBot {
+				return nil, nil
+			}
+			account.IsBot = isBot
+			return []interface{}{account}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return enricher.Execute()
+}
+
+// notBotAuthor returns a condition excluding the rows authored by bot accounts, `column` holds the account id
+func notBotAuthor(column string) string {
+	return fmt.Sprintf("NOT EXISTS(SELECT 1 FROM accounts ba WHERE ba.id = %s AND ba.is_bot = TRUE)", column)
+}
@@ -48,11 +48,15 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*DoraTaskData)
 
 	// Get pull requests by repo project_name
+	prFilter := "pr.merged_date IS NOT NULL AND pm.project_name = ? AND pm.table = 'repos'"
+	if data.Options.ExcludeBotAccounts {
+		prFilter += " AND " + notBotAuthor("pr.author_id")
+	}
 	cursor, err := db.Cursor(
 		dal.Select("pr.*"),
 		dal.From("pull_requests pr"),
 		dal.Join(`LEFT JOIN project_mapping pm ON (pm.row_id = pr.base_repo_id)`),
-		dal.Where("pr.merged_date IS NOT NULL AND pm.project_name = ? AND pm.table = 'repos'", data.Options.ProjectName),
+		dal.Where(prFilter, data.Options.ProjectName),
 	)
 	if err != nil {
 		return err
@@ -90,7 +94,7 @@ func CalculateChangeLeadTime(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 
 			// Get the first review for the PR
-			firstReview, err := getFirstReview(pr.Id, pr.AuthorId, db)
+			firstReview, err := getFirstReview(pr.Id, pr.AuthorId, data.Options.ExcludeBotAccounts, db)
 			if err != nil {
 				return nil, err
 			}
@@ -167,14 +171,20 @@ func getFirstCommit(prId string, db dal.Dal) (*code.PullRequestCommit, errors.Er
 }
 
 // getFirstReview takes a PR ID, PR creator ID, and a database connection as input, and returns the first review comment of the PR.
-func getFirstReview(prId string, prCreator string, db dal.Dal) (*code.PullRequestComment, errors.Error) {
+// The comments of bot accounts are skipped when excludeBots is set.
+func getFirstReview(prId string, prCreator string, excludeBots bool, db dal.Dal) (*code.PullRequestComment, errors.Error) {
 	// Initialize a review comment object
 	review := &code.PullRequestComment{}
+	// Filter by the PR ID and exclude comments from the PR creator
+	reviewFilter := "pull_request_id = ? and account_id != ?"
+	if excludeBots {
+		reviewFilter += " AND " + notBotAuthor("pull_request_comments.account_id")
+	}
 	// Define the SQL clauses for the database query
 	commentClauses := []dal.Clause{
-		dal.From(&code.PullRequestComment{}),                                  // Select from the "pull_request_comments" table
-		dal.Where("pull_request_id = ? and account_id != ?", prId, prCreator), // Filter by the PR ID and exclude comments from the PR creator
-		dal.Orderby("created_date ASC"),                                       // Order by the created date of the review comments (ascending)
+		dal.From(&code.PullRequestComment{}),     // Select from the "pull_request_comments" table
+		dal.Where(reviewFilter, prId, prCreator), // Filter by the PR ID, the creator and the bots
+		dal.Orderby("created_date ASC"),          // Order by the created date of the review comments (ascending)
 	}
 
 	// Execute the query and retrieve the first review comment
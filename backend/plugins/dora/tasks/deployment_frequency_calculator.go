@@ -0,0 +1,142 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateDeploymentFrequencyMeta = plugin.SubTaskMeta{
+	Name:             "calculateDeploymentFrequency",
+	EntryPoint:       CalculateDeploymentFrequency,
+	EnabledByDefault: true,
+	Description:      "Calculate the weekly and monthly deployments and deploy days of the project into project_deployment_frequencies",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD, plugin.DOMAIN_TYPE_CROSS},
+}
+
+// CalculateDeploymentFrequency counts, per calendar week and month in UTC, the successful production deployments
+// of the project and the distinct days they happened on, periods without any deployment are recorded as well
+// so the deploy days can be averaged over time
+func CalculateDeploymentFrequency(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+
+	var deploymentDates []time.Time
+	err := db.Pluck("d.finished_date", &deploymentDates,
+		dal.From("cicd_deployments d"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = d.cicd_scope_id)"),
+		dal.Where(
+			"pm.project_name = ? AND d.environment = ? AND d.result = ? AND d.finished_date IS NOT NULL",
+			data.Options.ProjectName, devops.PRODUCTION, devops.RESULT_SUCCESS,
+		),
+	)
+	if err != nil {
+		return err
+	}
+
+	rawDataSubTask, err := api.NewRawDataSubTask(api.RawDataSubTaskArgs{
+		Ctx:    taskCtx,
+		Params: DoraApiParams{ProjectName: data.Options.ProjectName},
+		Table:  "project_deployment_frequencies",
+	})
+	if err != nil {
+		return err
+	}
+	divider := api.NewBatchSaveDivider(taskCtx, 500, rawDataSubTask.GetTable(), rawDataSubTask.GetParams())
+	// make sure the outdated records get deleted even if there is nothing to save
+	batch, err := divider.ForType(reflect.TypeOf(&crossdomain.ProjectDeploymentFrequency{}))
+	if err != nil {
+		return err
+	}
+	for _, frequency := range deploymentFrequencies(data.Options.ProjectName, deploymentDates, time.Now()) {
+		frequency.RawDataOrigin = common.RawDataOrigin{
+			RawDataTable:  rawDataSubTask.GetTable(),
+			RawDataParams: rawDataSubTask.GetParams(),
+		}
+		err = batch.Add(frequency)
+		if err != nil {
+			return err
+		}
+	}
+	return divider.Close()
+}
+
+// deploymentFrequencies aggregates the deployment dates into weekly and monthly records, starting from the period
+// of the earliest deployment up to the period `now` belongs to, the current period only counts the days elapsed
+func deploymentFrequencies(projectName string, dates []time.Time, now time.Time) []*crossdomain.ProjectDeploymentFrequency {
+	if len(dates) == 0 {
+		return nil
+	}
+	sort.Slice(dates, func(i, j int) bool { return dates[i].Before(dates[j]) })
+	today := dayStart(now)
+	var frequencies []*crossdomain.ProjectDeploymentFrequency
+	periods := []struct {
+		periodType string
+		start      func(time.Time) time.Time
+		next       func(time.Time) time.Time
+	}{
+		{crossdomain.DEPLOYMENT_FREQUENCY_WEEK, weekStart, func(t time.Time) time.Time { return t.AddDate(0, 0, 7) }},
+		{crossdomain.DEPLOYMENT_FREQUENCY_MONTH, monthStart, func(t time.Time) time.Time { return t.AddDate(0, 1, 0) }},
+	}
+	for _, period := range periods {
+		i := 0
+		for start := period.start(dates[0]); !start.After(today); start = period.next(start) {
+			end := period.next(start)
+			frequency := &crossdomain.ProjectDeploymentFrequency{
+				ProjectName: projectName,
+				PeriodType:  period.periodType,
+				PeriodStart: start,
+			}
+			days := map[time.Time]bool{}
+			for ; i < len(dates) && dates[i].Before(end); i++ {
+				frequency.DeploymentsCount++
+				days[dayStart(dates[i])] = true
+			}
+			frequency.DeployDays = len(days)
+			if today.Before(end) {
+				end = today.AddDate(0, 0, 1)
+			}
+			frequency.DaysInPeriod = int(end.Sub(start).Hours() / 24)
+			frequency.DeployDaysRate = float64(frequency.DeployDays) * 100 / float64(frequency.DaysInPeriod)
+			frequencies = append(frequencies, frequency)
+		}
+	}
+	return frequencies
+}
+
+// dayStart returns the 00:00 UTC of the day `t` belongs to
+func dayStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// monthStart returns the first day 00:00 UTC of the month `t` belongs to
+func monthStart(t time.Time) time.Time {
+	t = t.UTC()
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
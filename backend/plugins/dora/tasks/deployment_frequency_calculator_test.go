@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDeploymentFrequencies(t *testing.T) {
+	assert.Nil(t, deploymentFrequencies("p", nil, time.Now()))
+
+	// Wednesday
+	now := time.Date(2023, 12, 13, 15, 0, 0, 0, time.UTC)
+	dates := []time.Time{
+		time.Date(2023, 12, 12, 9, 0, 0, 0, time.UTC),
+		// two deployments on the same day count as one deploy day
+		time.Date(2023, 11, 28, 9, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 28, 17, 0, 0, 0, time.UTC),
+		time.Date(2023, 11, 30, 9, 0, 0, 0, time.UTC),
+	}
+	frequencies := deploymentFrequencies("p", dates, now)
+
+	var weeks, months []*crossdomain.ProjectDeploymentFrequency
+	for _, f := range frequencies {
+		assert.Equal(t, "p", f.ProjectName)
+		if f.PeriodType == crossdomain.DEPLOYMENT_FREQUENCY_WEEK {
+			weeks = append(weeks, f)
+		} else {
+			months = append(months, f)
+		}
+	}
+
+	assert.Len(t, weeks, 3)
+	assert.Equal(t, time.Date(2023, 11, 27, 0, 0, 0, 0, time.UTC), weeks[0].PeriodStart)
+	assert.Equal(t, 3, weeks[0].DeploymentsCount)
+	assert.Equal(t, 2, weeks[0].DeployDays)
+	assert.Equal(t, 7, weeks[0].DaysInPeriod)
+	// the week without deployments is kept
+	assert.Equal(t, 0, weeks[1].DeployDays)
+	assert.Equal(t, float64(0), weeks[1].DeployDaysRate)
+	// the current week only counts Monday to Wednesday
+	assert.Equal(t, 1, weeks[2].DeployDays)
+	assert.Equal(t, 3, weeks[2].DaysInPeriod)
+
+	assert.Len(t, months, 2)
+	assert.Equal(t, time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC), months[0].PeriodStart)
+	assert.Equal(t, 30, months[0].DaysInPeriod)
+	assert.Equal(t, 2, months[0].DeployDays)
+	assert.Equal(t, 13, months[1].DaysInPeriod)
+	assert.Equal(t, 1, months[1].DeploymentsCount)
+}
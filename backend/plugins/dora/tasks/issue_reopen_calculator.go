@@ -0,0 +1,177 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateIssueReopensMeta = plugin.SubTaskMeta{
+	Name:             "calculateIssueReopens",
+	EntryPoint:       CalculateIssueReopens,
+	EnabledByDefault: true,
+	Description:      "Detect issues moved back from DONE in issue_changelogs of the project boards into issue_reopens, and the bugs reintroduced after their fix was deployed",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type statusChangelog struct {
+	IssueId           string
+	IssueType         string
+	AuthorId          string
+	AuthorName        string
+	OriginalFromValue string
+	OriginalToValue   string
+	FromValue         string
+	ToValue           string
+	CreatedDate       time.Time
+}
+
+// CalculateIssueReopens walks through the standardized status changes of the issues of the project, any change
+// from DONE to another status is a reopen, the time to reopen is measured from the latest change into DONE.
+// A BUG reopened after a successful production deployment following its resolution is a reintroduced bug
+func CalculateIssueReopens(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+
+	var deploymentDates []time.Time
+	err := db.Pluck("d.finished_date", &deploymentDates,
+		dal.From("cicd_deployments d"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = d.cicd_scope_id)"),
+		dal.Where(
+			"pm.project_name = ? AND d.environment = ? AND d.result = ? AND d.finished_date IS NOT NULL",
+			data.Options.ProjectName, devops.PRODUCTION, devops.RESULT_SUCCESS,
+		),
+		dal.Orderby("d.finished_date"),
+	)
+	if err != nil {
+		return err
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select(`DISTINCT ic.issue_id, i.type AS issue_type, ic.author_id, ic.author_name,
+			ic.original_from_value, ic.original_to_value, ic.from_value, ic.to_value, ic.created_date`),
+		dal.From("issue_changelogs ic"),
+		dal.Join("JOIN issues i ON i.id = ic.issue_id"),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = ic.issue_id"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+		dal.Where("pm.project_name = ? AND ic.field_name = ?", data.Options.ProjectName, "status"),
+		dal.Orderby("ic.issue_id, ic.created_date"),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	rawDataSubTask, err := api.NewRawDataSubTask(api.RawDataSubTaskArgs{
+		Ctx:    taskCtx,
+		Params: DoraApiParams{ProjectName: data.Options.ProjectName},
+		Table:  "issue_reopens",
+	})
+	if err != nil {
+		return err
+	}
+	divider := api.NewBatchSaveDivider(taskCtx, 500, rawDataSubTask.GetTable(), rawDataSubTask.GetParams())
+	// make sure the outdated records get deleted even if there is nothing to save
+	batch, err := divider.ForType(reflect.TypeOf(&ticket.IssueReopen{}))
+	if err != nil {
+		return err
+	}
+	detector := &reopenDetector{deploymentDates: deploymentDates}
+	for cursor.Next() {
+		changelog := &statusChangelog{}
+		err = db.Fetch(cursor, changelog)
+		if err != nil {
+			return err
+		}
+		reopen := detector.add(changelog)
+		if reopen == nil {
+			continue
+		}
+		reopen.RawDataOrigin = common.RawDataOrigin{
+			RawDataTable:  rawDataSubTask.GetTable(),
+			RawDataParams: rawDataSubTask.GetParams(),
+		}
+		err = batch.Add(reopen)
+		if err != nil {
+			return err
+		}
+	}
+	return divider.Close()
+}
+
+// reopenDetector must be fed with the status changelogs ordered by issue and date, deploymentDates are the
+// finished dates of the successful production deployments of the project in ascending order
+type reopenDetector struct {
+	deploymentDates []time.Time
+	issueId         string
+	reopens         int
+	resolvedDate    *time.Time
+}
+
+func (d *reopenDetector) add(changelog *statusChangelog) *ticket.IssueReopen {
+	if changelog.IssueId != d.issueId {
+		*d = reopenDetector{deploymentDates: d.deploymentDates, issueId: changelog.IssueId}
+	}
+	if changelog.ToValue == ticket.DONE {
+		if changelog.FromValue != ticket.DONE {
+			resolvedDate := changelog.CreatedDate
+			d.resolvedDate = &resolvedDate
+		}
+		return nil
+	}
+	if changelog.FromValue != ticket.DONE || changelog.ToValue == "" {
+		return nil
+	}
+	d.reopens++
+	reopen := &ticket.IssueReopen{
+		IssueId:        changelog.IssueId,
+		ReopenedDate:   changelog.CreatedDate,
+		IssueType:      changelog.IssueType,
+		ReopenSeq:      d.reopens,
+		ResolvedDate:   d.resolvedDate,
+		ReopenedById:   changelog.AuthorId,
+		ReopenedByName: changelog.AuthorName,
+		FromStatus:     changelog.OriginalFromValue,
+		ToStatus:       changelog.OriginalToValue,
+	}
+	if d.resolvedDate != nil {
+		minutes := int64(changelog.CreatedDate.Sub(*d.resolvedDate).Minutes())
+		reopen.TimeToReopenMinutes = &minutes
+		reopen.IsReintroduced = changelog.IssueType == ticket.BUG && d.deployedBetween(*d.resolvedDate, changelog.CreatedDate)
+	}
+	d.resolvedDate = nil
+	return reopen
+}
+
+// deployedBetween tells whether a deployment finished after `from` and no later than `to`
+func (d *reopenDetector) deployedBetween(from, to time.Time) bool {
+	i := sort.Search(len(d.deploymentDates), func(i int) bool {
+		return d.deploymentDates[i].After(from)
+	})
+	return i < len(d.deploymentDates) && !d.deploymentDates[i].After(to)
+}
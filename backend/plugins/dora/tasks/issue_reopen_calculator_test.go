@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReopenDetector(t *testing.T) {
+	day := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	changelog := func(issueId string, from, to string, hours int) *statusChangelog {
+		return &statusChangelog{
+			IssueId:           issueId,
+			IssueType:         ticket.BUG,
+			OriginalFromValue: from,
+			OriginalToValue:   to,
+			FromValue:         from,
+			ToValue:           to,
+			CreatedDate:       day.Add(time.Duration(hours) * time.Hour),
+		}
+	}
+	detector := &reopenDetector{}
+	assert.Nil(t, detector.add(changelog("1", ticket.TODO, ticket.IN_PROGRESS, 0)))
+	assert.Nil(t, detector.add(changelog("1", ticket.IN_PROGRESS, ticket.DONE, 1)))
+
+	reopen := detector.add(changelog("1", ticket.DONE, ticket.TODO, 3))
+	assert.NotNil(t, reopen)
+	assert.Equal(t, 1, reopen.ReopenSeq)
+	assert.Equal(t, ticket.BUG, reopen.IssueType)
+	assert.Equal(t, day.Add(time.Hour), *reopen.ResolvedDate)
+	assert.Equal(t, int64(120), *reopen.TimeToReopenMinutes)
+
+	assert.Nil(t, detector.add(changelog("1", ticket.TODO, ticket.DONE, 4)))
+	// moving between two DONE statuses keeps the original resolution date
+	assert.Nil(t, detector.add(changelog("1", ticket.DONE, ticket.DONE, 5)))
+	reopen = detector.add(changelog("1", ticket.DONE, ticket.IN_PROGRESS, 6))
+	assert.Equal(t, 2, reopen.ReopenSeq)
+	assert.Equal(t, int64(120), *reopen.TimeToReopenMinutes)
+
+	// the counter restarts for the next issue, which was resolved before the collected history
+	reopen = detector.add(changelog("2", ticket.DONE, ticket.TODO, 7))
+	assert.Equal(t, 1, reopen.ReopenSeq)
+	assert.Nil(t, reopen.ResolvedDate)
+	assert.Nil(t, reopen.TimeToReopenMinutes)
+}
+
+func TestReopenDetectorReintroducedBugs(t *testing.T) {
+	day := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	changelog := func(issueType, from, to string, hours int) *statusChangelog {
+		return &statusChangelog{
+			IssueId:     issueType,
+			IssueType:   issueType,
+			FromValue:   from,
+			ToValue:     to,
+			CreatedDate: day.Add(time.Duration(hours) * time.Hour),
+		}
+	}
+	detector := &reopenDetector{deploymentDates: []time.Time{day.Add(2 * time.Hour), day.Add(8 * time.Hour)}}
+
+	// the fix of the bug got deployed before it was reopened
+	assert.Nil(t, detector.add(changelog(ticket.BUG, ticket.IN_PROGRESS, ticket.DONE, 1)))
+	assert.True(t, detector.add(changelog(ticket.BUG, ticket.DONE, ticket.TODO, 3)).IsReintroduced)
+	// no deployment between the resolution and the reopen
+	assert.Nil(t, detector.add(changelog(ticket.BUG, ticket.TODO, ticket.DONE, 4)))
+	assert.False(t, detector.add(changelog(ticket.BUG, ticket.DONE, ticket.TODO, 5)).IsReintroduced)
+	// only bugs get reintroduced
+	assert.Nil(t, detector.add(changelog(ticket.REQUIREMENT, ticket.IN_PROGRESS, ticket.DONE, 6)))
+	assert.False(t, detector.add(changelog(ticket.REQUIREMENT, ticket.DONE, ticket.TODO, 9)).IsReintroduced)
+}
@@ -0,0 +1,186 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var CalculateIssueTraceabilityMeta = plugin.SubTaskMeta{
+	Name:             "calculateIssueTraceability",
+	EntryPoint:       CalculateIssueTraceability,
+	EnabledByDefault: true,
+	Description:      "Calculate the weekly percentage of commits and pull requests linked to issues for each repo into issue_traceability_metrics",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE, plugin.DOMAIN_TYPE_CROSS},
+}
+
+type traceabilityRow struct {
+	RepoId string
+	Date   *time.Time
+	Linked bool
+}
+
+// CalculateIssueTraceability counts, per repo of the project per week, the commits linked to issues through
+// issue_commits and the pull requests linked through pull_request_issues. The weeks which are over are kept as
+// they were first reported, like the health score snapshots, so looking back shows the numbers of the time
+func CalculateIssueTraceability(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	metrics := map[string]*code.IssueTraceabilityMetric{}
+	commitFilter, prFilter := "pm.project_name = ?", "pm.project_name = ?"
+	if data.Options.ExcludeBotAccounts {
+		commitFilter += " AND " + notBotAuthor("c.author_id")
+		prFilter += " AND " + notBotAuthor("pr.author_id")
+	}
+
+	commitCursor, err := db.Cursor(
+		dal.Select(`rc.repo_id, c.authored_date AS date,
+			EXISTS(SELECT 1 FROM issue_commits ic WHERE ic.commit_sha = c.sha) AS linked`),
+		dal.From("repo_commits rc"),
+		dal.Join("JOIN commits c ON c.sha = rc.commit_sha"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = rc.repo_id)"),
+		dal.Where(commitFilter, data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	err = accumulateTraceability(db, commitCursor, metrics, false)
+	if err != nil {
+		return err
+	}
+
+	prCursor, err := db.Cursor(
+		dal.Select(`pr.base_repo_id AS repo_id, pr.created_date AS date,
+			EXISTS(SELECT 1 FROM pull_request_issues pri WHERE pri.pull_request_id = pr.id) AS linked`),
+		dal.From("pull_requests pr"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = pr.base_repo_id)"),
+		dal.Where(prFilter, data.Options.ProjectName),
+	)
+	if err != nil {
+		return err
+	}
+	err = accumulateTraceability(db, prCursor, metrics, true)
+	if err != nil {
+		return err
+	}
+
+	rawDataSubTask, err := api.NewRawDataSubTask(api.RawDataSubTaskArgs{
+		Ctx:    taskCtx,
+		Params: DoraApiParams{ProjectName: data.Options.ProjectName},
+		Table:  "issue_traceability_metrics",
+	})
+	if err != nil {
+		return err
+	}
+	var reported []*code.IssueTraceabilityMetric
+	err = db.All(
+		&reported,
+		dal.Select("repo_id, week_start"),
+		dal.From(&code.IssueTraceabilityMetric{}),
+		dal.Where("raw_data_params = ? AND week_start < ?", rawDataSubTask.GetParams(), weekStart(time.Now())),
+	)
+	if err != nil {
+		return err
+	}
+	for _, metric := range reported {
+		delete(metrics, traceabilityKey(metric.RepoId, metric.WeekStart))
+	}
+	batch, err := api.NewBatchSave(taskCtx, reflect.TypeOf(&code.IssueTraceabilityMetric{}), 500)
+	if err != nil {
+		return err
+	}
+	for _, metric := range metrics {
+		metric.RawDataOrigin = common.RawDataOrigin{
+			RawDataTable:  rawDataSubTask.GetTable(),
+			RawDataParams: rawDataSubTask.GetParams(),
+		}
+		finalizeTraceability(metric)
+		err = batch.Add(metric)
+		if err != nil {
+			return err
+		}
+	}
+	return batch.Close()
+}
+
+func accumulateTraceability(db dal.Dal, cursor dal.Rows, metrics map[string]*code.IssueTraceabilityMetric, isPullRequest bool) errors.Error {
+	defer cursor.Close()
+	for cursor.Next() {
+		row := &traceabilityRow{}
+		err := db.Fetch(cursor, row)
+		if err != nil {
+			return err
+		}
+		addTraceabilityRow(metrics, row, isPullRequest)
+	}
+	return nil
+}
+
+func addTraceabilityRow(metrics map[string]*code.IssueTraceabilityMetric, row *traceabilityRow, isPullRequest bool) {
+	if row.Date == nil {
+		return
+	}
+	week := weekStart(*row.Date)
+	key := traceabilityKey(row.RepoId, week)
+	metric, ok := metrics[key]
+	if !ok {
+		metric = &code.IssueTraceabilityMetric{RepoId: row.RepoId, WeekStart: week}
+		metrics[key] = metric
+	}
+	if isPullRequest {
+		metric.PullRequestsCount++
+		if row.Linked {
+			metric.LinkedPullRequestsCount++
+		}
+	} else {
+		metric.CommitsCount++
+		if row.Linked {
+			metric.LinkedCommitsCount++
+		}
+	}
+}
+
+func traceabilityKey(repoId string, week time.Time) string {
+	return fmt.Sprintf("%s:%s", repoId, week.UTC().Format(time.DateOnly))
+}
+
+func finalizeTraceability(metric *code.IssueTraceabilityMetric) {
+	if metric.CommitsCount > 0 {
+		metric.CommitLinkRate = float64(metric.LinkedCommitsCount) * 100 / float64(metric.CommitsCount)
+	}
+	if metric.PullRequestsCount > 0 {
+		metric.PullRequestLinkRate = float64(metric.LinkedPullRequestsCount) * 100 / float64(metric.PullRequestsCount)
+	}
+}
+
+// weekStart returns the Monday 00:00 UTC of the week `t` belongs to
+func weekStart(t time.Time) time.Time {
+	t = t.UTC()
+	day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+	offset := (int(day.Weekday()) + 6) % 7
+	return day.AddDate(0, 0, -offset)
+}
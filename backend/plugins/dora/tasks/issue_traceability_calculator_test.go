@@ -0,0 +1,69 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWeekStart(t *testing.T) {
+	monday := time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, monday, weekStart(monday))
+	assert.Equal(t, monday, weekStart(time.Date(2023, 12, 20, 13, 4, 5, 0, time.UTC)))
+	assert.Equal(t, monday, weekStart(time.Date(2023, 12, 24, 23, 59, 59, 0, time.UTC)))
+	// 2023-12-18T01:00:00+08:00 is still Sunday in UTC
+	assert.Equal(t, monday.AddDate(0, 0, -7), weekStart(time.Date(2023, 12, 18, 1, 0, 0, 0, time.FixedZone("", 8*3600))))
+}
+
+func TestAddTraceabilityRow(t *testing.T) {
+	metrics := map[string]*code.IssueTraceabilityMetric{}
+	date := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	nextWeek := date.AddDate(0, 0, 7)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r1", Date: &date, Linked: true}, false)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r1", Date: &date, Linked: false}, false)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r1", Date: &date, Linked: false}, false)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r1", Date: &date, Linked: true}, true)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r1", Date: &nextWeek, Linked: false}, true)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r2", Date: &date, Linked: true}, false)
+	addTraceabilityRow(metrics, &traceabilityRow{RepoId: "r2", Linked: true}, false)
+	assert.Len(t, metrics, 3)
+
+	metric := metrics["r1:2023-12-18"]
+	finalizeTraceability(metric)
+	assert.Equal(t, 3, metric.CommitsCount)
+	assert.Equal(t, 1, metric.LinkedCommitsCount)
+	assert.InDelta(t, 33.33, metric.CommitLinkRate, 0.01)
+	assert.Equal(t, 1, metric.PullRequestsCount)
+	assert.Equal(t, float64(100), metric.PullRequestLinkRate)
+
+	metric = metrics["r1:2023-12-25"]
+	finalizeTraceability(metric)
+	assert.Equal(t, 0, metric.CommitsCount)
+	assert.Equal(t, float64(0), metric.CommitLinkRate)
+	assert.Equal(t, float64(0), metric.PullRequestLinkRate)
+
+	assert.Equal(t, 1, metrics["r2:2023-12-18"].LinkedCommitsCount)
+
+	// the week starts loaded from the database match regardless of their location
+	monday := time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, "r1:2023-12-18", traceabilityKey("r1", monday.In(time.FixedZone("", -5*3600))))
+}
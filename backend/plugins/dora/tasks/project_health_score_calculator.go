@@ -0,0 +1,255 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// healthScoreWindowDays is the period before the snapshot date the health score is evaluated on
+const healthScoreWindowDays = 30
+
+var CalculateProjectHealthScoreMeta = plugin.SubTaskMeta{
+	Name:             "calculateProjectHealthScore",
+	EntryPoint:       CalculateProjectHealthScore,
+	EnabledByDefault: true,
+	Description:      "Calculate the weighted health score of the project for the last 30 days into project_health_scores",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CROSS},
+}
+
+// CalculateProjectHealthScore snapshots the health score of the project, it is made of
+//   - dora: the DORA performance level of the median change lead time and the deployment frequency
+//   - review coverage: the percentage of merged pull requests reviewed or commented by someone other than the author
+//   - test stability: the success rate of the finished pipelines
+//   - incident load: 100 minus 10 points per incident created
+func CalculateProjectHealthScore(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	projectName := data.Options.ProjectName
+	now := time.Now().UTC()
+	since := now.AddDate(0, 0, -healthScoreWindowDays)
+
+	snapshot := &crossdomain.ProjectHealthScore{
+		ProjectName:  projectName,
+		SnapshotDate: time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC),
+	}
+
+	// dora
+	var cycleTimes []int64
+	err := db.Pluck("ppm.pr_cycle_time", &cycleTimes,
+		dal.From("project_pr_metrics ppm"),
+		dal.Join("JOIN cicd_deployment_commits dc ON dc.id = ppm.deployment_commit_id"),
+		dal.Where("ppm.project_name = ? AND ppm.pr_cycle_time IS NOT NULL AND dc.finished_date >= ?", projectName, since),
+	)
+	if err != nil {
+		return err
+	}
+	var deploymentDates []time.Time
+	err = db.Pluck("d.finished_date", &deploymentDates,
+		dal.From("cicd_deployments d"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = d.cicd_scope_id)"),
+		dal.Where(
+			"pm.project_name = ? AND d.environment = ? AND d.result = ? AND d.finished_date >= ?",
+			projectName, devops.PRODUCTION, devops.RESULT_SUCCESS, since,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	snapshot.DoraScore = doraScore(cycleTimes, deploymentDates)
+
+	// review coverage, the pull requests of bots and the comments from bots are left out when asked to
+	prFilter, reviewFilter := "pm.project_name = ? AND pr.merged_date >= ?", "prc.account_id != pr.author_id"
+	if data.Options.ExcludeBotAccounts {
+		prFilter += " AND " + notBotAuthor("pr.author_id")
+		reviewFilter += " AND " + notBotAuthor("prc.account_id")
+	}
+	mergedPrs, err := db.Count(
+		dal.From("pull_requests pr"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = pr.base_repo_id)"),
+		dal.Where(prFilter, projectName, since),
+	)
+	if err != nil {
+		return err
+	}
+	reviewedPrs, err := db.Count(
+		dal.From("pull_requests pr"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = pr.base_repo_id)"),
+		dal.Where(
+			prFilter+" AND EXISTS(SELECT 1 FROM pull_request_comments prc WHERE prc.pull_request_id = pr.id AND "+reviewFilter+")",
+			projectName, since,
+		),
+	)
+	if err != nil {
+		return err
+	}
+	snapshot.ReviewCoverageScore = percentage(reviewedPrs, mergedPrs)
+
+	// test stability
+	finishedPipelines, err := db.Count(
+		dal.From("cicd_pipelines p"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = p.cicd_scope_id)"),
+		dal.Where(
+			"pm.project_name = ? AND p.finished_date >= ? AND p.result IN ?",
+			projectName, since, []string{devops.RESULT_SUCCESS, devops.RESULT_FAILURE},
+		),
+	)
+	if err != nil {
+		return err
+	}
+	successfulPipelines, err := db.Count(
+		dal.From("cicd_pipelines p"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = p.cicd_scope_id)"),
+		dal.Where("pm.project_name = ? AND p.finished_date >= ? AND p.result = ?", projectName, since, devops.RESULT_SUCCESS),
+	)
+	if err != nil {
+		return err
+	}
+	snapshot.TestStabilityScore = percentage(successfulPipelines, finishedPipelines)
+
+	// incident load, only evaluated when the project has boards
+	boards, err := db.Count(
+		dal.From("project_mapping pm"),
+		dal.Where("pm.project_name = ? AND pm.table = 'boards'", projectName),
+	)
+	if err != nil {
+		return err
+	}
+	if boards > 0 {
+		incidents, err := db.Count(
+			dal.From("issues i"),
+			dal.Join("JOIN board_issues bi ON bi.issue_id = i.id"),
+			dal.Join("JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+			dal.Where("pm.project_name = ? AND i.type = ? AND i.created_date >= ?", projectName, ticket.INCIDENT, since),
+		)
+		if err != nil {
+			return err
+		}
+		snapshot.IncidentLoadScore = incidentLoadScore(incidents)
+	}
+
+	snapshot.Score = weightedHealthScore(snapshot, data.Options.HealthScoreWeights)
+	rawDataSubTask, err := api.NewRawDataSubTask(api.RawDataSubTaskArgs{
+		Ctx:    taskCtx,
+		Params: DoraApiParams{ProjectName: projectName},
+		Table:  "project_health_scores",
+	})
+	if err != nil {
+		return err
+	}
+	snapshot.RawDataOrigin = common.RawDataOrigin{
+		RawDataTable:  rawDataSubTask.GetTable(),
+		RawDataParams: rawDataSubTask.GetParams(),
+	}
+	return db.CreateOrUpdate(snapshot)
+}
+
+func percentage(part, total int64) *float64 {
+	if total == 0 {
+		return nil
+	}
+	result := float64(part) * 100 / float64(total)
+	return &result
+}
+
+// doraScore maps the median change lead time and the deployment frequency to the DORA performance levels,
+// elite scores 100, high 75, medium 50 and low 25
+func doraScore(cycleTimeMinutes []int64, deploymentDates []time.Time) *float64 {
+	var scores []float64
+	if len(cycleTimeMinutes) > 0 {
+		sort.Slice(cycleTimeMinutes, func(i, j int) bool { return cycleTimeMinutes[i] < cycleTimeMinutes[j] })
+		median := cycleTimeMinutes[len(cycleTimeMinutes)/2]
+		switch {
+		case median < 24*60:
+			scores = append(scores, 100)
+		case median < 7*24*60:
+			scores = append(scores, 75)
+		case median < healthScoreWindowDays*24*60:
+			scores = append(scores, 50)
+		default:
+			scores = append(scores, 25)
+		}
+	}
+	deploymentDays := map[string]bool{}
+	for _, date := range deploymentDates {
+		deploymentDays[date.UTC().Format(time.DateOnly)] = true
+	}
+	switch {
+	// deploying on most of the working days
+	case len(deploymentDays) >= 15:
+		scores = append(scores, 100)
+	case len(deploymentDays) >= 4:
+		scores = append(scores, 75)
+	case len(deploymentDays) >= 1:
+		scores = append(scores, 50)
+	case len(scores) > 0:
+		scores = append(scores, 25)
+	}
+	if len(scores) == 0 {
+		return nil
+	}
+	var sum float64
+	for _, score := range scores {
+		sum += score
+	}
+	result := sum / float64(len(scores))
+	return &result
+}
+
+func incidentLoadScore(incidents int64) *float64 {
+	result := math.Max(0, 100-10*float64(incidents))
+	return &result
+}
+
+// weightedHealthScore is the weighted average of the available component scores
+func weightedHealthScore(snapshot *crossdomain.ProjectHealthScore, weights *HealthScoreWeights) float64 {
+	if weights == nil {
+		weights = &HealthScoreWeights{Dora: 1, ReviewCoverage: 1, TestStability: 1, IncidentLoad: 1}
+	}
+	var sum, totalWeight float64
+	for _, component := range []struct {
+		score  *float64
+		weight float64
+	}{
+		{snapshot.DoraScore, weights.Dora},
+		{snapshot.ReviewCoverageScore, weights.ReviewCoverage},
+		{snapshot.TestStabilityScore, weights.TestStability},
+		{snapshot.IncidentLoadScore, weights.IncidentLoad},
+	} {
+		if component.score == nil || component.weight <= 0 {
+			continue
+		}
+		sum += *component.score * component.weight
+		totalWeight += component.weight
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return sum / totalWeight
+}
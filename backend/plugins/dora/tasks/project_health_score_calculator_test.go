@@ -0,0 +1,61 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoraScore(t *testing.T) {
+	assert.Nil(t, doraScore(nil, nil))
+
+	day := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	var dailyDeployments []time.Time
+	for i := 0; i < 20; i++ {
+		// two deployments a day count as one day
+		dailyDeployments = append(dailyDeployments, day.AddDate(0, 0, i), day.AddDate(0, 0, i).Add(time.Hour))
+	}
+	// elite lead time and deployment frequency
+	assert.Equal(t, float64(100), *doraScore([]int64{60, 120, 3000}, dailyDeployments))
+	// high lead time, monthly deployments
+	assert.Equal(t, float64(62.5), *doraScore([]int64{3 * 24 * 60}, dailyDeployments[:1]))
+	// low lead time, no deployments at all
+	assert.Equal(t, float64(25), *doraScore([]int64{60 * 24 * 60}, nil))
+}
+
+func TestWeightedHealthScore(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	snapshot := &crossdomain.ProjectHealthScore{
+		DoraScore:           score(100),
+		ReviewCoverageScore: score(50),
+		TestStabilityScore:  score(90),
+	}
+	// incident load is not available
+	assert.Equal(t, float64(80), weightedHealthScore(snapshot, nil))
+	assert.Equal(t, float64(75), weightedHealthScore(snapshot, &HealthScoreWeights{Dora: 1, ReviewCoverage: 1}))
+	assert.Equal(t, float64(0), weightedHealthScore(&crossdomain.ProjectHealthScore{}, nil))
+
+	assert.Equal(t, float64(70), *incidentLoadScore(3))
+	assert.Equal(t, float64(0), *incidentLoadScore(12))
+	assert.Nil(t, percentage(1, 0))
+	assert.Equal(t, float64(25), *percentage(1, 4))
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var EnrichRollbackDeploymentsMeta = plugin.SubTaskMeta{
+	Name:             "enrichRollbackDeployments",
+	EntryPoint:       EnrichRollbackDeployments,
+	EnabledByDefault: true,
+	Description:      "Mark cicd_deployments matching rollbackPattern as rollbacks and link them to the deployments they rolled back",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+// EnrichRollbackDeployments flags deployments whose name matches the rollbackPattern as rollbacks, a rollback
+// without an explicit rollback_of_deployment_id (i.e. from the webhook) is linked to the previous deployment
+// of the same cicd_scope and environment, so the remediation time of the failed change is its finished_date
+// minus the finished_date of the rolled back deployment
+func EnrichRollbackDeployments(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*DoraTaskData)
+	cursor, err := db.Cursor(
+		dal.Select("d.*"),
+		dal.From("cicd_deployments d"),
+		dal.Join("LEFT JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = d.cicd_scope_id)"),
+		dal.Where(
+			`
+			d.finished_date IS NOT NULL
+			AND d.environment IS NOT NULL AND d.environment != ''
+			AND pm.project_name = ?
+			`,
+			data.Options.ProjectName,
+		),
+		dal.Orderby(`d.cicd_scope_id, d.environment, d.finished_date`),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	var prev *devops.CICDDeployment
+	enricher, err := api.NewDataEnricher(api.DataEnricherArgs[devops.CICDDeployment]{
+		Ctx:   taskCtx,
+		Name:  "rollback_deployment_enricher",
+		Input: cursor,
+		Enrich: func(deployment *devops.CICDDeployment) ([]interface{}, errors.Error) {
+			if prev != nil && (prev.CicdScopeId != deployment.CicdScopeId || prev.Environment != deployment.Environment) {
+				prev = nil
+			}
+			changed := enrichRollback(deployment, prev, data.RollbackRegex)
+			prev = deployment
+			if !changed {
+				return nil, nil
+			}
+			return []interface{}{deployment}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return enricher.Execute()
+}
+
+// enrichRollback updates the rollback fields of the deployment, `prev` is the previous deployment of the same
+// cicd_scope and environment, it returns whether the deployment was changed
+func enrichRollback(deployment *devops.CICDDeployment, prev *devops.CICDDeployment, rollbackRegex *regexp.Regexp) bool {
+	changed := false
+	if !deployment.IsRollback && rollbackRegex != nil && rollbackRegex.MatchString(deployment.Name) {
+		deployment.IsRollback = true
+		changed = true
+	}
+	if deployment.IsRollback && deployment.RollbackOfDeploymentId == "" && prev != nil {
+		deployment.RollbackOfDeploymentId = prev.Id
+		changed = true
+	}
+	return changed
+}
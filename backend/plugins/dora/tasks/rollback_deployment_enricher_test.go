@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEnrichRollback(t *testing.T) {
+	rollbackRegex := regexp.MustCompile(`(?i)rollback`)
+	prev := &devops.CICDDeployment{DomainEntity: domainlayer.DomainEntity{Id: "d1"}, Name: "deploy v2"}
+
+	deployment := &devops.CICDDeployment{Name: "Rollback to v1"}
+	assert.True(t, enrichRollback(deployment, prev, rollbackRegex))
+	assert.True(t, deployment.IsRollback)
+	assert.Equal(t, "d1", deployment.RollbackOfDeploymentId)
+
+	// already linked
+	assert.False(t, enrichRollback(deployment, prev, rollbackRegex))
+
+	// explicit rollback from the webhook without a pattern
+	deployment = &devops.CICDDeployment{Name: "deploy v1", IsRollback: true}
+	assert.True(t, enrichRollback(deployment, prev, nil))
+	assert.Equal(t, "d1", deployment.RollbackOfDeploymentId)
+
+	// explicit link is kept
+	deployment = &devops.CICDDeployment{Name: "rollback", IsRollback: true, RollbackOfDeploymentId: "d0"}
+	assert.False(t, enrichRollback(deployment, prev, rollbackRegex))
+	assert.Equal(t, "d0", deployment.RollbackOfDeploymentId)
+
+	// not a rollback
+	deployment = &devops.CICDDeployment{Name: "deploy v3"}
+	assert.False(t, enrichRollback(deployment, prev, rollbackRegex))
+	assert.False(t, deployment.IsRollback)
+
+	// the first deployment of the environment has nothing to roll back
+	deployment = &devops.CICDDeployment{Name: "rollback"}
+	assert.True(t, enrichRollback(deployment, nil, rollbackRegex))
+	assert.Empty(t, deployment.RollbackOfDeploymentId)
+}
@@ -18,6 +18,8 @@ limitations under the License.
 package tasks
 
 import (
+	"regexp"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
@@ -30,10 +32,26 @@ type DoraOptions struct {
 	Tasks       []string `json:"tasks,omitempty"`
 	Since       string
 	ProjectName string `json:"projectName"`
+	// RollbackPattern matches the names of deployments that are rollbacks, i.e. `(?i)rollback|revert`
+	RollbackPattern string `json:"rollbackPattern"`
+	// HealthScoreWeights overrides the weights of the project health score components which all default to 1,
+	// a zero weight excludes the component
+	HealthScoreWeights *HealthScoreWeights `json:"healthScoreWeights,omitempty"`
+	// ExcludeBotAccounts excludes the pull requests and review comments authored by bot accounts from the change
+	// lead time, review coverage and issue traceability metrics, and the bot commits from the issue traceability
+	ExcludeBotAccounts bool `json:"excludeBotAccounts"`
+}
+
+type HealthScoreWeights struct {
+	Dora           float64 `json:"dora"`
+	ReviewCoverage float64 `json:"reviewCoverage"`
+	TestStability  float64 `json:"testStability"`
+	IncidentLoad   float64 `json:"incidentLoad"`
 }
 
 type DoraTaskData struct {
-	Options *DoraOptions
+	Options       *DoraOptions
+	RollbackRegex *regexp.Regexp
 }
 
 func DecodeAndValidateTaskOptions(options map[string]interface{}) (*DoraOptions, errors.Error) {
@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"regexp"
+	"strings"
+)
+
+// Locations are the paths a CODEOWNERS file is looked up in, the first one found is used
+var Locations = []string{".github/CODEOWNERS", "CODEOWNERS", "docs/CODEOWNERS", ".gitlab/CODEOWNERS"}
+
+// gitlabSection matches the section headers of GitLab, i.e. `[Docs]` or `^[Backend][2] @owner`
+var gitlabSection = regexp.MustCompile(`^\^?\[[^\]]+\](\[\d+\])?`)
+
+// Rule is a line of the CODEOWNERS file, a rule without owners makes the matched files unowned
+type Rule struct {
+	Pattern string
+	Owners  []string
+	re      *regexp.Regexp
+}
+
+// Ruleset is the parsed CODEOWNERS file, the rules are kept in the order of the file
+type Ruleset []*Rule
+
+// Parse parses the CODEOWNERS file with the syntax shared by GitHub and GitLab, lines it doesn't
+// understand (i.e. negated patterns, which neither supports) are skipped like GitHub does
+func Parse(content []byte) Ruleset {
+	var rules Ruleset
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "!") || gitlabSection.MatchString(line) {
+			continue
+		}
+		fields := splitFields(line)
+		re, err := compilePattern(fields[0])
+		if err != nil {
+			continue
+		}
+		rule := &Rule{Pattern: fields[0], re: re}
+		for _, owner := range fields[1:] {
+			if strings.HasPrefix(owner, "#") {
+				break
+			}
+			rule.Owners = append(rule.Owners, owner)
+		}
+		rules = append(rules, rule)
+	}
+	return rules
+}
+
+// Match returns the rule deciding the owners of `path`, the last matching rule takes precedence
+func (rs Ruleset) Match(path string) *Rule {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rs) - 1; i >= 0; i-- {
+		if rs[i].re.MatchString(path) {
+			return rs[i]
+		}
+	}
+	return nil
+}
+
+// splitFields splits the line by whitespaces, a backslash escapes the following character
+func splitFields(line string) []string {
+	var fields []string
+	var sb strings.Builder
+	escaped := false
+	for _, c := range line {
+		switch {
+		case escaped:
+			sb.WriteRune(c)
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == ' ' || c == '\t':
+			if sb.Len() > 0 {
+				fields = append(fields, sb.String())
+				sb.Reset()
+			}
+		default:
+			sb.WriteRune(c)
+		}
+	}
+	if sb.Len() > 0 {
+		fields = append(fields, sb.String())
+	}
+	return fields
+}
+
+// compilePattern translates the gitignore style pattern into a regexp matching the file paths:
+// a pattern containing a slash (except a trailing one) is relative to the root, otherwise it matches at any depth,
+// a pattern matching a directory matches everything beneath it, except when its last segment ends with a
+// single `*` (i.e. `docs/*`) which only matches the files directly inside
+func compilePattern(pattern string) (*regexp.Regexp, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	body := strings.Trim(pattern, "/")
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(body, "/")
+	var sb strings.Builder
+	if anchored {
+		sb.WriteString("^")
+	} else {
+		sb.WriteString("^(?:.*/)?")
+	}
+	for i := 0; i < len(body); i++ {
+		switch c := body[i]; {
+		case c == '*' && strings.HasPrefix(body[i:], "**/"):
+			sb.WriteString("(?:.*/)?")
+			i += 2
+		case c == '*' && strings.HasPrefix(body[i:], "**"):
+			sb.WriteString(".*")
+			i++
+		case c == '*':
+			sb.WriteString("[^/]*")
+		case c == '?':
+			sb.WriteString("[^/]")
+		default:
+			sb.WriteString(regexp.QuoteMeta(body[i : i+1]))
+		}
+	}
+	switch {
+	case dirOnly:
+		sb.WriteString("/.*$")
+	case strings.HasSuffix(body, "*") && !strings.HasSuffix(body, "**"):
+		sb.WriteString("$")
+	default:
+		sb.WriteString("(?:/.*)?$")
+	}
+	return regexp.Compile(sb.String())
+}
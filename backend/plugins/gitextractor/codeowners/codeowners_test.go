@@ -0,0 +1,77 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+const sample = `
+# default owners
+*       @org/everyone
+
+*.js    @org/frontend # inline comment
+**/logs @org/ops
+/build/logs/ @doctocat
+docs/*  docs@example.com
+apps/   @octocat
+/scripts/ @org/devops @org/sre
+/scripts/generated
+path\ with\ space/ @org/space
+
+[Backend][2] @org/backend
+/internal/ @org/core
+!negated @nobody
+`
+
+func TestParse(t *testing.T) {
+	rules := Parse([]byte(sample))
+	assert.Len(t, rules, 10)
+	assert.Equal(t, "*", rules[0].Pattern)
+	assert.Equal(t, []string{"@org/frontend"}, rules[1].Owners)
+	assert.Equal(t, []string{"@org/devops", "@org/sre"}, rules[6].Owners)
+	assert.Empty(t, rules[7].Owners)
+	assert.Equal(t, "path with space/", rules[8].Pattern)
+}
+
+func TestMatch(t *testing.T) {
+	rules := Parse([]byte(sample))
+	owners := func(path string) []string {
+		rule := rules.Match(path)
+		if rule == nil {
+			return nil
+		}
+		return rule.Owners
+	}
+	assert.Equal(t, []string{"@org/everyone"}, owners("README.md"))
+	assert.Equal(t, []string{"@org/frontend"}, owners("web/src/index.js"))
+	assert.Equal(t, []string{"@doctocat"}, owners("build/logs/today.log"))
+	assert.Equal(t, []string{"@org/ops"}, owners("src/build/logs/today.log"))
+	assert.Equal(t, []string{"docs@example.com"}, owners("docs/getting-started.md"))
+	assert.Equal(t, []string{"@org/everyone"}, owners("docs/build-app/troubleshooting.md"))
+	assert.Equal(t, []string{"@octocat"}, owners("apps/api/main.go"))
+	assert.Equal(t, []string{"@octocat"}, owners("services/apps/main.go"))
+	assert.Equal(t, []string{"@org/ops"}, owners("deep/nested/logs/app.log"))
+	assert.Equal(t, []string{"@org/devops", "@org/sre"}, owners("scripts/build.sh"))
+	assert.Empty(t, owners("scripts/generated/a.sh"))
+	assert.Equal(t, []string{"@org/space"}, owners("path with space/file"))
+	assert.Equal(t, []string{"@org/core"}, owners("internal/x.go"))
+	assert.Nil(t, Ruleset{}.Match("README.md"))
+}
@@ -31,5 +31,8 @@ type Store interface {
 	CommitFileComponents(commitFileComponent *code.CommitFileComponent) errors.Error
 	CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error
 	RepoSnapshot(snapshot *code.RepoSnapshot) errors.Error
+	RepoFileOwners(owner *code.RepoFileOwner) errors.Error
+	// DeleteRepoFileOwners removes the file owners of the repo, for the repos whose CODEOWNERS file is gone
+	DeleteRepoFileOwners(repoId string) errors.Error
 	Close() errors.Error
 }
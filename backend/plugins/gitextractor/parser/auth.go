@@ -0,0 +1,147 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/sigv4"
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+)
+
+// UseServerAwsCredentials lets the CodeCommit remotes without credentials fall back to the AWS_ACCESS_KEY_ID,
+// AWS_SECRET_ACCESS_KEY and AWS_SESSION_TOKEN environment variables of the server, it is off by default since
+// every connection would then clone with the credentials of the server
+const UseServerAwsCredentials = "GIT_CODECOMMIT_USE_SERVER_CREDENTIALS"
+
+var codeCommitHostPattern = regexp.MustCompile(`^git-codecommit(?:-fips)?\.([a-z0-9-]+)\.amazonaws\.com(?:\.cn)?$`)
+
+// newHttpAuth returns the auth method to clone `repoUrl` over http(s). Remotes with their own credential
+// schemes (Azure Repos, AWS CodeCommit) get a dedicated helper, so credentials never have to be embedded in the url
+func newHttpAuth(repoUrl, user, password string, useServerAwsCredentials bool) (githttp.AuthMethod, errors.Error) {
+	u, err := neturl.Parse(repoUrl)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid repo url")
+	}
+	host := u.Hostname()
+	if isAzureHost(host) && password != "" {
+		// Azure Repos ignores the username, the PAT alone identifies the user
+		return &azurePatAuth{pat: password}, nil
+	}
+	// the IAM "HTTPS Git credentials" of CodeCommit are plain basic auth, only the access keys are signed with SigV4
+	matches := codeCommitHostPattern.FindStringSubmatch(host)
+	if matches != nil && (isAwsAccessKeyId(user) || (user == "" && useServerAwsCredentials)) {
+		auth := &codeCommitAuth{
+			region:          matches[1],
+			host:            host,
+			path:            u.Path,
+			accessKeyId:     user,
+			secretAccessKey: password,
+			now:             time.Now,
+		}
+		// fall back to the standard AWS environment variables of the server, the instance metadata is not looked up
+		if auth.accessKeyId == "" {
+			auth.accessKeyId = os.Getenv("AWS_ACCESS_KEY_ID")
+			auth.secretAccessKey = os.Getenv("AWS_SECRET_ACCESS_KEY")
+			auth.sessionToken = os.Getenv("AWS_SESSION_TOKEN")
+		}
+		if auth.accessKeyId == "" || auth.secretAccessKey == "" {
+			return nil, errors.BadInput.New("AWS access key is required to clone from CodeCommit")
+		}
+		return auth, nil
+	}
+	if user != "" {
+		return &githttp.BasicAuth{
+			Username: user,
+			Password: password,
+		}, nil
+	}
+	return nil, nil
+}
+
+// isAwsAccessKeyId tells the long-term (AKIA) and temporary (ASIA) AWS access key ids apart from other usernames
+func isAwsAccessKeyId(user string) bool {
+	return strings.HasPrefix(user, "AKIA") || strings.HasPrefix(user, "ASIA")
+}
+
+func isAzureHost(host string) bool {
+	return host == "dev.azure.com" || strings.HasSuffix(host, ".visualstudio.com")
+}
+
+// azurePatAuth authenticates against Azure Repos with a personal access token,
+// see https://learn.microsoft.com/en-us/azure/devops/organizations/accounts/use-personal-access-tokens-to-authenticate
+type azurePatAuth struct {
+	pat string
+}
+
+func (a *azurePatAuth) SetAuth(r *http.Request) {
+	r.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte(":"+a.pat)))
+}
+
+func (a *azurePatAuth) Name() string {
+	return "azure-pat-auth"
+}
+
+func (a *azurePatAuth) String() string {
+	return fmt.Sprintf("%s - %s", a.Name(), "*******")
+}
+
+// codeCommitAuth signs the git requests to AWS CodeCommit with SigV4 the same way as the
+// `aws codecommit credential-helper` does, the signature is renewed for every request since it expires
+type codeCommitAuth struct {
+	region          string
+	host            string
+	path            string
+	accessKeyId     string
+	secretAccessKey string
+	sessionToken    string
+	now             func() time.Time
+}
+
+func (a *codeCommitAuth) SetAuth(r *http.Request) {
+	r.SetBasicAuth(a.username(), a.password(a.now().UTC()))
+}
+
+func (a *codeCommitAuth) Name() string {
+	return "codecommit-sigv4-auth"
+}
+
+func (a *codeCommitAuth) String() string {
+	return fmt.Sprintf("%s - %s:%s", a.Name(), a.accessKeyId, "*******")
+}
+
+func (a *codeCommitAuth) username() string {
+	if a.sessionToken != "" {
+		return a.accessKeyId + "%" + a.sessionToken
+	}
+	return a.accessKeyId
+}
+
+func (a *codeCommitAuth) password(now time.Time) string {
+	timestamp := now.Format("20060102T150405")
+	canonicalRequest := fmt.Sprintf("GIT\n%s\n\nhost:%s\n\nhost\n", a.path, a.host)
+	return timestamp + "Z" + sigv4.Signature(a.secretAccessKey, timestamp, now.Format("20060102"), a.region, "codecommit", canonicalRequest)
+}
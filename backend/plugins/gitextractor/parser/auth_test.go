@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	githttp "github.com/go-git/go-git/v5/plumbing/transport/http"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewHttpAuth(t *testing.T) {
+	auth, err := newHttpAuth("https://github.com/apache/incubator-devlake.git", "", "", false)
+	assert.Nil(t, err)
+	assert.Nil(t, auth)
+
+	auth, err = newHttpAuth("https://github.com/apache/incubator-devlake.git", "user", "token", false)
+	assert.Nil(t, err)
+	assert.IsType(t, &githttp.BasicAuth{}, auth)
+
+	auth, err = newHttpAuth("https://org@dev.azure.com/org/project/_git/repo", "", "secret-pat", false)
+	assert.Nil(t, err)
+	req, _ := http.NewRequest(http.MethodGet, "https://dev.azure.com/org/project/_git/repo/info/refs", nil)
+	auth.SetAuth(req)
+	assert.Equal(t, "Basic OnNlY3JldC1wYXQ=", req.Header.Get("Authorization"))
+	assert.NotContains(t, auth.String(), "secret-pat")
+
+	t.Setenv("AWS_ACCESS_KEY_ID", "")
+	_, err = newHttpAuth("https://git-codecommit.us-east-1.amazonaws.com/v1/repos/demo", "", "", true)
+	assert.NotNil(t, err)
+
+	auth, err = newHttpAuth("https://git-codecommit.us-east-1.amazonaws.com/v1/repos/demo", "AKIAEXAMPLE", "secret", false)
+	assert.Nil(t, err)
+	assert.IsType(t, &codeCommitAuth{}, auth)
+	assert.Equal(t, "us-east-1", auth.(*codeCommitAuth).region)
+
+	// the IAM HTTPS Git credentials keep using basic auth
+	auth, err = newHttpAuth("https://git-codecommit.us-east-1.amazonaws.com/v1/repos/demo", "user-at-123456789012", "generated", false)
+	assert.Nil(t, err)
+	assert.Equal(t, &githttp.BasicAuth{Username: "user-at-123456789012", Password: "generated"}, auth)
+}
+
+func TestCodeCommitAuth(t *testing.T) {
+	t.Setenv("AWS_ACCESS_KEY_ID", "AKID")
+	t.Setenv("AWS_SECRET_ACCESS_KEY", "secret")
+	t.Setenv("AWS_SESSION_TOKEN", "token")
+	// the credentials of the server are only used when allowed
+	auth, err := newHttpAuth("https://git-codecommit.us-east-1.amazonaws.com/v1/repos/demo", "", "", false)
+	assert.Nil(t, err)
+	assert.Nil(t, auth)
+	auth, err = newHttpAuth("https://git-codecommit.us-east-1.amazonaws.com/v1/repos/demo", "", "", true)
+	assert.Nil(t, err)
+	cc := auth.(*codeCommitAuth)
+	cc.now = func() time.Time {
+		return time.Date(2023, 12, 1, 12, 0, 0, 0, time.UTC)
+	}
+	req, _ := http.NewRequest(http.MethodGet, "https://git-codecommit.us-east-1.amazonaws.com/v1/repos/demo/info/refs", nil)
+	cc.SetAuth(req)
+	user, password, ok := req.BasicAuth()
+	assert.True(t, ok)
+	assert.Equal(t, "AKID%token", user)
+	assert.Equal(t, "20231201T120000Z5c80ad729abf5add85dd1b1caa10f8d90745fccd795ca626c947a6b12ace9708", password)
+}
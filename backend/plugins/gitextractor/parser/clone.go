@@ -25,6 +25,7 @@ import (
 	"fmt"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/go-git/go-git/v5/plumbing"
 	"github.com/go-git/go-git/v5/plumbing/protocol/packp/capability"
 	"github.com/go-git/go-git/v5/plumbing/transport"
 
@@ -48,21 +49,25 @@ import (
 
 const DefaultUser = "git"
 
-func cloneOverSSH(ctx plugin.SubTaskContext, url, dir, passphrase string, pk []byte) errors.Error {
+func newSSHAuth(passphrase string, pk []byte) (*ssh.PublicKeys, errors.Error) {
 	key, err := ssh.NewPublicKeys(DefaultUser, pk, passphrase)
 	if err != nil {
-		return errors.Convert(err)
+		return nil, errors.Convert(err)
 	}
 	key.HostKeyCallbackHelper = ssh.HostKeyCallbackHelper{
 		HostKeyCallback: func(hostname string, remote net.Addr, key ssh2.PublicKey) error {
 			return nil
 		},
 	}
+	return key, nil
+}
+
+func cloneOverSSH(ctx plugin.SubTaskContext, url, dir string, key *ssh.PublicKeys) errors.Error {
 	var data []byte
 	buf := bytes.NewBuffer(data)
 	done := make(chan struct{}, 1)
 	go refreshCloneProgress(ctx, done, buf)
-	_, err = gogit.PlainCloneContext(ctx.GetContext(), dir, true, &gogit.CloneOptions{
+	_, err := gogit.PlainCloneContext(ctx.GetContext(), dir, true, &gogit.CloneOptions{
 		URL:      url,
 		Auth:     key,
 		Progress: buf,
@@ -75,6 +80,15 @@ func cloneOverSSH(ctx plugin.SubTaskContext, url, dir, passphrase string, pk []b
 }
 
 func (l *GitRepoCreator) CloneOverHTTP(ctx plugin.SubTaskContext, repoId, url, user, password, proxy string) (*GitRepo, errors.Error) {
+	// the blueprints embed the token in the url, move it to the auth method so it is not saved in the repo config
+	url, urlUser, urlPassword := stripUrlCredentials(url)
+	if user == "" {
+		user, password = urlUser, urlPassword
+	}
+	auth, err := newHttpAuth(url, user, password, ctx.GetConfigReader().GetBool(UseServerAwsCredentials))
+	if err != nil {
+		return nil, err
+	}
 	return withTempDirectory(func(dir string) (*GitRepo, error) {
 		var data []byte
 		buf := bytes.NewBuffer(data)
@@ -82,6 +96,7 @@ func (l *GitRepoCreator) CloneOverHTTP(ctx plugin.SubTaskContext, repoId, url, u
 		go refreshCloneProgress(ctx, done, buf)
 		cloneOptions := &gogit.CloneOptions{
 			URL:      url,
+			Auth:     auth,
 			Progress: buf,
 		}
 		if proxy != "" {
@@ -104,12 +119,6 @@ func (l *GitRepoCreator) CloneOverHTTP(ctx plugin.SubTaskContext, repoId, url, u
 			}
 			client.InstallProtocol("https", githttp.NewClient(customClient))
 		}
-		if user != "" {
-			cloneOptions.Auth = &githttp.BasicAuth{
-				Username: user,
-				Password: password,
-			}
-		}
 		// fmt.Printf("CloneOverHTTP clone opt: %+v\ndir: %v, repo: %v, id: %v, user: %v, passwd: %v, proxy: %v\n", cloneOptions, dir, url, repoId, user, password, proxy)
 		if isAzureRepo(ctx.GetContext(), url) {
 			// https://github.com/go-git/go-git/issues/64
@@ -118,7 +127,10 @@ func (l *GitRepoCreator) CloneOverHTTP(ctx plugin.SubTaskContext, repoId, url, u
 				capability.ThinPack,
 			}
 		}
-		_, err := gogit.PlainCloneContext(ctx.GetContext(), dir, true, cloneOptions)
+		err := l.cloneOrRestore(ctx, repoId, url, dir, auth, func() error {
+			_, err := gogit.PlainCloneContext(ctx.GetContext(), dir, true, cloneOptions)
+			return err
+		})
 		done <- struct{}{}
 		if err != nil {
 			l.logger.Error(err, "PlainCloneContext")
@@ -134,7 +146,13 @@ func (l *GitRepoCreator) CloneOverSSH(ctx plugin.SubTaskContext, repoId, url, pr
 		if err != nil {
 			return nil, err
 		}
-		err = cloneOverSSH(ctx, url, dir, passphrase, pk)
+		key, err := newSSHAuth(passphrase, pk)
+		if err != nil {
+			return nil, err
+		}
+		err = l.cloneOrRestore(ctx, repoId, url, dir, key, func() error {
+			return cloneOverSSH(ctx, url, dir, key)
+		})
 		if err != nil {
 			return nil, err
 		}
@@ -142,6 +160,72 @@ func (l *GitRepoCreator) CloneOverSSH(ctx plugin.SubTaskContext, repoId, url, pr
 	})
 }
 
+// cloneOrRestore restores the repo from the cache and fetches the updates, it falls back to `clone` if the repo
+// isn't cached or the fetching fails. The up-to-date repo is saved back to the cache
+func (l *GitRepoCreator) cloneOrRestore(ctx plugin.SubTaskContext, repoId, url, dir string, auth transport.AuthMethod, clone func() error) error {
+	if l.cache == nil {
+		return clone()
+	}
+	restored, err := l.cache.Restore(ctx.GetContext(), repoId, dir)
+	if err != nil {
+		l.logger.Warn(err, "failed to restore repo %s from cache", repoId)
+	}
+	if restored {
+		if err := restoreRemoteConfig(dir, url); err != nil {
+			l.logger.Warn(err, "failed to restore the config of cached repo %s, clone it instead", repoId)
+			restored = false
+		} else if err := fetchUpdates(ctx.GetContext(), dir, auth); err != nil {
+			l.logger.Warn(err, "failed to fetch cached repo %s, clone it instead", repoId)
+			restored = false
+		}
+	}
+	if !restored {
+		// start over from an empty directory, the failed restoring or fetching might have left files behind
+		if err := os.RemoveAll(dir); err != nil {
+			return err
+		}
+		if err := os.MkdirAll(dir, 0700); err != nil {
+			return err
+		}
+		if err := clone(); err != nil {
+			return err
+		}
+	}
+	if err := l.cache.Save(ctx.GetContext(), repoId, dir); err != nil {
+		l.logger.Warn(err, "failed to save repo %s to cache", repoId)
+	}
+	return nil
+}
+
+// fetchUpdates fetches the remote branches and tags into the cached repo, and moves the default branch
+// to the latest commit of the remote like a fresh clone would
+func fetchUpdates(ctx context.Context, dir string, auth transport.AuthMethod) error {
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		return err
+	}
+	err = repo.FetchContext(ctx, &gogit.FetchOptions{
+		Auth:  auth,
+		Tags:  gogit.AllTags,
+		Force: true,
+	})
+	if err != nil && err != gogit.NoErrAlreadyUpToDate {
+		return err
+	}
+	head, err := repo.Storer.Reference(plumbing.HEAD)
+	if err != nil {
+		return err
+	}
+	if head.Type() != plumbing.SymbolicReference || !head.Target().IsBranch() {
+		return nil
+	}
+	remote, err := repo.Reference(plumbing.NewRemoteReferenceName(gogit.DefaultRemoteName, head.Target().Short()), true)
+	if err != nil {
+		return err
+	}
+	return repo.Storer.SetReference(plumbing.NewHashReference(head.Target(), remote.Hash()))
+}
+
 func withTempDirectory(f func(tempDir string) (*GitRepo, error)) (*GitRepo, errors.Error) {
 	dir, err := os.MkdirTemp("", "gitextractor")
 	if err != nil {
@@ -203,5 +287,6 @@ func refreshCloneProgress(subTaskCtx plugin.SubTaskContext, done chan struct{},
 }
 
 func isAzureRepo(ctx context.Context, repoUrl string) bool {
-	return strings.Contains(repoUrl, "dev.azure.com")
+	u, err := neturl.Parse(repoUrl)
+	return err == nil && isAzureHost(u.Hostname())
 }
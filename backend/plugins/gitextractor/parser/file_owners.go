@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/codeowners"
+
+	git "github.com/libgit2/git2go/v33"
+)
+
+// CollectFileOwners resolves the owners of every file at the head of the repo according to its CODEOWNERS file
+func (r *GitRepo) CollectFileOwners(subtaskCtx plugin.SubTaskContext) errors.Error {
+	head, err := r.repo.Head()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	commit, err := r.repo.LookupCommit(head.Target())
+	if err != nil {
+		return errors.Convert(err)
+	}
+	tree, err := commit.Tree()
+	if err != nil {
+		return errors.Convert(err)
+	}
+	var rules codeowners.Ruleset
+	for _, location := range codeowners.Locations {
+		entry, e := tree.EntryByPath(location)
+		if e != nil || entry.Type != git.ObjectBlob {
+			continue
+		}
+		blob, e := r.repo.LookupBlob(entry.Id)
+		if e != nil {
+			return errors.Convert(e)
+		}
+		rules = codeowners.Parse(blob.Contents())
+		break
+	}
+	if len(rules) == 0 {
+		// the owners resolved from a CODEOWNERS file removed since then are outdated
+		r.logger.Info("no CODEOWNERS rules found in repo %s", r.id)
+		return r.store.DeleteRepoFileOwners(r.id)
+	}
+	ctx := subtaskCtx.GetContext()
+	err = tree.Walk(func(root string, entry *git.TreeEntry) error {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		default:
+		}
+		if entry.Type != git.ObjectBlob {
+			return nil
+		}
+		path := root + entry.Name
+		rule := rules.Match(path)
+		if rule == nil {
+			return nil
+		}
+		for _, owner := range rule.Owners {
+			err := r.store.RepoFileOwners(&code.RepoFileOwner{
+				RepoId:   r.id,
+				FilePath: path,
+				Owner:    owner,
+				Pattern:  rule.Pattern,
+			})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return errors.Convert(err)
+}
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package parser
+
+import (
+	"fmt"
+	neturl "net/url"
+
+	gogit "github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/config"
+)
+
+// stripUrlCredentials removes the userinfo from `rawUrl` and returns it separately, the credentials
+// must only be passed through the auth method, otherwise git persists them in the config of the repo
+func stripUrlCredentials(rawUrl string) (cleanUrl, user, password string) {
+	u, err := neturl.Parse(rawUrl)
+	if err != nil || u.User == nil {
+		return rawUrl, "", ""
+	}
+	user = u.User.Username()
+	password, _ = u.User.Password()
+	u.User = nil
+	return u.String(), user, password
+}
+
+// restoreRemoteConfig rebuilds the config of a bare repo restored from the cache, the config is never
+// cached since it may hold the remote url with credentials
+func restoreRemoteConfig(dir, url string) error {
+	repo, err := gogit.PlainOpen(dir)
+	if err != nil {
+		return err
+	}
+	cfg := config.NewConfig()
+	cfg.Core.IsBare = true
+	cfg.Remotes[gogit.DefaultRemoteName] = &config.RemoteConfig{
+		Name:  gogit.DefaultRemoteName,
+		URLs:  []string{url},
+		Fetch: []config.RefSpec{config.RefSpec(fmt.Sprintf(config.DefaultFetchRefSpec, gogit.DefaultRemoteName))},
+	}
+	return repo.Storer.SetConfig(cfg)
+}
@@ -65,6 +65,10 @@ func (r *GitRepo) CollectAll(subtaskCtx plugin.SubTaskContext) errors.Error {
 	if err != nil {
 		return err
 	}
+	err = r.CollectFileOwners(subtaskCtx)
+	if err != nil {
+		return err
+	}
 	return r.CollectDiffLine(subtaskCtx)
 }
 
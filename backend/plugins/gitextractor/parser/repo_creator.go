@@ -18,6 +18,8 @@ limitations under the License.
 package parser
 
 import (
+	"context"
+
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
@@ -29,9 +31,16 @@ const (
 	TAG    = "TAG"
 )
 
+// RepoCache keeps the cloned bare repositories between runs, so they only have to be fetched instead of cloned
+type RepoCache interface {
+	Restore(ctx context.Context, repoId string, dir string) (bool, errors.Error)
+	Save(ctx context.Context, repoId string, dir string) errors.Error
+}
+
 type GitRepoCreator struct {
 	store  models.Store
 	logger log.Logger
+	cache  RepoCache
 }
 
 func NewGitRepoCreator(store models.Store, logger log.Logger) *GitRepoCreator {
@@ -41,6 +50,12 @@ func NewGitRepoCreator(store models.Store, logger log.Logger) *GitRepoCreator {
 	}
 }
 
+// WithCache makes the creator restore the repos from the cache before cloning and save them afterward
+func (l *GitRepoCreator) WithCache(cache RepoCache) *GitRepoCreator {
+	l.cache = cache
+	return l
+}
+
 // LocalRepo open a local repository
 func (l *GitRepoCreator) LocalRepo(repoPath, repoId string) (*GitRepo, errors.Error) {
 	repo, err := git.OpenRepository(repoPath)
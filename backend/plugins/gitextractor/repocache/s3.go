@@ -0,0 +1,240 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repocache
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	neturl "net/url"
+	"os"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/config"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/sigv4"
+)
+
+const (
+	S3_ENDPOINT          = "GIT_REPO_CACHE_S3_ENDPOINT"
+	S3_REGION            = "GIT_REPO_CACHE_S3_REGION"
+	S3_BUCKET            = "GIT_REPO_CACHE_S3_BUCKET"
+	S3_PREFIX            = "GIT_REPO_CACHE_S3_PREFIX"
+	S3_ACCESS_KEY_ID     = "GIT_REPO_CACHE_S3_ACCESS_KEY_ID"
+	S3_SECRET_ACCESS_KEY = "GIT_REPO_CACHE_S3_SECRET_ACCESS_KEY"
+)
+
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// maxObjectSize is the limit of a single PUT, larger repos would need a multipart upload and are not cached
+const maxObjectSize = 5 << 30
+
+// S3RepoCache keeps the bare repositories as gzipped tarballs in an S3 compatible object storage, so
+// stateless workers can reuse the clones of previous runs without a shared volume.
+// Objects are addressed path-style (endpoint/bucket/key) which is supported by AWS, MinIO, Ceph and the like
+type S3RepoCache struct {
+	endpoint        *neturl.URL
+	region          string
+	bucket          string
+	prefix          string
+	accessKeyId     string
+	secretAccessKey string
+	sessionToken    string
+	client          *http.Client
+	now             func() time.Time
+}
+
+// NewS3RepoCache creates the cache from the GIT_REPO_CACHE_S3_* settings, it returns nil if no bucket is configured.
+// The credentials fall back to the standard AWS_* variables
+func NewS3RepoCache(cfg config.ConfigReader) (*S3RepoCache, errors.Error) {
+	bucket := cfg.GetString(S3_BUCKET)
+	if bucket == "" {
+		return nil, nil
+	}
+	c := &S3RepoCache{
+		region:          cfg.GetString(S3_REGION),
+		bucket:          bucket,
+		prefix:          strings.Trim(cfg.GetString(S3_PREFIX), "/"),
+		accessKeyId:     cfg.GetString(S3_ACCESS_KEY_ID),
+		secretAccessKey: cfg.GetString(S3_SECRET_ACCESS_KEY),
+		client:          &http.Client{},
+		now:             time.Now,
+	}
+	if c.region == "" {
+		c.region = "us-east-1"
+	}
+	if c.prefix == "" {
+		c.prefix = "gitextractor"
+	}
+	if c.accessKeyId == "" {
+		c.accessKeyId = cfg.GetString("AWS_ACCESS_KEY_ID")
+		c.secretAccessKey = cfg.GetString("AWS_SECRET_ACCESS_KEY")
+		c.sessionToken = cfg.GetString("AWS_SESSION_TOKEN")
+	}
+	if c.accessKeyId == "" || c.secretAccessKey == "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("%s and %s are required for the repo cache", S3_ACCESS_KEY_ID, S3_SECRET_ACCESS_KEY))
+	}
+	endpoint := cfg.GetString(S3_ENDPOINT)
+	if endpoint == "" {
+		endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", c.region)
+	}
+	var err error
+	c.endpoint, err = neturl.Parse(strings.TrimSuffix(endpoint, "/"))
+	if err != nil || c.endpoint.Host == "" {
+		return nil, errors.BadInput.New(fmt.Sprintf("invalid %s: %s", S3_ENDPOINT, endpoint))
+	}
+	return c, nil
+}
+
+// Restore downloads the cached repo into `dir`, it returns false if the repo hasn't been cached yet
+func (c *S3RepoCache) Restore(ctx context.Context, repoId string, dir string) (bool, errors.Error) {
+	req, err := c.newRequest(ctx, http.MethodGet, repoId, nil)
+	if err != nil {
+		return false, err
+	}
+	res, e := c.client.Do(req)
+	if e != nil {
+		return false, errors.Convert(e)
+	}
+	defer res.Body.Close()
+	if res.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if res.StatusCode != http.StatusOK {
+		return false, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to download %s from the repo cache", req.URL.Path))
+	}
+	if err = extractArchive(res.Body, dir); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Save uploads `dir` as the cached repo, it replaces the previous one.
+// The archive is uploaded with a single PUT, so repos whose archive exceeds 5GB are not cached
+func (c *S3RepoCache) Save(ctx context.Context, repoId string, dir string) errors.Error {
+	// S3 requires the length of the object upfront, so the archive is buffered in a temp file
+	f, e := os.CreateTemp("", "gitextractor-cache")
+	if e != nil {
+		return errors.Convert(e)
+	}
+	defer func() {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+	}()
+	if err := archiveDir(dir, f); err != nil {
+		return err
+	}
+	size, e := f.Seek(0, io.SeekCurrent)
+	if e != nil {
+		return errors.Convert(e)
+	}
+	if size > maxObjectSize {
+		return errors.BadInput.New(fmt.Sprintf("the archive of repo %s is %d bytes, larger than the 5GB limit of a single upload", repoId, size))
+	}
+	if _, e = f.Seek(0, io.SeekStart); e != nil {
+		return errors.Convert(e)
+	}
+	req, err := c.newRequest(ctx, http.MethodPut, repoId, f)
+	if err != nil {
+		return err
+	}
+	req.ContentLength = size
+	res, e := c.client.Do(req)
+	if e != nil {
+		return errors.Convert(e)
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to upload %s to the repo cache", req.URL.Path))
+	}
+	return nil
+}
+
+func (c *S3RepoCache) objectPath(repoId string) string {
+	return fmt.Sprintf("%s/%s/%s/%s.tar.gz", c.endpoint.Path, c.bucket, c.prefix, repoId)
+}
+
+func (c *S3RepoCache) newRequest(ctx context.Context, method string, repoId string, body io.Reader) (*http.Request, errors.Error) {
+	u := *c.endpoint
+	u.Path = c.objectPath(repoId)
+	u.RawPath = uriEncode(u.Path)
+	req, e := http.NewRequestWithContext(ctx, method, u.String(), body)
+	if e != nil {
+		return nil, errors.Convert(e)
+	}
+	c.sign(req, c.now().UTC())
+	return req, nil
+}
+
+// sign adds the AWS Signature Version 4 to the request, the payload is left unsigned since the
+// archive is streamed, see https://docs.aws.amazon.com/AmazonS3/latest/API/sig-v4-header-based-auth.html
+func (c *S3RepoCache) sign(req *http.Request, now time.Time) {
+	amzDate := now.Format("20060102T150405Z")
+	date := now.Format("20060102")
+	req.Header.Set("x-amz-date", amzDate)
+	req.Header.Set("x-amz-content-sha256", unsignedPayload)
+	if c.sessionToken != "" {
+		req.Header.Set("x-amz-security-token", c.sessionToken)
+	}
+	headers := map[string]string{"host": req.URL.Host}
+	for name := range req.Header {
+		if lower := strings.ToLower(name); strings.HasPrefix(lower, "x-amz-") {
+			headers[lower] = strings.TrimSpace(req.Header.Get(name))
+		}
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		req.URL.RawQuery,
+		canonicalHeaders.String(),
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+	scope := sigv4.Scope(date, c.region, "s3")
+	signature := sigv4.Signature(c.secretAccessKey, amzDate, date, c.region, "s3", canonicalRequest)
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		c.accessKeyId, scope, signedHeaders, signature,
+	))
+}
+
+// uriEncode escapes everything but the unreserved characters and the slashes as S3 requires
+func uriEncode(path string) string {
+	var sb strings.Builder
+	for _, b := range []byte(path) {
+		if b >= 'A' && b <= 'Z' || b >= 'a' && b <= 'z' || b >= '0' && b <= '9' || strings.IndexByte("-_.~/", b) >= 0 {
+			sb.WriteByte(b)
+		} else {
+			fmt.Fprintf(&sb, "%%%02X", b)
+		}
+	}
+	return sb.String()
+}
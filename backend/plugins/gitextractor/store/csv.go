@@ -86,6 +86,7 @@ type CsvStore struct {
 	commitFileComponentWriter *csvWriter
 	commitLineChangeWriter    *csvWriter
 	snapshotWriter            *csvWriter
+	repoFileOwnerWriter       *csvWriter
 }
 
 func NewCsvStore(dir string) (*CsvStore, errors.Error) {
@@ -129,6 +130,10 @@ func NewCsvStore(dir string) (*CsvStore, errors.Error) {
 	if err != nil {
 		return nil, errors.Convert(err)
 	}
+	s.repoFileOwnerWriter, err = newCsvWriter(filepath.Join(dir, "repo_file_owners.csv"), code.RepoFileOwner{})
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
 	return s, nil
 }
 
@@ -160,6 +165,15 @@ func (c *CsvStore) RepoSnapshot(ss *code.RepoSnapshot) errors.Error {
 	return c.snapshotWriter.Write(ss)
 }
 
+func (c *CsvStore) RepoFileOwners(owner *code.RepoFileOwner) errors.Error {
+	return c.repoFileOwnerWriter.Write(owner)
+}
+
+// DeleteRepoFileOwners is a no-op since the csv files are written from scratch
+func (c *CsvStore) DeleteRepoFileOwners(repoId string) errors.Error {
+	return nil
+}
+
 func (c *CsvStore) CommitParents(pp []*code.CommitParent) errors.Error {
 	var err error
 	for _, p := range pp {
@@ -190,5 +204,8 @@ func (c *CsvStore) Close() errors.Error {
 	if c.snapshotWriter != nil {
 		c.snapshotWriter.Close()
 	}
+	if c.repoFileOwnerWriter != nil {
+		c.repoFileOwnerWriter.Close()
+	}
 	return nil
 }
@@ -19,6 +19,7 @@ package store
 
 import (
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer"
@@ -31,6 +32,7 @@ import (
 const BathSize = 100
 
 type Database struct {
+	db     dal.Dal
 	driver *helper.BatchSaveDivider
 	table  string
 	params string
@@ -38,6 +40,7 @@ type Database struct {
 
 func NewDatabase(basicRes context.BasicRes, repoId string) *Database {
 	database := &Database{
+		db:     basicRes.GetDal(),
 		table:  "gitextractor",
 		params: repoId,
 	}
@@ -124,6 +127,19 @@ func (d *Database) RepoSnapshot(snapshotElement *code.RepoSnapshot) errors.Error
 	return batch.Add(snapshotElement)
 }
 
+func (d *Database) RepoFileOwners(owner *code.RepoFileOwner) errors.Error {
+	batch, err := d.driver.ForType(reflect.TypeOf(owner))
+	if err != nil {
+		return err
+	}
+	d.updateRawDataFields(&owner.RawDataOrigin)
+	return batch.Add(owner)
+}
+
+func (d *Database) DeleteRepoFileOwners(repoId string) errors.Error {
+	return d.db.Delete(&code.RepoFileOwner{}, dal.Where("repo_id = ?", repoId))
+}
+
 func (d *Database) CommitLineChange(commitLineChange *code.CommitLineChange) errors.Error {
 	batch, err := d.driver.ForType(reflect.TypeOf(commitLineChange))
 	if err != nil {
@@ -24,6 +24,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/models"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/parser"
+	"github.com/apache/incubator-devlake/plugins/gitextractor/repocache"
 	"github.com/apache/incubator-devlake/plugins/gitextractor/store"
 	"strings"
 )
@@ -58,6 +59,13 @@ func NewGitRepo(ctx plugin.SubTaskContext, logger log.Logger, storage models.Sto
 	var err errors.Error
 	var repo *parser.GitRepo
 	p := parser.NewGitRepoCreator(storage, logger)
+	cache, err := repocache.NewS3RepoCache(ctx.GetConfigReader())
+	if err != nil {
+		return nil, err
+	}
+	if cache != nil {
+		p.WithCache(cache)
+	}
 	if strings.HasPrefix(op.Url, "http") {
 		repo, err = p.CloneOverHTTP(ctx, op.RepoId, op.Url, op.User, op.Password, op.Proxy)
 	} else if url := strings.TrimPrefix(op.Url, "ssh://"); strings.HasPrefix(url, "git@") {
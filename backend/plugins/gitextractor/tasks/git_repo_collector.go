@@ -103,6 +103,12 @@ func CollectGitDiffLines(subTaskCtx plugin.SubTaskContext) errors.Error {
 	return repo.CollectDiffLine(subTaskCtx)
 }
 
+func CollectGitFileOwners(subTaskCtx plugin.SubTaskContext) errors.Error {
+	repo := getGitRepo(subTaskCtx)
+	subTaskCtx.SetProgress(0, -1)
+	return repo.CollectFileOwners(subTaskCtx)
+}
+
 func getGitRepo(subTaskCtx plugin.SubTaskContext) *parser.GitRepo {
 	taskData, ok := subTaskCtx.GetData().(*GitExtractorTaskData)
 	if !ok {
@@ -149,3 +155,12 @@ var CollectGitDiffLineMeta = plugin.SubTaskMeta{
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
 	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
 }
+
+var CollectGitFileOwnersMeta = plugin.SubTaskMeta{
+	Name:             "collectFileOwners",
+	EntryPoint:       CollectGitFileOwners,
+	EnabledByDefault: true,
+	Description:      "collect file owners from CODEOWNERS into Domain Layer Tables",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&CloneGitRepoMeta},
+}
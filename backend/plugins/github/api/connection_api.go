@@ -20,7 +20,9 @@ package api
 import (
 	"context"
 	"fmt"
+	"io"
 	"net/http"
+	"net/url"
 	"strings"
 
 	"github.com/mitchellh/mapstructure"
@@ -41,6 +43,38 @@ var parentPermissions = map[string]string{
 	"read:org":        "admin:org",
 }
 
+// fineGrainedPermProbes lists the entities collected by the plugin which require an optional
+// repository permission of a fine-grained token, along with the endpoint used to probe it
+var fineGrainedPermProbes = []struct {
+	Entity     string
+	Permission string
+	Path       string
+}{
+	{Entity: "issues", Permission: "Issues", Path: "repos/%s/issues"},
+	{Entity: "actions", Permission: "Actions", Path: "repos/%s/actions/runs"},
+	{Entity: "dependabot", Permission: "Dependabot alerts", Path: "repos/%s/dependabot/alerts"},
+}
+
+// maxFineGrainedProbeRepos caps the number of repos probed for an entity whose feature is disabled on the first repos
+const maxFineGrainedProbeRepos = 5
+
+// GithubTokenPermission tells whether the token is granted the permission required by an entity,
+// Undetermined is set when the feature is disabled on every probed repo so the permission could not be checked
+type GithubTokenPermission struct {
+	Entity       string `json:"entity"`
+	Permission   string `json:"permission"`
+	Granted      bool   `json:"granted"`
+	Undetermined bool   `json:"undetermined,omitempty"`
+}
+
+type fineGrainedProbeResult int
+
+const (
+	probeGranted fineGrainedProbeResult = iota
+	probeDenied
+	probeFeatureDisabled
+)
+
 // findMissingPerms returns the missing required permissions from the given user permissions
 func findMissingPerms(userPerms map[string]bool, requiredPerms []string) []string {
 	missingPerms := make([]string, 0)
@@ -55,9 +89,11 @@ func findMissingPerms(userPerms map[string]bool, requiredPerms []string) []strin
 
 type GithubTestConnResponse struct {
 	shared.ApiBody
-	Login         string                         `json:"login"`
-	Warning       bool                           `json:"warning"`
-	Installations []models.GithubAppInstallation `json:"installations"`
+	Login           string                         `json:"login"`
+	Warning         bool                           `json:"warning"`
+	Installations   []models.GithubAppInstallation `json:"installations"`
+	AccessibleRepos []string                       `json:"accessibleRepos,omitempty"`
+	Permissions     []GithubTokenPermission        `json:"permissions,omitempty"`
 }
 
 // TestConnection test github connection
@@ -165,6 +201,8 @@ func testConnection(ctx context.Context, conn models.GithubConn) (*GithubTestCon
 			githubApiResponse.Warning = tokenTestResult.Warning
 			githubApiResponse.Message = tokenTestResult.Message
 			githubApiResponse.Login = tokenTestResult.Login
+			githubApiResponse.AccessibleRepos = tokenTestResult.AccessibleRepos
+			githubApiResponse.Permissions = tokenTestResult.Permissions
 		}
 	} else {
 		return nil, errors.BadInput.New("invalid authentication method")
@@ -188,6 +226,10 @@ type GitHubTestConnResult struct {
 	Login         string                         `json:"login"`
 	Warning       bool                           `json:"warning"`
 	Installations []models.GithubAppInstallation `json:"installations,omitempty"`
+
+	// Fine-grained AccessToken
+	AccessibleRepos []string                `json:"accessibleRepos,omitempty"`
+	Permissions     []GithubTokenPermission `json:"permissions,omitempty"`
 }
 
 type GithubMultiTestConnResponse struct {
@@ -221,6 +263,8 @@ func testGithubConnAccessTokenAuth(ctx context.Context, conn models.GithubConn)
 	success := false
 	warning := false
 	var messages []string
+	var accessibleRepos []string
+	var permissions []GithubTokenPermission
 	// for github classic token, check permission
 	if strings.HasPrefix(conn.Token, "ghp_") {
 		scopes := res.Header.Get("X-OAuth-Scopes")
@@ -253,6 +297,27 @@ func testGithubConnAccessTokenAuth(ctx context.Context, conn models.GithubConn)
 			))
 		}
 	}
+	// for github fine-grained token, there is no scope header, probe the accessible repos instead
+	if strings.HasPrefix(conn.Token, "github_pat_") {
+		accessibleRepos, err = listAccessibleRepos(apiClient)
+		if err != nil {
+			return nil, err
+		}
+		success = true
+		if len(accessibleRepos) == 0 {
+			warning = true
+			messages = append(messages, "This token has no access to any repository, please check the field Repository access")
+		} else {
+			permissions, err = probeFineGrainedPerms(apiClient, accessibleRepos)
+			if err != nil {
+				return nil, err
+			}
+			if msg := fineGrainedPermsMessage(permissions); msg != "" {
+				warning = true
+				messages = append(messages, msg)
+			}
+		}
+	}
 
 	sanitizeConn := conn.Sanitize()
 	tokenTestResult := GitHubTestConnResult{
@@ -262,10 +327,105 @@ func testGithubConnAccessTokenAuth(ctx context.Context, conn models.GithubConn)
 		Message:    strings.Join(messages, ";\n"),
 		Login:      githubUserOfToken.Login,
 		Warning:    warning,
+
+		AccessibleRepos: accessibleRepos,
+		Permissions:     permissions,
 	}
 	return &tokenTestResult, nil
 }
 
+// listAccessibleRepos returns the full names of the repositories a fine-grained token has access to (first page only)
+func listAccessibleRepos(apiClient *api.ApiClient) ([]string, errors.Error) {
+	res, err := apiClient.Get("user/repos", url.Values{"per_page": []string{"100"}}, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "list accessible repositories failed")
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, errors.HttpStatus(res.StatusCode).New("unexpected status code while listing accessible repositories")
+	}
+	var repos []struct {
+		FullName string `json:"full_name"`
+	}
+	err = api.UnmarshalResponse(res, &repos)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "list accessible repositories failed")
+	}
+	fullNames := make([]string, 0, len(repos))
+	for _, repo := range repos {
+		fullNames = append(fullNames, repo.FullName)
+	}
+	return fullNames, nil
+}
+
+// probeFineGrainedPerms requests the endpoint of each entity against the given repos to find out whether
+// the fine-grained token is granted the corresponding permission, repos where the feature is disabled are skipped
+func probeFineGrainedPerms(apiClient *api.ApiClient, repoFullNames []string) ([]GithubTokenPermission, errors.Error) {
+	if len(repoFullNames) > maxFineGrainedProbeRepos {
+		repoFullNames = repoFullNames[:maxFineGrainedProbeRepos]
+	}
+	permissions := make([]GithubTokenPermission, 0, len(fineGrainedPermProbes))
+	for _, probe := range fineGrainedPermProbes {
+		permission := GithubTokenPermission{
+			Entity:       probe.Entity,
+			Permission:   probe.Permission,
+			Undetermined: true,
+		}
+		for _, repoFullName := range repoFullNames {
+			res, err := apiClient.Get(fmt.Sprintf(probe.Path, repoFullName), url.Values{"per_page": []string{"1"}}, nil)
+			if err != nil {
+				return nil, errors.BadInput.Wrap(err, fmt.Sprintf("probe %s permission failed", probe.Entity))
+			}
+			result := classifyFineGrainedProbe(res)
+			if result == probeFeatureDisabled {
+				continue
+			}
+			permission.Granted = result == probeGranted
+			permission.Undetermined = false
+			break
+		}
+		permissions = append(permissions, permission)
+	}
+	return permissions, nil
+}
+
+// classifyFineGrainedProbe tells a missing permission apart from a feature disabled on the repo: github responds
+// 410 when issues are disabled and 403 "... disabled for this repository" for dependabot alerts, while a missing
+// permission of a fine-grained token ends up as 403 "Resource not accessible by personal access token" or 404
+func classifyFineGrainedProbe(res *http.Response) fineGrainedProbeResult {
+	defer res.Body.Close()
+	switch res.StatusCode {
+	case http.StatusOK:
+		return probeGranted
+	case http.StatusGone:
+		return probeFeatureDisabled
+	case http.StatusForbidden:
+		body, _ := io.ReadAll(res.Body)
+		if strings.Contains(strings.ToLower(string(body)), "disabled for this repository") {
+			return probeFeatureDisabled
+		}
+	}
+	return probeDenied
+}
+
+// fineGrainedPermsMessage returns the warning for the entities which would fail to be collected
+func fineGrainedPermsMessage(permissions []GithubTokenPermission) string {
+	var entities, perms []string
+	for _, p := range permissions {
+		if !p.Granted && !p.Undetermined {
+			entities = append(entities, p.Entity)
+			perms = append(perms, p.Permission)
+		}
+	}
+	if len(entities) == 0 {
+		return ""
+	}
+	return fmt.Sprintf(
+		"This token is not able to collect %s, please grant the read-only repository permission(s) %s",
+		strings.Join(entities, ", "),
+		strings.Join(perms, ", "),
+	)
+}
+
 func getInstallationsWithGithubConnAppKeyAuth(ctx context.Context, conn models.GithubConn) (*GitHubTestConnResult, error) {
 	apiClient, err := api.NewApiClientFromConnection(ctx, basicRes, &conn)
 	if err != nil {
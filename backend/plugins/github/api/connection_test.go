@@ -18,6 +18,9 @@ limitations under the License.
 package api
 
 import (
+	"io"
+	"net/http"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -48,3 +51,44 @@ func TestFindMissingPerms(t *testing.T) {
 	missingPerms = findMissingPerms(userPerms, requiredPerms)
 	assert.Equal(t, []string{"repo:status", "read:user"}, missingPerms)
 }
+
+func TestFineGrainedPermsMessage(t *testing.T) {
+	// all permissions are granted
+	permissions := []GithubTokenPermission{
+		{Entity: "issues", Permission: "Issues", Granted: true},
+		{Entity: "actions", Permission: "Actions", Granted: true},
+	}
+	assert.Empty(t, fineGrainedPermsMessage(permissions))
+
+	// permissions are missing
+	permissions = []GithubTokenPermission{
+		{Entity: "issues", Permission: "Issues", Granted: true},
+		{Entity: "actions", Permission: "Actions", Granted: false},
+		{Entity: "dependabot", Permission: "Dependabot alerts", Granted: false},
+	}
+	assert.Equal(t,
+		"This token is not able to collect actions, dependabot, please grant the read-only repository permission(s) Actions, Dependabot alerts",
+		fineGrainedPermsMessage(permissions),
+	)
+
+	// permissions which could not be checked are not reported as missing
+	permissions = []GithubTokenPermission{
+		{Entity: "issues", Permission: "Issues", Undetermined: true},
+		{Entity: "actions", Permission: "Actions", Granted: true},
+	}
+	assert.Empty(t, fineGrainedPermsMessage(permissions))
+}
+
+func TestClassifyFineGrainedProbe(t *testing.T) {
+	newResponse := func(status int, body string) *http.Response {
+		return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+	}
+	assert.Equal(t, probeGranted, classifyFineGrainedProbe(newResponse(http.StatusOK, `[]`)))
+	// issues are disabled
+	assert.Equal(t, probeFeatureDisabled, classifyFineGrainedProbe(newResponse(http.StatusGone, `{"message":"Issues are disabled for this repo"}`)))
+	// dependabot alerts are disabled
+	assert.Equal(t, probeFeatureDisabled, classifyFineGrainedProbe(newResponse(http.StatusForbidden, `{"message":"Dependabot alerts are disabled for this repository."}`)))
+	// permission is missing
+	assert.Equal(t, probeDenied, classifyFineGrainedProbe(newResponse(http.StatusForbidden, `{"message":"Resource not accessible by personal access token"}`)))
+	assert.Equal(t, probeDenied, classifyFineGrainedProbe(newResponse(http.StatusNotFound, `{"message":"Not Found"}`)))
+}
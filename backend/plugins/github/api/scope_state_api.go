@@ -35,3 +35,34 @@ import (
 func GetScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	return dsHelper.ScopeApi.GetScopeLatestSyncState(input)
 }
+
+// PatchScopeLatestSyncState override one GitHub repo's latest sync state
+// @Summary override one GitHub repo's latest sync state
+// @Description set the latest success start of the collector of raw_data_table, or of all collectors if omitted,
+// @Description the next run collects the data updated after it, or everything if it is null
+// @Tags plugins/github
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path int true "scope ID"
+// @Param body body models.LatestSyncState true "json"
+// @Success 200  {object} []models.LatestSyncState
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/github/connections/{connectionId}/scopes/{scopeId}/latest-sync-state [PATCH]
+func PatchScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeApi.PatchScopeLatestSyncState(input)
+}
+
+// DeleteScopeLatestSyncState clear one GitHub repo's latest sync state
+// @Summary clear one GitHub repo's latest sync state
+// @Description clear the state of the collector of rawDataTable, or of all collectors if omitted, so the next run collects everything
+// @Tags plugins/github
+// @Param connectionId path int true "connection ID"
+// @Param scopeId path int true "scope ID"
+// @Param rawDataTable query string false "raw table of the collector"
+// @Success 200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/github/connections/{connectionId}/scopes/{scopeId}/latest-sync-state [DELETE]
+func DeleteScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return dsHelper.ScopeApi.DeleteScopeLatestSyncState(input)
+}
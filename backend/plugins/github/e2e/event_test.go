@@ -20,6 +20,8 @@ package e2e
 import (
 	"testing"
 
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
 	"github.com/apache/incubator-devlake/plugins/github/models"
 
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
@@ -54,6 +56,7 @@ func TestEventDataFlow(t *testing.T) {
 			"github_id",
 			"issue_id",
 			"type",
+			"author_id",
 			"author_username",
 			"github_created_at",
 			"_raw_data_params",
@@ -76,4 +79,13 @@ func TestEventDataFlow(t *testing.T) {
 			"_raw_data_remark",
 		},
 	)
+
+	// verify conversion of the closed/reopened events
+	dataflowTester.ImportCsvIntoTabler("./raw_tables/_tool_github_issues_in_event.csv", &models.GithubIssue{})
+	dataflowTester.FlushTabler(&ticket.IssueChangelogs{})
+	dataflowTester.Subtask(tasks.ConvertIssueEventsMeta, taskData)
+	dataflowTester.VerifyTableWithOptions(ticket.IssueChangelogs{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/issue_changelogs_in_event.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
@@ -104,6 +104,12 @@ func (p Github) GetTablesInfo() []dal.Tabler {
 		&models.GithubIssueAssignee{},
 		&models.GithubScopeConfig{},
 		&models.GithubDeployment{},
+		&models.GithubBranch{},
+		&models.GithubBranchComparison{},
+		&models.GithubBranchProtection{},
+		&models.GithubDependabotAlert{},
+		&models.GithubCodeScanningAlert{},
+		&models.GithubSecretScanningAlert{},
 	}
 }
 
@@ -196,7 +202,9 @@ func (p Github) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
-			"GET": api.GetScopeLatestSyncState,
+			"GET":    api.GetScopeLatestSyncState,
+			"PATCH":  api.PatchScopeLatestSyncState,
+			"DELETE": api.DeleteScopeLatestSyncState,
 		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopes,
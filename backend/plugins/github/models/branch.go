@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type GithubBranch struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey;autoIncrement:false"`
+	Name         string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(40)"`
+	Protected    bool
+	common.NoPKModel
+}
+
+func (GithubBranch) TableName() string {
+	return "_tool_github_branches"
+}
+
+// GithubBranchComparison is the result of comparing a branch against the default branch of the repo
+type GithubBranchComparison struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	RepoId          int    `gorm:"primaryKey;autoIncrement:false"`
+	BranchName      string `gorm:"primaryKey;type:varchar(255)"`
+	BaseBranch      string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	AheadBy         int
+	BehindBy        int
+	MergeBaseSha    string `gorm:"type:varchar(40)"`
+	MergeBaseDate   *time.Time
+	FirstCommitDate *time.Time
+	LastCommitDate  *time.Time
+	common.NoPKModel
+}
+
+func (GithubBranchComparison) TableName() string {
+	return "_tool_github_branch_comparisons"
+}
+
+// GithubBranchProtection is the protection rule of the default branch of the repo
+type GithubBranchProtection struct {
+	ConnectionId                 uint64 `gorm:"primaryKey"`
+	RepoId                       int    `gorm:"primaryKey;autoIncrement:false"`
+	BranchName                   string `gorm:"primaryKey;type:varchar(255)"`
+	RequirePullRequestReviews    bool
+	RequiredApprovingReviewCount int
+	RequireCodeOwnerReviews      bool
+	DismissStaleReviews          bool
+	RequireStatusChecks          bool
+	StrictStatusChecks           bool
+	RequiredStatusChecks         string
+	EnforceAdmins                bool
+	RequiredSignatures           bool
+	RequiredLinearHistory        bool
+	AllowForcePushes             bool
+	AllowDeletions               bool
+	common.NoPKModel
+}
+
+func (GithubBranchProtection) TableName() string {
+	return "_tool_github_branch_protections"
+}
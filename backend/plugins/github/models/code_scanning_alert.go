@@ -0,0 +1,55 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GithubCodeScanningAlert is an alert raised by a code scanning tool (e.g. CodeQL), the location
+// comes from the most recent instance of the alert
+type GithubCodeScanningAlert struct {
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	RepoId                int    `gorm:"primaryKey;autoIncrement:false"`
+	Number                int    `gorm:"primaryKey;autoIncrement:false"`
+	State                 string `gorm:"type:varchar(100)"`
+	RuleId                string `gorm:"type:varchar(255)"`
+	RuleDescription       string
+	RuleSeverity          string `gorm:"type:varchar(100)"`
+	SecuritySeverityLevel string `gorm:"type:varchar(100)"`
+	ToolName              string `gorm:"type:varchar(100)"`
+	Ref                   string `gorm:"type:varchar(255)"`
+	FilePath              string
+	StartLine             int
+	EndLine               int
+	HtmlUrl               string
+	DismissedBy           string `gorm:"type:varchar(255)"`
+	DismissedReason       string `gorm:"type:varchar(100)"`
+	DismissedComment      string
+	GithubCreatedAt       time.Time
+	GithubUpdatedAt       *time.Time
+	FixedAt               *time.Time
+	DismissedAt           *time.Time
+	common.NoPKModel
+}
+
+func (GithubCodeScanningAlert) TableName() string {
+	return "_tool_github_code_scanning_alerts"
+}
@@ -23,10 +23,11 @@ import (
 )
 
 type GithubIssueEvent struct {
-	ConnectionId    uint64    `gorm:"primaryKey"`
-	GithubId        int       `gorm:"primaryKey"`
-	IssueId         int       `gorm:"index;comment:References the Issue"`
-	Type            string    `gorm:"type:varchar(255);comment:Events that can occur to an issue, ex. assigned, closed, labeled, etc."`
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	GithubId        int    `gorm:"primaryKey"`
+	IssueId         int    `gorm:"index;comment:References the Issue"`
+	Type            string `gorm:"type:varchar(255);comment:Events that can occur to an issue, ex. assigned, closed, labeled, etc."`
+	AuthorId        int
 	AuthorUsername  string    `gorm:"type:varchar(255)"`
 	GithubCreatedAt time.Time `gorm:"index"`
 	common.NoPKModel
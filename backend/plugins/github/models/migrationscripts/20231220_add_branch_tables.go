@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBranchTables)(nil)
+
+type githubBranch20231220 struct {
+	ConnectionId uint64 `gorm:"primaryKey"`
+	RepoId       int    `gorm:"primaryKey;autoIncrement:false"`
+	Name         string `gorm:"primaryKey;type:varchar(255)"`
+	CommitSha    string `gorm:"type:varchar(40)"`
+	Protected    bool
+	archived.NoPKModel
+}
+
+func (githubBranch20231220) TableName() string {
+	return "_tool_github_branches"
+}
+
+type githubBranchComparison20231220 struct {
+	ConnectionId    uint64 `gorm:"primaryKey"`
+	RepoId          int    `gorm:"primaryKey;autoIncrement:false"`
+	BranchName      string `gorm:"primaryKey;type:varchar(255)"`
+	BaseBranch      string `gorm:"type:varchar(255)"`
+	Status          string `gorm:"type:varchar(100)"`
+	AheadBy         int
+	BehindBy        int
+	MergeBaseSha    string `gorm:"type:varchar(40)"`
+	MergeBaseDate   *time.Time
+	FirstCommitDate *time.Time
+	LastCommitDate  *time.Time
+	archived.NoPKModel
+}
+
+func (githubBranchComparison20231220) TableName() string {
+	return "_tool_github_branch_comparisons"
+}
+
+type scopeConfig20231220 struct {
+	StaleBranchDays int `mapstructure:"staleBranchDays,omitempty" json:"staleBranchDays"`
+}
+
+func (scopeConfig20231220) TableName() string {
+	return "_tool_github_scope_configs"
+}
+
+type addBranchTables struct{}
+
+func (*addBranchTables) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubBranch20231220{},
+		&githubBranchComparison20231220{},
+		&scopeConfig20231220{},
+	)
+}
+
+func (*addBranchTables) Version() uint64 {
+	return 20231220000001
+}
+
+func (*addBranchTables) Name() string {
+	return "add branch tables and stale_branch_days to _tool_github_scope_configs"
+}
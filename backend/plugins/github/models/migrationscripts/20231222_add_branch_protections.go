@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBranchProtections)(nil)
+
+type githubBranchProtection20231222 struct {
+	ConnectionId                 uint64 `gorm:"primaryKey"`
+	RepoId                       int    `gorm:"primaryKey;autoIncrement:false"`
+	BranchName                   string `gorm:"primaryKey;type:varchar(255)"`
+	RequirePullRequestReviews    bool
+	RequiredApprovingReviewCount int
+	RequireCodeOwnerReviews      bool
+	DismissStaleReviews          bool
+	RequireStatusChecks          bool
+	StrictStatusChecks           bool
+	RequiredStatusChecks         string
+	EnforceAdmins                bool
+	RequiredSignatures           bool
+	RequiredLinearHistory        bool
+	AllowForcePushes             bool
+	AllowDeletions               bool
+	archived.NoPKModel
+}
+
+func (githubBranchProtection20231222) TableName() string {
+	return "_tool_github_branch_protections"
+}
+
+type addBranchProtections struct{}
+
+func (*addBranchProtections) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubBranchProtection20231222{},
+	)
+}
+
+func (*addBranchProtections) Version() uint64 {
+	return 20231222000001
+}
+
+func (*addBranchProtections) Name() string {
+	return "add _tool_github_branch_protections"
+}
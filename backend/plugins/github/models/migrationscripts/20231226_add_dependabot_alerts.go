@@ -0,0 +1,74 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addDependabotAlerts)(nil)
+
+type githubDependabotAlert20231226 struct {
+	ConnectionId     uint64 `gorm:"primaryKey"`
+	RepoId           int    `gorm:"primaryKey;autoIncrement:false"`
+	Number           int    `gorm:"primaryKey;autoIncrement:false"`
+	State            string `gorm:"type:varchar(100)"`
+	PackageName      string `gorm:"type:varchar(255)"`
+	PackageEcosystem string `gorm:"type:varchar(100)"`
+	ManifestPath     string
+	DependencyScope  string `gorm:"type:varchar(100)"`
+	GhsaId           string `gorm:"type:varchar(100)"`
+	CveId            string `gorm:"type:varchar(100)"`
+	Summary          string
+	Severity         string `gorm:"type:varchar(100)"`
+	HtmlUrl          string
+	DismissedReason  string `gorm:"type:varchar(100)"`
+	GithubCreatedAt  time.Time
+	GithubUpdatedAt  time.Time
+	FixedAt          *time.Time
+	DismissedAt      *time.Time
+	AutoDismissedAt  *time.Time
+	archived.NoPKModel
+}
+
+func (githubDependabotAlert20231226) TableName() string {
+	return "_tool_github_dependabot_alerts"
+}
+
+type addDependabotAlerts struct{}
+
+func (*addDependabotAlerts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubDependabotAlert20231226{},
+	)
+}
+
+func (*addDependabotAlerts) Version() uint64 {
+	return 20231226000001
+}
+
+func (*addDependabotAlerts) Name() string {
+	return "add _tool_github_dependabot_alerts"
+}
@@ -0,0 +1,100 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addScanningAlerts)(nil)
+
+type githubCodeScanningAlert20231227 struct {
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	RepoId                int    `gorm:"primaryKey;autoIncrement:false"`
+	Number                int    `gorm:"primaryKey;autoIncrement:false"`
+	State                 string `gorm:"type:varchar(100)"`
+	RuleId                string `gorm:"type:varchar(255)"`
+	RuleDescription       string
+	RuleSeverity          string `gorm:"type:varchar(100)"`
+	SecuritySeverityLevel string `gorm:"type:varchar(100)"`
+	ToolName              string `gorm:"type:varchar(100)"`
+	Ref                   string `gorm:"type:varchar(255)"`
+	FilePath              string
+	StartLine             int
+	EndLine               int
+	HtmlUrl               string
+	DismissedBy           string `gorm:"type:varchar(255)"`
+	DismissedReason       string `gorm:"type:varchar(100)"`
+	DismissedComment      string
+	GithubCreatedAt       time.Time
+	GithubUpdatedAt       *time.Time
+	FixedAt               *time.Time
+	DismissedAt           *time.Time
+	archived.NoPKModel
+}
+
+func (githubCodeScanningAlert20231227) TableName() string {
+	return "_tool_github_code_scanning_alerts"
+}
+
+type githubSecretScanningAlert20231227 struct {
+	ConnectionId             uint64 `gorm:"primaryKey"`
+	RepoId                   int    `gorm:"primaryKey;autoIncrement:false"`
+	Number                   int    `gorm:"primaryKey;autoIncrement:false"`
+	State                    string `gorm:"type:varchar(100)"`
+	Resolution               string `gorm:"type:varchar(100)"`
+	ResolutionComment        string
+	ResolvedBy               string `gorm:"type:varchar(255)"`
+	SecretType               string `gorm:"type:varchar(255)"`
+	SecretTypeDisplayName    string `gorm:"type:varchar(255)"`
+	PushProtectionBypassed   bool
+	PushProtectionBypassedAt *time.Time
+	HtmlUrl                  string
+	GithubCreatedAt          time.Time
+	GithubUpdatedAt          *time.Time
+	ResolvedAt               *time.Time
+	archived.NoPKModel
+}
+
+func (githubSecretScanningAlert20231227) TableName() string {
+	return "_tool_github_secret_scanning_alerts"
+}
+
+type addScanningAlerts struct{}
+
+func (*addScanningAlerts) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&githubCodeScanningAlert20231227{},
+		&githubSecretScanningAlert20231227{},
+	)
+}
+
+func (*addScanningAlerts) Version() uint64 {
+	return 20231227000001
+}
+
+func (*addScanningAlerts) Name() string {
+	return "add _tool_github_code_scanning_alerts and _tool_github_secret_scanning_alerts"
+}
@@ -47,5 +47,10 @@ func All() []plugin.MigrationScript {
 		new(modifyGithubMilestone),
 		new(addEnvNamePattern),
 		new(modifyIssueTypeLength),
+		new(addBranchTables),
+		new(addBranchProtections),
+		new(addDependabotAlerts),
+		new(addScanningAlerts),
+		new(addAuthorIdToIssueEvents),
 	}
 }
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// GithubSecretScanningAlert is an alert raised for a secret committed to a repo, the secret itself is never stored
+type GithubSecretScanningAlert struct {
+	ConnectionId             uint64 `gorm:"primaryKey"`
+	RepoId                   int    `gorm:"primaryKey;autoIncrement:false"`
+	Number                   int    `gorm:"primaryKey;autoIncrement:false"`
+	State                    string `gorm:"type:varchar(100)"`
+	Resolution               string `gorm:"type:varchar(100)"`
+	ResolutionComment        string
+	ResolvedBy               string `gorm:"type:varchar(255)"`
+	SecretType               string `gorm:"type:varchar(255)"`
+	SecretTypeDisplayName    string `gorm:"type:varchar(255)"`
+	PushProtectionBypassed   bool
+	PushProtectionBypassedAt *time.Time
+	HtmlUrl                  string
+	GithubCreatedAt          time.Time
+	GithubUpdatedAt          *time.Time
+	ResolvedAt               *time.Time
+	common.NoPKModel
+}
+
+func (GithubSecretScanningAlert) TableName() string {
+	return "_tool_github_secret_scanning_alerts"
+}
@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/url"
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiBranchComparisonsMeta)
+}
+
+const RAW_BRANCH_COMPARISON_TABLE = "github_api_branch_comparisons"
+
+var CollectApiBranchComparisonsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBranchComparisons",
+	EntryPoint:       CollectApiBranchComparisons,
+	EnabledByDefault: false,
+	Description:      "Collect the comparison between every branch and the default branch from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{models.GithubBranch{}.TableName()},
+	ProductTables:    []string{RAW_BRANCH_COMPARISON_TABLE},
+}
+
+// BranchComparisonInput is the input of the comparison collector, Base is the default branch of the repo
+type BranchComparisonInput struct {
+	Name string
+	Base string
+}
+
+// EscapedName is the path escaped Name for the UrlTemplate
+func (input BranchComparisonInput) EscapedName() string {
+	return escapeBranchName(input.Name)
+}
+
+// EscapedBase is the path escaped Base for the UrlTemplate
+func (input BranchComparisonInput) EscapedBase() string {
+	return escapeBranchName(input.Base)
+}
+
+// escapeBranchName escapes the characters like `#`, `?` or `%` of a branch name to be used in a url path,
+// the slashes separating the segments of names like `feature/x` are kept as github expects them
+func escapeBranchName(name string) string {
+	segments := strings.Split(name, "/")
+	for i, segment := range segments {
+		segments[i] = url.PathEscape(segment)
+	}
+	return strings.Join(segments, "/")
+}
+
+func CollectApiBranchComparisons(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_COMPARISON_TABLE)
+	db := taskCtx.GetDal()
+
+	defaultBranch, err := fetchDefaultBranch(data)
+	if err != nil {
+		return err
+	}
+	if defaultBranch == "" {
+		taskCtx.GetLogger().Info("repo %s has no default branch, skip comparing branches", data.Options.Name)
+		return nil
+	}
+
+	cursor, err := db.Cursor(
+		dal.Select("name, ? AS base", defaultBranch),
+		dal.From(models.GithubBranch{}.TableName()),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	iterator, err := api.NewDalCursorIterator(db, cursor, reflect.TypeOf(BranchComparisonInput{}))
+	if err != nil {
+		return err
+	}
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Incremental:        false,
+		Input:              iterator,
+		// the comparison between the default branch and itself is kept as well, its merge base
+		// is the head of the default branch, which gives us the last commit date of it
+		UrlTemplate:    "repos/{{ .Params.Name }}/compare/{{ .Input.EscapedBase }}...{{ .Input.EscapedName }}",
+		ResponseParser: api.GetRawMessageDirectFromResponse,
+		AfterResponse:  ignoreHTTPStatus404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,35 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEscapeBranchName(t *testing.T) {
+	assert.Equal(t, "feature/foo", escapeBranchName("feature/foo"))
+	assert.Equal(t, "fix%23123/50%25%3F", escapeBranchName("fix#123/50%?"))
+
+	input := BranchComparisonInput{Name: "fix#123", Base: "main"}
+	uri, err := api.GetURIStringPointer("https://api.github.com/", "repos/a/b/compare/"+input.EscapedBase()+"..."+input.EscapedName(), nil)
+	assert.Nil(t, err)
+	assert.Equal(t, "https://api.github.com/repos/a/b/compare/main...fix%23123", *uri)
+}
@@ -0,0 +1,119 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiBranchComparisonsMeta)
+}
+
+var ExtractApiBranchComparisonsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranchComparisons",
+	EntryPoint:       ExtractApiBranchComparisons,
+	EnabledByDefault: false,
+	Description:      "Extract raw branch comparison data into tool layer table github_branch_comparisons",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{RAW_BRANCH_COMPARISON_TABLE},
+	ProductTables:    []string{models.GithubBranchComparison{}.TableName()},
+}
+
+type githubApiComparisonCommit struct {
+	Sha    string `json:"sha"`
+	Commit struct {
+		Committer struct {
+			Date common.Iso8601Time `json:"date"`
+		} `json:"committer"`
+	} `json:"commit"`
+}
+
+type GithubApiBranchComparison struct {
+	Status          string                      `json:"status"`
+	AheadBy         int                         `json:"ahead_by"`
+	BehindBy        int                         `json:"behind_by"`
+	MergeBaseCommit githubApiComparisonCommit   `json:"merge_base_commit"`
+	Commits         []githubApiComparisonCommit `json:"commits"`
+}
+
+func ExtractApiBranchComparisons(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_COMPARISON_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &BranchComparisonInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			apiComparison := &GithubApiBranchComparison{}
+			err = errors.Convert(json.Unmarshal(row.Data, apiComparison))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{convertGithubBranchComparison(apiComparison, input, data.Options.ConnectionId, data.Options.GithubId)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func convertGithubBranchComparison(
+	apiComparison *GithubApiBranchComparison,
+	input *BranchComparisonInput,
+	connectionId uint64,
+	repoId int,
+) *models.GithubBranchComparison {
+	comparison := &models.GithubBranchComparison{
+		ConnectionId: connectionId,
+		RepoId:       repoId,
+		BranchName:   input.Name,
+		BaseBranch:   input.Base,
+		Status:       apiComparison.Status,
+		AheadBy:      apiComparison.AheadBy,
+		BehindBy:     apiComparison.BehindBy,
+		MergeBaseSha: apiComparison.MergeBaseCommit.Sha,
+	}
+	if apiComparison.MergeBaseCommit.Sha != "" {
+		comparison.MergeBaseDate = timePtr(apiComparison.MergeBaseCommit.Commit.Committer.Date.ToTime())
+	}
+	// commits are listed in chronological order, note that Github returns at most 250 commits,
+	// the first commit date is approximated for branches going further ahead of the base
+	if len(apiComparison.Commits) > 0 {
+		comparison.FirstCommitDate = timePtr(apiComparison.Commits[0].Commit.Committer.Date.ToTime())
+		comparison.LastCommitDate = timePtr(apiComparison.Commits[len(apiComparison.Commits)-1].Commit.Committer.Date.ToTime())
+	} else {
+		// the branch has no commit of its own, its head is the merge base
+		comparison.LastCommitDate = comparison.MergeBaseDate
+	}
+	return comparison
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,124 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertBranchesMeta)
+}
+
+// DefaultStaleBranchDays is used when `staleBranchDays` is not set in the scope config
+const DefaultStaleBranchDays = 90
+
+var ConvertBranchesMeta = plugin.SubTaskMeta{
+	Name:             "convertBranches",
+	EntryPoint:       ConvertBranches,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table github_branches and github_branch_comparisons into domain layer table branch_lifetimes",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{
+		models.GithubBranch{}.TableName(),           // cursor
+		models.GithubBranchComparison{}.TableName(), // cursor
+		RAW_BRANCH_COMPARISON_TABLE},
+	ProductTables: []string{code.BranchLifetime{}.TableName()},
+}
+
+type BranchConverterModel struct {
+	models.GithubBranchComparison
+	CommitSha string
+	Protected bool
+}
+
+func ConvertBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_COMPARISON_TABLE)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.Select("gbc.*, gb.commit_sha, gb.protected"),
+		dal.From("_tool_github_branch_comparisons gbc"),
+		dal.Join(`JOIN _tool_github_branches gb ON gb.connection_id = gbc.connection_id
+			AND gb.repo_id = gbc.repo_id AND gb.name = gbc.branch_name`),
+		dal.Where("gbc.repo_id = ? AND gbc.connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	staleBranchDays := DefaultStaleBranchDays
+	if data.Options.ScopeConfig != nil && data.Options.ScopeConfig.StaleBranchDays > 0 {
+		staleBranchDays = data.Options.ScopeConfig.StaleBranchDays
+	}
+	staleBefore := time.Now().AddDate(0, 0, -staleBranchDays)
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(BranchConverterModel{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			branch := inputRow.(*BranchConverterModel)
+			return []interface{}{convertBranchLifetime(branch, repoId, staleBefore)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func convertBranchLifetime(branch *BranchConverterModel, repoId string, staleBefore time.Time) *code.BranchLifetime {
+	lifetime := &code.BranchLifetime{
+		// same format as the refs generated by gitextractor so the two tables can be joined
+		DomainEntity:    domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", repoId, branch.BranchName)},
+		RepoId:          repoId,
+		Name:            branch.BranchName,
+		BaseBranch:      branch.BaseBranch,
+		CommitSha:       branch.CommitSha,
+		IsDefault:       branch.BranchName == branch.BaseBranch,
+		IsProtected:     branch.Protected,
+		AheadBy:         branch.AheadBy,
+		BehindBy:        branch.BehindBy,
+		FirstCommitDate: branch.FirstCommitDate,
+		LastCommitDate:  branch.LastCommitDate,
+	}
+	if lifetime.IsDefault {
+		return lifetime
+	}
+	// a branch lives from the first commit it diverged from the default branch till its latest commit
+	if branch.FirstCommitDate != nil && branch.LastCommitDate != nil {
+		minutes := int(branch.LastCommitDate.Sub(*branch.FirstCommitDate).Minutes())
+		lifetime.LifetimeMinutes = &minutes
+	}
+	lifetime.IsStale = branch.LastCommitDate != nil && branch.LastCommitDate.Before(staleBefore)
+	return lifetime
+}
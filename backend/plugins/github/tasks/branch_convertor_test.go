@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/plugins/github/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertBranchLifetime(t *testing.T) {
+	first := time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2023, 10, 2, 12, 0, 0, 0, time.UTC)
+	staleBefore := time.Date(2023, 11, 1, 0, 0, 0, 0, time.UTC)
+	branch := &BranchConverterModel{
+		GithubBranchComparison: models.GithubBranchComparison{
+			BranchName:      "feature/foo",
+			BaseBranch:      "main",
+			AheadBy:         3,
+			FirstCommitDate: &first,
+			LastCommitDate:  &last,
+		},
+		CommitSha: "abc",
+	}
+
+	lifetime := convertBranchLifetime(branch, "github:GithubRepo:1:123", staleBefore)
+	assert.Equal(t, "github:GithubRepo:1:123:feature/foo", lifetime.Id)
+	assert.False(t, lifetime.IsDefault)
+	assert.Equal(t, 36*60, *lifetime.LifetimeMinutes)
+	assert.True(t, lifetime.IsStale)
+
+	lifetime = convertBranchLifetime(branch, "github:GithubRepo:1:123", first)
+	assert.False(t, lifetime.IsStale)
+
+	branch.BranchName = "main"
+	lifetime = convertBranchLifetime(branch, "github:GithubRepo:1:123", staleBefore)
+	assert.True(t, lifetime.IsDefault)
+	assert.Nil(t, lifetime.LifetimeMinutes)
+	assert.False(t, lifetime.IsStale)
+}
+
+func TestConvertGithubBranchComparisonWithoutCommits(t *testing.T) {
+	apiComparison := &GithubApiBranchComparison{Status: "behind", BehindBy: 2}
+	apiComparison.MergeBaseCommit.Sha = "abc"
+	assert.Nil(t, apiComparison.MergeBaseCommit.Commit.Committer.Date.UnmarshalJSON([]byte(`"2023-10-01T00:00:00Z"`)))
+
+	comparison := convertGithubBranchComparison(apiComparison, &BranchComparisonInput{Name: "old", Base: "main"}, 1, 123)
+	assert.Equal(t, "old", comparison.BranchName)
+	assert.Equal(t, "main", comparison.BaseBranch)
+	assert.Nil(t, comparison.FirstCommitDate)
+	assert.Equal(t, comparison.MergeBaseDate, comparison.LastCommitDate)
+	assert.Equal(t, 2023, comparison.LastCommitDate.Year())
+}
@@ -0,0 +1,75 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiBranchesMeta)
+}
+
+var ExtractApiBranchesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranches",
+	EntryPoint:       ExtractApiBranches,
+	EnabledByDefault: false,
+	Description:      "Extract raw branch data into tool layer table github_branches",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{RAW_BRANCH_TABLE},
+	ProductTables:    []string{models.GithubBranch{}.TableName()},
+}
+
+type GithubApiBranch struct {
+	Name   string `json:"name"`
+	Commit struct {
+		Sha string `json:"sha"`
+	} `json:"commit"`
+	Protected bool `json:"protected"`
+}
+
+func ExtractApiBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiBranch := &GithubApiBranch{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiBranch))
+			if err != nil {
+				return nil, err
+			}
+			branch := &models.GithubBranch{
+				ConnectionId: data.Options.ConnectionId,
+				RepoId:       data.Options.GithubId,
+				Name:         apiBranch.Name,
+				CommitSha:    apiBranch.Commit.Sha,
+				Protected:    apiBranch.Protected,
+			}
+			return []interface{}{branch}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
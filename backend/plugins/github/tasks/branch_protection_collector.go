@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiBranchProtectionsMeta)
+}
+
+const RAW_BRANCH_PROTECTION_TABLE = "github_api_branch_protections"
+
+var CollectApiBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiBranchProtections",
+	EntryPoint:       CollectApiBranchProtections,
+	EnabledByDefault: true,
+	Description:      "Collect the protection rule of the default branch from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{},
+	ProductTables:    []string{RAW_BRANCH_PROTECTION_TABLE},
+}
+
+type BranchProtectionInput struct {
+	Name string
+}
+
+// EscapedName is the path escaped Name for the UrlTemplate
+func (input BranchProtectionInput) EscapedName() string {
+	return escapeBranchName(input.Name)
+}
+
+func CollectApiBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_PROTECTION_TABLE)
+
+	// query the default branch directly instead of relying on the opt-in collectApiBranches,
+	// unprotected branches have no protection rule to collect, github responds 404 for them
+	defaultBranch, err := fetchDefaultBranch(data)
+	if err != nil {
+		return err
+	}
+	iterator := api.NewQueueIterator()
+	iterator.Push(&BranchProtectionInput{Name: defaultBranch})
+
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Incremental:        false,
+		Input:              iterator,
+		UrlTemplate:        "repos/{{ .Params.Name }}/branches/{{ .Input.EscapedName }}/protection",
+		ResponseParser:     api.GetRawMessageDirectFromResponse,
+		AfterResponse:      ignoreHTTPStatus403And404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
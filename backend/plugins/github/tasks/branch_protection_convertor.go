@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertBranchProtectionsMeta)
+}
+
+var ConvertBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "convertBranchProtections",
+	EntryPoint:       ConvertBranchProtections,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_branch_protections into domain layer table branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{
+		models.GithubBranchProtection{}.TableName(), // cursor
+		RAW_BRANCH_PROTECTION_TABLE},
+	ProductTables: []string{code.BranchProtection{}.TableName()},
+}
+
+func ConvertBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_PROTECTION_TABLE)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubBranchProtection{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GithubBranchProtection{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			protection := inputRow.(*models.GithubBranchProtection)
+			domainProtection := &code.BranchProtection{
+				DomainEntity:  domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", repoId, protection.BranchName)},
+				RepoId:        repoId,
+				BranchPattern: protection.BranchName,
+				// only the protection of the default branch is collected
+				IsDefaultBranch:        true,
+				RequirePullRequest:     protection.RequirePullRequestReviews,
+				RequiredApprovals:      protection.RequiredApprovingReviewCount,
+				RequireCodeOwnerReview: protection.RequireCodeOwnerReviews,
+				RequireStatusChecks:    protection.RequireStatusChecks,
+				RequiredStatusChecks:   protection.RequiredStatusChecks,
+				RequireSignedCommits:   protection.RequiredSignatures,
+				AllowForcePushes:       protection.AllowForcePushes,
+				AllowDeletions:         protection.AllowDeletions,
+				EnforceAdmins:          protection.EnforceAdmins,
+			}
+			return []interface{}{domainProtection}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
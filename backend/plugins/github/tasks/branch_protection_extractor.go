@@ -0,0 +1,113 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiBranchProtectionsMeta)
+}
+
+var ExtractApiBranchProtectionsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiBranchProtections",
+	EntryPoint:       ExtractApiBranchProtections,
+	EnabledByDefault: true,
+	Description:      "Extract raw branch protection data into tool layer table github_branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	DependencyTables: []string{RAW_BRANCH_PROTECTION_TABLE},
+	ProductTables:    []string{models.GithubBranchProtection{}.TableName()},
+}
+
+type githubApiEnabledSetting struct {
+	Enabled bool `json:"enabled"`
+}
+
+type GithubApiBranchProtection struct {
+	RequiredPullRequestReviews *struct {
+		RequiredApprovingReviewCount int  `json:"required_approving_review_count"`
+		RequireCodeOwnerReviews      bool `json:"require_code_owner_reviews"`
+		DismissStaleReviews          bool `json:"dismiss_stale_reviews"`
+	} `json:"required_pull_request_reviews"`
+	RequiredStatusChecks *struct {
+		Strict   bool     `json:"strict"`
+		Contexts []string `json:"contexts"`
+	} `json:"required_status_checks"`
+	EnforceAdmins         githubApiEnabledSetting `json:"enforce_admins"`
+	RequiredSignatures    githubApiEnabledSetting `json:"required_signatures"`
+	RequiredLinearHistory githubApiEnabledSetting `json:"required_linear_history"`
+	AllowForcePushes      githubApiEnabledSetting `json:"allow_force_pushes"`
+	AllowDeletions        githubApiEnabledSetting `json:"allow_deletions"`
+}
+
+func ExtractApiBranchProtections(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_BRANCH_PROTECTION_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			input := &BranchProtectionInput{}
+			err := errors.Convert(json.Unmarshal(row.Input, input))
+			if err != nil {
+				return nil, err
+			}
+			apiProtection := &GithubApiBranchProtection{}
+			err = errors.Convert(json.Unmarshal(row.Data, apiProtection))
+			if err != nil {
+				return nil, err
+			}
+			protection := convertGithubBranchProtection(apiProtection)
+			protection.ConnectionId = data.Options.ConnectionId
+			protection.RepoId = data.Options.GithubId
+			protection.BranchName = input.Name
+			return []interface{}{protection}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func convertGithubBranchProtection(apiProtection *GithubApiBranchProtection) *models.GithubBranchProtection {
+	protection := &models.GithubBranchProtection{
+		EnforceAdmins:         apiProtection.EnforceAdmins.Enabled,
+		RequiredSignatures:    apiProtection.RequiredSignatures.Enabled,
+		RequiredLinearHistory: apiProtection.RequiredLinearHistory.Enabled,
+		AllowForcePushes:      apiProtection.AllowForcePushes.Enabled,
+		AllowDeletions:        apiProtection.AllowDeletions.Enabled,
+	}
+	if reviews := apiProtection.RequiredPullRequestReviews; reviews != nil {
+		protection.RequirePullRequestReviews = true
+		protection.RequiredApprovingReviewCount = reviews.RequiredApprovingReviewCount
+		protection.RequireCodeOwnerReviews = reviews.RequireCodeOwnerReviews
+		protection.DismissStaleReviews = reviews.DismissStaleReviews
+	}
+	if checks := apiProtection.RequiredStatusChecks; checks != nil {
+		protection.RequireStatusChecks = true
+		protection.StrictStatusChecks = checks.Strict
+		protection.RequiredStatusChecks = strings.Join(checks.Contexts, ",")
+	}
+	return protection
+}
@@ -0,0 +1,49 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertGithubBranchProtection(t *testing.T) {
+	apiProtection := &GithubApiBranchProtection{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"required_pull_request_reviews": {"required_approving_review_count": 2, "require_code_owner_reviews": true},
+		"required_status_checks": {"strict": true, "contexts": ["ci/build", "ci/test"]},
+		"enforce_admins": {"enabled": true},
+		"allow_force_pushes": {"enabled": false}
+	}`), apiProtection))
+
+	protection := convertGithubBranchProtection(apiProtection)
+	assert.True(t, protection.RequirePullRequestReviews)
+	assert.Equal(t, 2, protection.RequiredApprovingReviewCount)
+	assert.True(t, protection.RequireCodeOwnerReviews)
+	assert.True(t, protection.RequireStatusChecks)
+	assert.True(t, protection.StrictStatusChecks)
+	assert.Equal(t, "ci/build,ci/test", protection.RequiredStatusChecks)
+	assert.True(t, protection.EnforceAdmins)
+	assert.False(t, protection.AllowForcePushes)
+
+	protection = convertGithubBranchProtection(&GithubApiBranchProtection{})
+	assert.False(t, protection.RequirePullRequestReviews)
+	assert.False(t, protection.RequireStatusChecks)
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiCodeScanningAlertsMeta)
+}
+
+const RAW_CODE_SCANNING_ALERT_TABLE = "github_api_code_scanning_alerts"
+
+var CollectApiCodeScanningAlertsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiCodeScanningAlerts",
+	EntryPoint:       CollectApiCodeScanningAlerts,
+	EnabledByDefault: true,
+	Description:      "Collect code scanning alerts data from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{},
+	ProductTables:    []string{RAW_CODE_SCANNING_ALERT_TABLE},
+}
+
+func CollectApiCodeScanningAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CODE_SCANNING_ALERT_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		UrlTemplate:        "repos/{{ .Params.Name }}/code-scanning/alerts",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+			query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		GetTotalPages:  GetTotalPagesFromResponse,
+		ResponseParser: api.GetRawMessageArrayFromResponse,
+		// code scanning is not set up for the repo, or the token lacks the security_events permission
+		AfterResponse: ignoreHTTPStatus403And404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
@@ -0,0 +1,132 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertCodeScanningAlertsMeta)
+}
+
+var ConvertCodeScanningAlertsMeta = plugin.SubTaskMeta{
+	Name:             "convertCodeScanningAlerts",
+	EntryPoint:       ConvertCodeScanningAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_code_scanning_alerts into domain layer table security_vulnerabilities",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{
+		models.GithubCodeScanningAlert{}.TableName(), // cursor
+		RAW_CODE_SCANNING_ALERT_TABLE},
+	ProductTables: []string{
+		security.Vulnerability{}.TableName(),
+		security.VulnerabilityStatusChange{}.TableName()},
+}
+
+func ConvertCodeScanningAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CODE_SCANNING_ALERT_TABLE)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubCodeScanningAlert{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	alertIdGen := didgen.NewDomainIdGenerator(&models.GithubCodeScanningAlert{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GithubCodeScanningAlert{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alert := inputRow.(*models.GithubCodeScanningAlert)
+			vulnerability := convertCodeScanningAlertToVulnerability(alert)
+			vulnerability.DomainEntity = domainlayer.DomainEntity{Id: alertIdGen.Generate(alert.ConnectionId, alert.RepoId, alert.Number)}
+			vulnerability.ScopeId = repoId
+			return append(
+				[]interface{}{vulnerability},
+				makeVulnerabilityStatusChanges(vulnerability.Id,
+					vulnerabilityStatusChange{"open", security.OPEN, &alert.GithubCreatedAt},
+					vulnerabilityStatusChange{"fixed", security.FIXED, alert.FixedAt},
+					vulnerabilityStatusChange{"dismissed", security.DISMISSED, alert.DismissedAt},
+				)...,
+			), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func convertCodeScanningAlertToVulnerability(alert *models.GithubCodeScanningAlert) *security.Vulnerability {
+	vulnerability := &security.Vulnerability{
+		Source:         security.CODE_SCANNING,
+		Title:          alert.RuleDescription,
+		Severity:       codeScanningSeverity(alert),
+		OriginalStatus: alert.State,
+		Identifier:     alert.RuleId,
+		Package:        alert.ToolName,
+		FilePath:       alert.FilePath,
+		Url:            alert.HtmlUrl,
+		CreatedDate:    alert.GithubCreatedAt,
+	}
+	switch alert.State {
+	case "fixed":
+		vulnerability.Status = security.FIXED
+		resolveVulnerability(vulnerability, alert.FixedAt)
+	case "dismissed":
+		vulnerability.Status = security.DISMISSED
+		resolveVulnerability(vulnerability, alert.DismissedAt)
+	default:
+		vulnerability.Status = security.OPEN
+	}
+	return vulnerability
+}
+
+// codeScanningSeverity prefers the security severity of the rule, only security rules (e.g. of CodeQL)
+// have it, the others fall back to the severity of the rule which is one of none, note, warning and error
+func codeScanningSeverity(alert *models.GithubCodeScanningAlert) string {
+	if alert.SecuritySeverityLevel != "" {
+		return strings.ToUpper(alert.SecuritySeverityLevel)
+	}
+	switch alert.RuleSeverity {
+	case "error":
+		return security.HIGH
+	case "warning":
+		return security.MEDIUM
+	default:
+		return security.LOW
+	}
+}
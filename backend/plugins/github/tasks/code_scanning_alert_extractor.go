@@ -0,0 +1,122 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiCodeScanningAlertsMeta)
+}
+
+var ExtractApiCodeScanningAlertsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiCodeScanningAlerts",
+	EntryPoint:       ExtractApiCodeScanningAlerts,
+	EnabledByDefault: true,
+	Description:      "Extract raw code scanning alerts data into tool layer table github_code_scanning_alerts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{RAW_CODE_SCANNING_ALERT_TABLE},
+	ProductTables:    []string{models.GithubCodeScanningAlert{}.TableName()},
+}
+
+type GithubApiCodeScanningAlert struct {
+	Number      int    `json:"number"`
+	State       string `json:"state"`
+	HtmlUrl     string `json:"html_url"`
+	DismissedBy *struct {
+		Login string `json:"login"`
+	} `json:"dismissed_by"`
+	DismissedReason  string `json:"dismissed_reason"`
+	DismissedComment string `json:"dismissed_comment"`
+	Rule             struct {
+		Id                    string `json:"id"`
+		Severity              string `json:"severity"`
+		SecuritySeverityLevel string `json:"security_severity_level"`
+		Description           string `json:"description"`
+	} `json:"rule"`
+	Tool struct {
+		Name string `json:"name"`
+	} `json:"tool"`
+	MostRecentInstance struct {
+		Ref      string `json:"ref"`
+		Location struct {
+			Path      string `json:"path"`
+			StartLine int    `json:"start_line"`
+			EndLine   int    `json:"end_line"`
+		} `json:"location"`
+	} `json:"most_recent_instance"`
+	CreatedAt   common.Iso8601Time  `json:"created_at"`
+	UpdatedAt   *common.Iso8601Time `json:"updated_at"`
+	FixedAt     *common.Iso8601Time `json:"fixed_at"`
+	DismissedAt *common.Iso8601Time `json:"dismissed_at"`
+}
+
+func ExtractApiCodeScanningAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_CODE_SCANNING_ALERT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiAlert := &GithubApiCodeScanningAlert{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{convertGithubCodeScanningAlert(apiAlert, data.Options.ConnectionId, data.Options.GithubId)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func convertGithubCodeScanningAlert(apiAlert *GithubApiCodeScanningAlert, connectionId uint64, repoId int) *models.GithubCodeScanningAlert {
+	alert := &models.GithubCodeScanningAlert{
+		ConnectionId:          connectionId,
+		RepoId:                repoId,
+		Number:                apiAlert.Number,
+		State:                 apiAlert.State,
+		RuleId:                apiAlert.Rule.Id,
+		RuleDescription:       apiAlert.Rule.Description,
+		RuleSeverity:          apiAlert.Rule.Severity,
+		SecuritySeverityLevel: apiAlert.Rule.SecuritySeverityLevel,
+		ToolName:              apiAlert.Tool.Name,
+		Ref:                   apiAlert.MostRecentInstance.Ref,
+		FilePath:              apiAlert.MostRecentInstance.Location.Path,
+		StartLine:             apiAlert.MostRecentInstance.Location.StartLine,
+		EndLine:               apiAlert.MostRecentInstance.Location.EndLine,
+		HtmlUrl:               apiAlert.HtmlUrl,
+		DismissedReason:       apiAlert.DismissedReason,
+		DismissedComment:      apiAlert.DismissedComment,
+		GithubCreatedAt:       apiAlert.CreatedAt.ToTime(),
+		GithubUpdatedAt:       common.Iso8601TimeToTime(apiAlert.UpdatedAt),
+		FixedAt:               common.Iso8601TimeToTime(apiAlert.FixedAt),
+		DismissedAt:           common.Iso8601TimeToTime(apiAlert.DismissedAt),
+	}
+	if apiAlert.DismissedBy != nil {
+		alert.DismissedBy = apiAlert.DismissedBy.Login
+	}
+	return alert
+}
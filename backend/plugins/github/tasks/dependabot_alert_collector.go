@@ -0,0 +1,79 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/utils"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiDependabotAlertsMeta)
+}
+
+const RAW_DEPENDABOT_ALERT_TABLE = "github_api_dependabot_alerts"
+
+var CollectApiDependabotAlertsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiDependabotAlerts",
+	EntryPoint:       CollectApiDependabotAlerts,
+	EnabledByDefault: true,
+	Description:      "Collect dependabot alerts data from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{},
+	ProductTables:    []string{RAW_DEPENDABOT_ALERT_TABLE},
+}
+
+func CollectApiDependabotAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPENDABOT_ALERT_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		UrlTemplate:        "repos/{{ .Params.Name }}/dependabot/alerts",
+		// the endpoint only supports cursor-based pagination, the cursor of the next page comes from the link header
+		GetNextPageCustomData: func(prevReqData *api.RequestData, prevPageResponse *http.Response) (interface{}, errors.Error) {
+			cursor := utils.GetNextCursorFromLinkHeader(prevPageResponse.Header.Get("link"))
+			if cursor == "" {
+				return nil, api.ErrFinishCollect
+			}
+			return cursor, nil
+		},
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+			if cursor, ok := reqData.CustomData.(string); ok && cursor != "" {
+				query.Set("after", cursor)
+			}
+			return query, nil
+		},
+		ResponseParser: api.GetRawMessageArrayFromResponse,
+		// dependabot alerts are disabled for the repo or the token lacks the security_events permission
+		AfterResponse: ignoreHTTPStatus403And404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
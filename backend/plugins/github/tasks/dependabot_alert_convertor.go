@@ -0,0 +1,125 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertDependabotAlertsMeta)
+}
+
+var ConvertDependabotAlertsMeta = plugin.SubTaskMeta{
+	Name:             "convertDependabotAlerts",
+	EntryPoint:       ConvertDependabotAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_dependabot_alerts into domain layer table security_vulnerabilities",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{
+		models.GithubDependabotAlert{}.TableName(), // cursor
+		RAW_DEPENDABOT_ALERT_TABLE},
+	ProductTables: []string{
+		security.Vulnerability{}.TableName(),
+		security.VulnerabilityStatusChange{}.TableName()},
+}
+
+func ConvertDependabotAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPENDABOT_ALERT_TABLE)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubDependabotAlert{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	alertIdGen := didgen.NewDomainIdGenerator(&models.GithubDependabotAlert{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GithubDependabotAlert{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alert := inputRow.(*models.GithubDependabotAlert)
+			vulnerability := convertDependabotAlertToVulnerability(alert)
+			vulnerability.DomainEntity = domainlayer.DomainEntity{Id: alertIdGen.Generate(alert.ConnectionId, alert.RepoId, alert.Number)}
+			vulnerability.ScopeId = repoId
+			return append(
+				[]interface{}{vulnerability},
+				makeVulnerabilityStatusChanges(vulnerability.Id,
+					vulnerabilityStatusChange{"open", security.OPEN, &alert.GithubCreatedAt},
+					vulnerabilityStatusChange{"fixed", security.FIXED, alert.FixedAt},
+					vulnerabilityStatusChange{"dismissed", security.DISMISSED, alert.DismissedAt},
+					vulnerabilityStatusChange{"auto_dismissed", security.DISMISSED, alert.AutoDismissedAt},
+				)...,
+			), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func convertDependabotAlertToVulnerability(alert *models.GithubDependabotAlert) *security.Vulnerability {
+	vulnerability := &security.Vulnerability{
+		Source:         security.DEPENDABOT,
+		Title:          alert.Summary,
+		Severity:       strings.ToUpper(alert.Severity),
+		OriginalStatus: alert.State,
+		Identifier:     alert.GhsaId,
+		Package:        alert.PackageName,
+		Ecosystem:      alert.PackageEcosystem,
+		FilePath:       alert.ManifestPath,
+		Url:            alert.HtmlUrl,
+		CreatedDate:    alert.GithubCreatedAt,
+	}
+	// prefer the well known CVE id, not every advisory has one though
+	if alert.CveId != "" {
+		vulnerability.Identifier = alert.CveId
+	}
+	switch alert.State {
+	case "fixed":
+		vulnerability.Status = security.FIXED
+		resolveVulnerability(vulnerability, alert.FixedAt)
+	case "dismissed":
+		vulnerability.Status = security.DISMISSED
+		resolveVulnerability(vulnerability, alert.DismissedAt)
+	case "auto_dismissed":
+		vulnerability.Status = security.DISMISSED
+		resolveVulnerability(vulnerability, alert.AutoDismissedAt)
+	default:
+		vulnerability.Status = security.OPEN
+	}
+	return vulnerability
+}
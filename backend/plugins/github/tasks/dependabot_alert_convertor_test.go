@@ -0,0 +1,59 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertDependabotAlertToVulnerability(t *testing.T) {
+	apiAlert := &GithubApiDependabotAlert{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"number": 2,
+		"state": "fixed",
+		"dependency": {"package": {"ecosystem": "npm", "name": "lodash"}, "manifest_path": "package-lock.json", "scope": "runtime"},
+		"security_advisory": {"ghsa_id": "GHSA-jf85-cpcp-j695", "cve_id": "CVE-2019-10744", "summary": "Prototype Pollution in lodash", "severity": "critical"},
+		"html_url": "https://github.com/o/r/security/dependabot/2",
+		"created_at": "2023-12-01T10:00:00Z",
+		"updated_at": "2023-12-03T10:00:00Z",
+		"fixed_at": "2023-12-03T10:00:00Z"
+	}`), apiAlert))
+
+	alert := convertGithubDependabotAlert(apiAlert, 1, 100)
+	assert.Equal(t, 2, alert.Number)
+	assert.Equal(t, "lodash", alert.PackageName)
+
+	vulnerability := convertDependabotAlertToVulnerability(alert)
+	assert.Equal(t, security.DEPENDABOT, vulnerability.Source)
+	assert.Equal(t, security.FIXED, vulnerability.Status)
+	assert.Equal(t, security.CRITICAL, vulnerability.Severity)
+	assert.Equal(t, "CVE-2019-10744", vulnerability.Identifier)
+	assert.Equal(t, uint(2*24*60), *vulnerability.LeadTimeMinutes)
+
+	alert.State = "open"
+	alert.CveId = ""
+	vulnerability = convertDependabotAlertToVulnerability(alert)
+	assert.Equal(t, security.OPEN, vulnerability.Status)
+	assert.Equal(t, "GHSA-jf85-cpcp-j695", vulnerability.Identifier)
+	assert.Nil(t, vulnerability.ResolvedDate)
+	assert.Nil(t, vulnerability.LeadTimeMinutes)
+}
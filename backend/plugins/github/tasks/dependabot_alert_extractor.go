@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiDependabotAlertsMeta)
+}
+
+var ExtractApiDependabotAlertsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiDependabotAlerts",
+	EntryPoint:       ExtractApiDependabotAlerts,
+	EnabledByDefault: true,
+	Description:      "Extract raw dependabot alerts data into tool layer table github_dependabot_alerts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{RAW_DEPENDABOT_ALERT_TABLE},
+	ProductTables:    []string{models.GithubDependabotAlert{}.TableName()},
+}
+
+type GithubApiDependabotAlert struct {
+	Number     int    `json:"number"`
+	State      string `json:"state"`
+	Dependency struct {
+		Package struct {
+			Ecosystem string `json:"ecosystem"`
+			Name      string `json:"name"`
+		} `json:"package"`
+		ManifestPath string `json:"manifest_path"`
+		Scope        string `json:"scope"`
+	} `json:"dependency"`
+	SecurityAdvisory struct {
+		GhsaId   string `json:"ghsa_id"`
+		CveId    string `json:"cve_id"`
+		Summary  string `json:"summary"`
+		Severity string `json:"severity"`
+	} `json:"security_advisory"`
+	HtmlUrl         string              `json:"html_url"`
+	CreatedAt       common.Iso8601Time  `json:"created_at"`
+	UpdatedAt       common.Iso8601Time  `json:"updated_at"`
+	DismissedAt     *common.Iso8601Time `json:"dismissed_at"`
+	DismissedReason string              `json:"dismissed_reason"`
+	FixedAt         *common.Iso8601Time `json:"fixed_at"`
+	AutoDismissedAt *common.Iso8601Time `json:"auto_dismissed_at"`
+}
+
+func ExtractApiDependabotAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_DEPENDABOT_ALERT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiAlert := &GithubApiDependabotAlert{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{convertGithubDependabotAlert(apiAlert, data.Options.ConnectionId, data.Options.GithubId)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func convertGithubDependabotAlert(apiAlert *GithubApiDependabotAlert, connectionId uint64, repoId int) *models.GithubDependabotAlert {
+	return &models.GithubDependabotAlert{
+		ConnectionId:     connectionId,
+		RepoId:           repoId,
+		Number:           apiAlert.Number,
+		State:            apiAlert.State,
+		PackageName:      apiAlert.Dependency.Package.Name,
+		PackageEcosystem: apiAlert.Dependency.Package.Ecosystem,
+		ManifestPath:     apiAlert.Dependency.ManifestPath,
+		DependencyScope:  apiAlert.Dependency.Scope,
+		GhsaId:           apiAlert.SecurityAdvisory.GhsaId,
+		CveId:            apiAlert.SecurityAdvisory.CveId,
+		Summary:          apiAlert.SecurityAdvisory.Summary,
+		Severity:         apiAlert.SecurityAdvisory.Severity,
+		HtmlUrl:          apiAlert.HtmlUrl,
+		DismissedReason:  apiAlert.DismissedReason,
+		GithubCreatedAt:  apiAlert.CreatedAt.ToTime(),
+		GithubUpdatedAt:  apiAlert.UpdatedAt.ToTime(),
+		FixedAt:          common.Iso8601TimeToTime(apiAlert.FixedAt),
+		DismissedAt:      common.Iso8601TimeToTime(apiAlert.DismissedAt),
+		AutoDismissedAt:  common.Iso8601TimeToTime(apiAlert.AutoDismissedAt),
+	}
+}
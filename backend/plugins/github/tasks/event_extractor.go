@@ -82,6 +82,7 @@ func ExtractApiEvents(taskCtx plugin.SubTaskContext) errors.Error {
 			}
 
 			if body.Actor != nil {
+				githubIssueEvent.AuthorId = body.Actor.Id
 				githubIssueEvent.AuthorUsername = body.Actor.Login
 
 				githubAccount, err := convertAccount(body.Actor, data.Options.GithubId, data.Options.ConnectionId)
@@ -0,0 +1,118 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertIssueEventsMeta)
+}
+
+var ConvertIssueEventsMeta = plugin.SubTaskMeta{
+	Name:             "convertIssueEvents",
+	EntryPoint:       ConvertIssueEvents,
+	EnabledByDefault: true,
+	Description:      "Convert the closed/reopened events of tool layer table github_issue_events into domain layer table issue_changelogs",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
+	DependencyTables: []string{
+		models.GithubIssueEvent{}.TableName(), // cursor
+		models.GithubIssue{}.TableName(),      // cursor
+		RAW_EVENTS_TABLE},
+	ProductTables: []string{ticket.IssueChangelogs{}.TableName()},
+}
+
+// github issues only have 2 states, closing and reopening an issue are the only status changes
+var githubStatusEvents = map[string][2]string{
+	"closed":   {"open", "closed"},
+	"reopened": {"closed", "open"},
+}
+
+var githubStdStatus = map[string]string{
+	"open":   ticket.TODO,
+	"closed": ticket.DONE,
+}
+
+func ConvertIssueEvents(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*GithubTaskData)
+
+	cursor, err := db.Cursor(
+		dal.Select("_tool_github_issue_events.*"),
+		dal.From(&models.GithubIssueEvent{}),
+		dal.Join(`JOIN _tool_github_issues ON (_tool_github_issues.connection_id = _tool_github_issue_events.connection_id
+			AND _tool_github_issues.github_id = _tool_github_issue_events.issue_id)`),
+		dal.Where(
+			"_tool_github_issues.repo_id = ? AND _tool_github_issues.connection_id = ? AND _tool_github_issue_events.type IN ?",
+			data.Options.GithubId, data.Options.ConnectionId, []string{"closed", "reopened"},
+		),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	eventIdGen := didgen.NewDomainIdGenerator(&models.GithubIssueEvent{})
+	issueIdGen := didgen.NewDomainIdGenerator(&models.GithubIssue{})
+	accountIdGen := didgen.NewDomainIdGenerator(&models.GithubAccount{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Ctx: taskCtx,
+			Params: GithubApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				Name:         data.Options.Name,
+			},
+			Table: RAW_EVENTS_TABLE,
+		},
+		InputRowType: reflect.TypeOf(models.GithubIssueEvent{}),
+		Input:        cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			event := inputRow.(*models.GithubIssueEvent)
+			states := githubStatusEvents[event.Type]
+			changelog := &ticket.IssueChangelogs{
+				DomainEntity:      domainlayer.DomainEntity{Id: eventIdGen.Generate(event.ConnectionId, event.GithubId)},
+				IssueId:           issueIdGen.Generate(event.ConnectionId, event.IssueId),
+				AuthorId:          accountIdGen.Generate(event.ConnectionId, event.AuthorId),
+				AuthorName:        event.AuthorUsername,
+				FieldId:           "status",
+				FieldName:         "status",
+				OriginalFromValue: states[0],
+				OriginalToValue:   states[1],
+				FromValue:         githubStdStatus[states[0]],
+				ToValue:           githubStdStatus[states[1]],
+				CreatedDate:       event.GithubCreatedAt,
+			}
+			return []interface{}{changelog}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
@@ -0,0 +1,105 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertCodeScanningAlertToVulnerability(t *testing.T) {
+	apiAlert := &GithubApiCodeScanningAlert{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"number": 4,
+		"state": "dismissed",
+		"html_url": "https://github.com/o/r/security/code-scanning/4",
+		"dismissed_by": {"login": "octocat"},
+		"dismissed_reason": "false positive",
+		"rule": {"id": "js/sql-injection", "severity": "error", "security_severity_level": "high", "description": "Database query built from user-controlled sources"},
+		"tool": {"name": "CodeQL"},
+		"most_recent_instance": {"ref": "refs/heads/main", "location": {"path": "src/db.js", "start_line": 10, "end_line": 12}},
+		"created_at": "2023-12-01T10:00:00Z",
+		"dismissed_at": "2023-12-01T12:00:00Z"
+	}`), apiAlert))
+
+	alert := convertGithubCodeScanningAlert(apiAlert, 1, 100)
+	assert.Equal(t, "octocat", alert.DismissedBy)
+	assert.Equal(t, "src/db.js", alert.FilePath)
+
+	vulnerability := convertCodeScanningAlertToVulnerability(alert)
+	assert.Equal(t, security.CODE_SCANNING, vulnerability.Source)
+	assert.Equal(t, security.DISMISSED, vulnerability.Status)
+	assert.Equal(t, security.HIGH, vulnerability.Severity)
+	assert.Equal(t, "js/sql-injection", vulnerability.Identifier)
+	assert.Equal(t, "src/db.js", vulnerability.FilePath)
+	assert.Equal(t, uint(120), *vulnerability.LeadTimeMinutes)
+
+	changes := makeVulnerabilityStatusChanges("id",
+		vulnerabilityStatusChange{"open", security.OPEN, &alert.GithubCreatedAt},
+		vulnerabilityStatusChange{"fixed", security.FIXED, alert.FixedAt},
+		vulnerabilityStatusChange{"dismissed", security.DISMISSED, alert.DismissedAt},
+	)
+	assert.Len(t, changes, 2)
+	assert.Equal(t, security.DISMISSED, changes[1].(*security.VulnerabilityStatusChange).Status)
+
+	alert.SecuritySeverityLevel = ""
+	alert.RuleSeverity = "warning"
+	assert.Equal(t, security.MEDIUM, codeScanningSeverity(alert))
+}
+
+func TestConvertSecretScanningAlertToVulnerability(t *testing.T) {
+	res := &http.Response{Body: io.NopCloser(bytes.NewBufferString(`[{
+		"number": 2,
+		"state": "resolved",
+		"resolution": "revoked",
+		"resolved_by": {"login": "octocat"},
+		"secret_type": "github_personal_access_token",
+		"secret_type_display_name": "GitHub Personal Access Token",
+		"secret": "ghp_leaked",
+		"created_at": "2023-12-01T10:00:00Z",
+		"resolved_at": "2023-12-01T10:30:00Z"
+	}]`))}
+	items, err := parseSecretScanningAlerts(res)
+	assert.Nil(t, err)
+	assert.Len(t, items, 1)
+	assert.NotContains(t, string(items[0]), "ghp_leaked")
+
+	apiAlert := &GithubApiSecretScanningAlert{}
+	assert.Nil(t, json.Unmarshal(items[0], apiAlert))
+	alert := convertGithubSecretScanningAlert(apiAlert, 1, 100)
+	assert.Equal(t, "octocat", alert.ResolvedBy)
+
+	vulnerability := convertSecretScanningAlertToVulnerability(alert)
+	assert.Equal(t, security.SECRET_SCANNING, vulnerability.Source)
+	assert.Equal(t, security.FIXED, vulnerability.Status)
+	assert.Equal(t, uint(30), *vulnerability.LeadTimeMinutes)
+
+	alert.Resolution = "false_positive"
+	assert.Equal(t, security.DISMISSED, convertSecretScanningAlertToVulnerability(alert).Status)
+
+	alert.State = "open"
+	vulnerability = convertSecretScanningAlertToVulnerability(alert)
+	assert.Equal(t, security.OPEN, vulnerability.Status)
+	assert.Nil(t, vulnerability.ResolvedDate)
+}
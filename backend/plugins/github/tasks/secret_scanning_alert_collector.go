@@ -0,0 +1,89 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectApiSecretScanningAlertsMeta)
+}
+
+const RAW_SECRET_SCANNING_ALERT_TABLE = "github_api_secret_scanning_alerts"
+
+var CollectApiSecretScanningAlertsMeta = plugin.SubTaskMeta{
+	Name:             "collectApiSecretScanningAlerts",
+	EntryPoint:       CollectApiSecretScanningAlerts,
+	EnabledByDefault: true,
+	Description:      "Collect secret scanning alerts data from Github api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{},
+	ProductTables:    []string{RAW_SECRET_SCANNING_ALERT_TABLE},
+}
+
+func CollectApiSecretScanningAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SECRET_SCANNING_ALERT_TABLE)
+	collector, err := api.NewApiCollector(api.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		PageSize:           100,
+		Incremental:        false,
+		UrlTemplate:        "repos/{{ .Params.Name }}/secret-scanning/alerts",
+		Query: func(reqData *api.RequestData) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
+			query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
+			return query, nil
+		},
+		GetTotalPages:  GetTotalPagesFromResponse,
+		ResponseParser: parseSecretScanningAlerts,
+		// secret scanning is disabled for the repo, or the token lacks the secret_scanning_alerts permission
+		AfterResponse: ignoreHTTPStatus403And404,
+	})
+	if err != nil {
+		return err
+	}
+	return collector.Execute()
+}
+
+// parseSecretScanningAlerts drops the leaked secret from the alerts, it must not end up in the raw table
+func parseSecretScanningAlerts(res *http.Response) ([]json.RawMessage, errors.Error) {
+	var alerts []map[string]json.RawMessage
+	err := api.UnmarshalResponse(res, &alerts)
+	if err != nil {
+		return nil, err
+	}
+	items := make([]json.RawMessage, 0, len(alerts))
+	for _, alert := range alerts {
+		delete(alert, "secret")
+		item, err := json.Marshal(alert)
+		if err != nil {
+			return nil, errors.Convert(err)
+		}
+		items = append(items, item)
+	}
+	return items, nil
+}
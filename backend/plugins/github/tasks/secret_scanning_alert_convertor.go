@@ -0,0 +1,114 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertSecretScanningAlertsMeta)
+}
+
+var ConvertSecretScanningAlertsMeta = plugin.SubTaskMeta{
+	Name:             "convertSecretScanningAlerts",
+	EntryPoint:       ConvertSecretScanningAlerts,
+	EnabledByDefault: true,
+	Description:      "Convert tool layer table github_secret_scanning_alerts into domain layer table security_vulnerabilities",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{
+		models.GithubSecretScanningAlert{}.TableName(), // cursor
+		RAW_SECRET_SCANNING_ALERT_TABLE},
+	ProductTables: []string{
+		security.Vulnerability{}.TableName(),
+		security.VulnerabilityStatusChange{}.TableName()},
+}
+
+func ConvertSecretScanningAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SECRET_SCANNING_ALERT_TABLE)
+	db := taskCtx.GetDal()
+	cursor, err := db.Cursor(
+		dal.From(&models.GithubSecretScanningAlert{}),
+		dal.Where("repo_id = ? AND connection_id = ?", data.Options.GithubId, data.Options.ConnectionId),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.GithubRepo{}).Generate(data.Options.ConnectionId, data.Options.GithubId)
+	alertIdGen := didgen.NewDomainIdGenerator(&models.GithubSecretScanningAlert{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(models.GithubSecretScanningAlert{}),
+		Input:              cursor,
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			alert := inputRow.(*models.GithubSecretScanningAlert)
+			vulnerability := convertSecretScanningAlertToVulnerability(alert)
+			vulnerability.DomainEntity = domainlayer.DomainEntity{Id: alertIdGen.Generate(alert.ConnectionId, alert.RepoId, alert.Number)}
+			vulnerability.ScopeId = repoId
+			return append(
+				[]interface{}{vulnerability},
+				makeVulnerabilityStatusChanges(vulnerability.Id,
+					vulnerabilityStatusChange{"open", security.OPEN, &alert.GithubCreatedAt},
+					vulnerabilityStatusChange{"resolved", vulnerability.Status, vulnerability.ResolvedDate},
+				)...,
+			), nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
+
+func convertSecretScanningAlertToVulnerability(alert *models.GithubSecretScanningAlert) *security.Vulnerability {
+	vulnerability := &security.Vulnerability{
+		Source: security.SECRET_SCANNING,
+		Title:  alert.SecretTypeDisplayName,
+		// github has no severity for leaked secrets, all of them need to be revoked
+		Severity:       security.HIGH,
+		OriginalStatus: alert.State,
+		Identifier:     alert.SecretType,
+		Url:            alert.HtmlUrl,
+		CreatedDate:    alert.GithubCreatedAt,
+	}
+	if alert.State != "resolved" {
+		vulnerability.Status = security.OPEN
+		return vulnerability
+	}
+	// only a revoked secret is remediated, the other resolutions (false_positive, wont_fix, used_in_tests...) dismiss the alert
+	if alert.Resolution == "revoked" {
+		vulnerability.Status = security.FIXED
+	} else {
+		vulnerability.Status = security.DISMISSED
+	}
+	resolveVulnerability(vulnerability, alert.ResolvedAt)
+	return vulnerability
+}
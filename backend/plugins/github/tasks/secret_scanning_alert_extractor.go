@@ -0,0 +1,102 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/github/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractApiSecretScanningAlertsMeta)
+}
+
+var ExtractApiSecretScanningAlertsMeta = plugin.SubTaskMeta{
+	Name:             "extractApiSecretScanningAlerts",
+	EntryPoint:       ExtractApiSecretScanningAlerts,
+	EnabledByDefault: true,
+	Description:      "Extract raw secret scanning alerts data into tool layer table github_secret_scanning_alerts",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_SECURITY},
+	DependencyTables: []string{RAW_SECRET_SCANNING_ALERT_TABLE},
+	ProductTables:    []string{models.GithubSecretScanningAlert{}.TableName()},
+}
+
+type GithubApiSecretScanningAlert struct {
+	Number            int    `json:"number"`
+	State             string `json:"state"`
+	HtmlUrl           string `json:"html_url"`
+	Resolution        string `json:"resolution"`
+	ResolutionComment string `json:"resolution_comment"`
+	ResolvedBy        *struct {
+		Login string `json:"login"`
+	} `json:"resolved_by"`
+	SecretType               string              `json:"secret_type"`
+	SecretTypeDisplayName    string              `json:"secret_type_display_name"`
+	PushProtectionBypassed   bool                `json:"push_protection_bypassed"`
+	PushProtectionBypassedAt *common.Iso8601Time `json:"push_protection_bypassed_at"`
+	CreatedAt                common.Iso8601Time  `json:"created_at"`
+	UpdatedAt                *common.Iso8601Time `json:"updated_at"`
+	ResolvedAt               *common.Iso8601Time `json:"resolved_at"`
+}
+
+func ExtractApiSecretScanningAlerts(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_SECRET_SCANNING_ALERT_TABLE)
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiAlert := &GithubApiSecretScanningAlert{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiAlert))
+			if err != nil {
+				return nil, err
+			}
+			return []interface{}{convertGithubSecretScanningAlert(apiAlert, data.Options.ConnectionId, data.Options.GithubId)}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+	return extractor.Execute()
+}
+
+func convertGithubSecretScanningAlert(apiAlert *GithubApiSecretScanningAlert, connectionId uint64, repoId int) *models.GithubSecretScanningAlert {
+	alert := &models.GithubSecretScanningAlert{
+		ConnectionId:             connectionId,
+		RepoId:                   repoId,
+		Number:                   apiAlert.Number,
+		State:                    apiAlert.State,
+		Resolution:               apiAlert.Resolution,
+		ResolutionComment:        apiAlert.ResolutionComment,
+		SecretType:               apiAlert.SecretType,
+		SecretTypeDisplayName:    apiAlert.SecretTypeDisplayName,
+		PushProtectionBypassed:   apiAlert.PushProtectionBypassed,
+		PushProtectionBypassedAt: common.Iso8601TimeToTime(apiAlert.PushProtectionBypassedAt),
+		HtmlUrl:                  apiAlert.HtmlUrl,
+		GithubCreatedAt:          apiAlert.CreatedAt.ToTime(),
+		GithubUpdatedAt:          common.Iso8601TimeToTime(apiAlert.UpdatedAt),
+		ResolvedAt:               common.Iso8601TimeToTime(apiAlert.ResolvedAt),
+	}
+	if apiAlert.ResolvedBy != nil {
+		alert.ResolvedBy = apiAlert.ResolvedBy.Login
+	}
+	return alert
+}
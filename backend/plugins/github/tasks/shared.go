@@ -73,6 +73,20 @@ func ignoreHTTPStatus404(res *http.Response) errors.Error {
 	return nil
 }
 
+// ignoreHTTPStatus403And404 also ignores 403 which is returned when the token lacks the admin permission of the repo,
+// a 403 of the rate limit is left to the retries, skipping it would silently truncate the collected data
+func ignoreHTTPStatus403And404(res *http.Response) errors.Error {
+	if res.StatusCode == http.StatusForbidden && !isRateLimited(res) {
+		return api.ErrIgnoreAndContinue
+	}
+	return ignoreHTTPStatus404(res)
+}
+
+// isRateLimited returns true if the 403 is caused by the primary or the secondary rate limit of github
+func isRateLimited(res *http.Response) bool {
+	return res.Header.Get("Retry-After") != "" || res.Header.Get("X-RateLimit-Remaining") == "0"
+}
+
 func ignoreHTTPStatus422(res *http.Response) errors.Error {
 	if res.StatusCode == http.StatusUnprocessableEntity {
 		return api.ErrIgnoreAndContinue
@@ -92,3 +106,21 @@ func CreateRawDataSubTaskArgs(taskCtx plugin.SubTaskContext, table string) (*api
 	}
 	return RawDataSubTaskArgs, data
 }
+
+type githubApiRepoDefaultBranch struct {
+	DefaultBranch string `json:"default_branch"`
+}
+
+// fetchDefaultBranch returns the name of the default branch of the repo, it is empty for an empty repo
+func fetchDefaultBranch(data *GithubTaskData) (string, errors.Error) {
+	res, err := data.ApiClient.Get("repos/"+data.Options.Name, nil, nil)
+	if err != nil {
+		return "", err
+	}
+	repo := &githubApiRepoDefaultBranch{}
+	err = api.UnmarshalResponse(res, repo)
+	if err != nil {
+		return "", err
+	}
+	return repo.DefaultBranch, nil
+}
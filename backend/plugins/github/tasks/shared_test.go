@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIgnoreHTTPStatus403And404(t *testing.T) {
+	response := func(status int, headers map[string]string) *http.Response {
+		res := &http.Response{StatusCode: status, Header: http.Header{}}
+		for name, value := range headers {
+			res.Header.Set(name, value)
+		}
+		return res
+	}
+
+	assert.Nil(t, ignoreHTTPStatus403And404(response(http.StatusOK, nil)))
+	assert.Equal(t, api.ErrIgnoreAndContinue, ignoreHTTPStatus403And404(response(http.StatusNotFound, nil)))
+	assert.Equal(t, api.ErrIgnoreAndContinue, ignoreHTTPStatus403And404(response(http.StatusForbidden, map[string]string{
+		"X-RateLimit-Remaining": "4999",
+	})))
+	assert.Nil(t, ignoreHTTPStatus403And404(response(http.StatusForbidden, map[string]string{
+		"X-RateLimit-Remaining": "0",
+	})))
+	assert.Nil(t, ignoreHTTPStatus403And404(response(http.StatusForbidden, map[string]string{
+		"Retry-After": "60",
+	})))
+}
@@ -0,0 +1,58 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/security"
+)
+
+// vulnerabilityStatusChange is a status the alert entered, github only exposes the time of the
+// latest transition to each status, so the transitions are derived from these timestamps
+type vulnerabilityStatusChange struct {
+	originalStatus string
+	status         string
+	changedDate    *time.Time
+}
+
+// resolveVulnerability fills the resolved date and lead time of a fixed or dismissed vulnerability
+func resolveVulnerability(vulnerability *security.Vulnerability, resolvedDate *time.Time) {
+	if vulnerability.Status == security.OPEN || resolvedDate == nil {
+		return
+	}
+	vulnerability.ResolvedDate = resolvedDate
+	leadTimeMinutes := uint(resolvedDate.Sub(vulnerability.CreatedDate).Minutes())
+	vulnerability.LeadTimeMinutes = &leadTimeMinutes
+}
+
+func makeVulnerabilityStatusChanges(vulnerabilityId string, changes ...vulnerabilityStatusChange) []interface{} {
+	results := make([]interface{}, 0, len(changes))
+	for _, change := range changes {
+		if change.changedDate == nil {
+			continue
+		}
+		results = append(results, &security.VulnerabilityStatusChange{
+			VulnerabilityId: vulnerabilityId,
+			OriginalStatus:  change.originalStatus,
+			Status:          change.status,
+			ChangedDate:     *change.changedDate,
+		})
+	}
+	return results
+}
@@ -61,6 +61,14 @@ func TestParseLinkHeaderEmptyString(t *testing.T) {
 	assert.Equal(t, paginationInfo, pagingExpected)
 }
 
+func TestGetNextCursorFromLinkHeader(t *testing.T) {
+	link := `<https://api.github.com/repos/o/r/dependabot/alerts?per_page=100&before=Y3Vyc29yOnYyOpHOAAAAAQ%3D%3D>; rel="prev",
+  <https://api.github.com/repos/o/r/dependabot/alerts?per_page=100&after=Y3Vyc29yOnYyOpHOAAAAZA%3D%3D>; rel="next"`
+	assert.Equal(t, GetNextCursorFromLinkHeader(link), "Y3Vyc29yOnYyOpHOAAAAZA==")
+	assert.Equal(t, GetNextCursorFromLinkHeader(`<https://api.github.com/repos/o/r/dependabot/alerts?before=abc>; rel="prev"`), "")
+	assert.Equal(t, GetNextCursorFromLinkHeader(""), "")
+}
+
 // This test is incomplete.
 func TestGetRateLimitPerSecond(t *testing.T) {
 	date := "Mon, 20 Sep 2021 18:08:38 GMT"
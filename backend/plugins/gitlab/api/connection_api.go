@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -32,7 +33,8 @@ import (
 
 type GitlabTestConnResponse struct {
 	shared.ApiBody
-	Connection *models.GitlabConn
+	Connection     *models.GitlabConn
+	TokenExpiresAt *time.Time `json:"tokenExpiresAt,omitempty"`
 }
 
 func testConnection(ctx context.Context, connection models.GitlabConn) (*GitlabTestConnResponse, errors.Error) {
@@ -64,15 +66,79 @@ func testConnection(ctx context.Context, connection models.GitlabConn) (*GitlabT
 		return nil, errors.BadInput.New("token need api or read_api permissions scope")
 	}
 
+	// check token expiry
+	expiresAt, err := getTokenExpiresAt(apiClient)
+	if err != nil {
+		return nil, err
+	}
+
 	connection = connection.Sanitize()
 	body := GitlabTestConnResponse{}
 	body.Success = true
 	body.Message = "success"
 	body.Connection = &connection
+	body.TokenExpiresAt = expiresAt
+	warningDays := basicRes.GetConfigReader().GetInt("TOKEN_EXPIRY_WARNING_DAYS")
+	if (plugin.TokenExpiry{ExpiresAt: expiresAt}).ExpiresWithin(time.Now(), warningDays) {
+		body.Message = fmt.Sprintf("success, but the token expires on %s", expiresAt.Format("2006-01-02"))
+	}
 
 	return &body, nil
 }
 
+// getTokenExpiresAt returns when the token expires, nil if it never expires or the gitlab instance
+// is not able to tell (versions before 15.5, OAuth tokens)
+func getTokenExpiresAt(apiClient *api.ApiClient) (*time.Time, errors.Error) {
+	res, err := apiClient.Get("personal_access_tokens/self", nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		res.Body.Close()
+		return nil, nil
+	}
+	token := &models.ApiTokenResponse{}
+	err = api.UnmarshalResponse(res, token)
+	if err != nil {
+		return nil, err
+	}
+	if token.ExpiresAt == "" {
+		return nil, nil
+	}
+	expiresAt, e := time.Parse("2006-01-02", token.ExpiresAt)
+	if e != nil {
+		return nil, errors.Convert(e)
+	}
+	return &expiresAt, nil
+}
+
+// GetTokenExpiries returns when the tokens of all gitlab connections expire
+func GetTokenExpiries() ([]plugin.TokenExpiry, errors.Error) {
+	connections, err := dsHelper.ConnSrv.GetAll()
+	if err != nil {
+		return nil, err
+	}
+	expiries := make([]plugin.TokenExpiry, 0, len(connections))
+	for _, connection := range connections {
+		apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+		if err != nil {
+			basicRes.GetLogger().Warn(err, "failed to connect to gitlab connection %d", connection.ID)
+			continue
+		}
+		expiresAt, err := getTokenExpiresAt(apiClient)
+		if err != nil {
+			basicRes.GetLogger().Warn(err, "failed to get token expiry of gitlab connection %d", connection.ID)
+			continue
+		}
+		expiries = append(expiries, plugin.TokenExpiry{
+			ConnectionId:   connection.ID,
+			ConnectionName: connection.Name,
+			ExpiresAt:      expiresAt,
+		})
+	}
+	return expiries, nil
+}
+
 // TestConnection test gitlab connection
 // @Summary test gitlab connection
 // @Description Test gitlab Connection
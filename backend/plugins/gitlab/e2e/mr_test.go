@@ -20,6 +20,7 @@ package e2e
 import (
 	"testing"
 
+	coreModels "github.com/apache/incubator-devlake/core/models"
 	"github.com/apache/incubator-devlake/core/models/common"
 	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
 	"github.com/apache/incubator-devlake/helpers/e2ehelper"
@@ -59,6 +60,7 @@ func TestGitlabMrDataFlow(t *testing.T) {
 
 	// verify conversion
 	dataflowTester.FlushTabler(&code.PullRequest{})
+	dataflowTester.FlushTabler(&coreModels.ConverterRowHash{})
 	dataflowTester.Subtask(tasks.ConvertApiMergeRequestsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(&code.PullRequest{}, e2ehelper.TableOptions{
 		CSVRelPath:  "./snapshot_tables/pull_requests.csv",
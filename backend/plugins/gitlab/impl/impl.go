@@ -44,6 +44,7 @@ var _ interface {
 	plugin.PluginSource
 	plugin.DataSourcePluginBlueprintV200
 	plugin.CloseablePluginTask
+	plugin.PluginTokenExpiry
 } = (*Gitlab)(nil)
 
 type Gitlab struct{}
@@ -102,6 +103,8 @@ func (p Gitlab) GetTablesInfo() []dal.Tabler {
 		&models.GitlabIssueAssignee{},
 		&models.GitlabScopeConfig{},
 		&models.GitlabDeployment{},
+		&models.GitlabProtectedBranch{},
+		&models.GitlabPushRule{},
 	}
 }
 
@@ -247,7 +250,9 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
-			"GET": api.GetScopeLatestSyncState,
+			"GET":    api.GetScopeLatestSyncState,
+			"PATCH":  api.PatchScopeLatestSyncState,
+			"DELETE": api.DeleteScopeLatestSyncState,
 		},
 		"connections/:connectionId/remote-scopes": {
 			"GET": api.RemoteScopes,
@@ -274,6 +279,10 @@ func (p Gitlab) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 	}
 }
 
+func (p Gitlab) GetTokenExpiries() ([]plugin.TokenExpiry, errors.Error) {
+	return api.GetTokenExpiries()
+}
+
 func (p Gitlab) Close(taskCtx plugin.TaskContext) errors.Error {
 	data, ok := taskCtx.GetData().(*tasks.GitlabTaskData)
 	if !ok {
@@ -143,6 +143,14 @@ type ApiUserResponse struct {
 	Name string `json:"name"`
 }
 
+// ApiTokenResponse is the response of personal_access_tokens/self
+type ApiTokenResponse struct {
+	Id        int      `json:"id"`
+	Name      string   `json:"name"`
+	Scopes    []string `json:"scopes"`
+	ExpiresAt string   `json:"expires_at"`
+}
+
 func (GitlabConnection) TableName() string {
 	return "_tool_gitlab_connections"
 }
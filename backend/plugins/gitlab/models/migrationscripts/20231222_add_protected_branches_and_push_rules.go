@@ -0,0 +1,81 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+type gitlabProtectedBranch20231222 struct {
+	ConnectionId              uint64 `gorm:"primaryKey"`
+	ProjectId                 int    `gorm:"primaryKey;autoIncrement:false"`
+	ProtectedBranchId         int    `gorm:"primaryKey;autoIncrement:false"`
+	Name                      string `gorm:"type:varchar(255)"`
+	PushAccessLevels          string `gorm:"type:varchar(255)"`
+	MergeAccessLevels         string `gorm:"type:varchar(255)"`
+	NoOneCanPush              bool
+	AllowForcePush            bool
+	CodeOwnerApprovalRequired bool
+	archived.NoPKModel
+}
+
+func (gitlabProtectedBranch20231222) TableName() string {
+	return "_tool_gitlab_protected_branches"
+}
+
+type gitlabPushRule20231222 struct {
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	ProjectId             int    `gorm:"primaryKey;autoIncrement:false"`
+	PushRuleId            int
+	CommitMessageRegex    string `gorm:"type:varchar(255)"`
+	BranchNameRegex       string `gorm:"type:varchar(255)"`
+	AuthorEmailRegex      string `gorm:"type:varchar(255)"`
+	FileNameRegex         string `gorm:"type:varchar(255)"`
+	MaxFileSize           int
+	DenyDeleteTag         bool
+	MemberCheck           bool
+	PreventSecrets        bool
+	CommitCommitterCheck  bool
+	RejectUnsignedCommits bool
+	archived.NoPKModel
+}
+
+func (gitlabPushRule20231222) TableName() string {
+	return "_tool_gitlab_push_rules"
+}
+
+type addProtectedBranchesAndPushRules struct{}
+
+func (*addProtectedBranchesAndPushRules) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&gitlabProtectedBranch20231222{},
+		&gitlabPushRule20231222{},
+	)
+}
+
+func (*addProtectedBranchesAndPushRules) Version() uint64 {
+	return 20231222000001
+}
+
+func (*addProtectedBranchesAndPushRules) Name() string {
+	return "add _tool_gitlab_protected_branches and _tool_gitlab_push_rules"
+}
@@ -45,5 +45,6 @@ func All() []plugin.MigrationScript {
 		new(addQueuedDuration20231129),
 		new(modifyDeploymentMessageType),
 		new(addTimeToGitlabPipelineProject),
+		new(addProtectedBranchesAndPushRules),
 	}
 }
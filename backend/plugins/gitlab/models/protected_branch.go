@@ -0,0 +1,60 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+type GitlabProtectedBranch struct {
+	ConnectionId              uint64 `gorm:"primaryKey"`
+	ProjectId                 int    `gorm:"primaryKey;autoIncrement:false"`
+	ProtectedBranchId         int    `gorm:"primaryKey;autoIncrement:false"`
+	Name                      string `gorm:"type:varchar(255)"`
+	PushAccessLevels          string `gorm:"type:varchar(255)"`
+	MergeAccessLevels         string `gorm:"type:varchar(255)"`
+	NoOneCanPush              bool
+	AllowForcePush            bool
+	CodeOwnerApprovalRequired bool
+	common.NoPKModel
+}
+
+func (GitlabProtectedBranch) TableName() string {
+	return "_tool_gitlab_protected_branches"
+}
+
+type GitlabPushRule struct {
+	ConnectionId          uint64 `gorm:"primaryKey"`
+	ProjectId             int    `gorm:"primaryKey;autoIncrement:false"`
+	PushRuleId            int
+	CommitMessageRegex    string `gorm:"type:varchar(255)"`
+	BranchNameRegex       string `gorm:"type:varchar(255)"`
+	AuthorEmailRegex      string `gorm:"type:varchar(255)"`
+	FileNameRegex         string `gorm:"type:varchar(255)"`
+	MaxFileSize           int
+	DenyDeleteTag         bool
+	MemberCheck           bool
+	PreventSecrets        bool
+	CommitCommitterCheck  bool
+	RejectUnsignedCommits bool
+	common.NoPKModel
+}
+
+func (GitlabPushRule) TableName() string {
+	return "_tool_gitlab_push_rules"
+}
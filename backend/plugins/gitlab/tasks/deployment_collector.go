@@ -19,7 +19,6 @@ package tasks
 
 import (
 	"net/url"
-	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
@@ -70,16 +69,11 @@ func CollectDeployment(taskCtx plugin.SubTaskContext) errors.Error {
 			} else {
 				query.Set("order_by", "created_at")
 			}
-			if collectorWithState.Since != nil {
-				query.Set("updated_after", collectorWithState.Since.Format(time.RFC3339))
-			}
-			if collectorWithState.Before != nil {
-				query.Set("updated_before", collectorWithState.Before.Format(time.RFC3339))
-			}
 			return query, nil
 		},
-		GetTotalPages:  GetTotalPagesFromResponse,
-		ResponseParser: GetRawMessageFromResponse,
+		TimeWindowAdapter: GitlabTimeWindowAdapter,
+		GetTotalPages:     GetTotalPagesFromResponse,
+		ResponseParser:    GetRawMessageFromResponse,
 	})
 	if err != nil {
 		return err
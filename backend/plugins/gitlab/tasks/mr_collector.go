@@ -18,9 +18,6 @@ limitations under the License.
 package tasks
 
 import (
-	"net/url"
-	"time"
-
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
@@ -49,21 +46,13 @@ func CollectApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 	}
 
 	err = collectorWithState.InitCollector(helper.ApiCollectorArgs{
-		ApiClient:      data.ApiClient,
-		PageSize:       100,
-		UrlTemplate:    "projects/{{ .Params.ProjectId }}/merge_requests",
-		GetTotalPages:  GetTotalPagesFromResponse,
-		ResponseParser: GetRawMessageFromResponse,
-		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
-			query, err := GetQuery(reqData)
-			if err != nil {
-				return nil, err
-			}
-			if collectorWithState.Since != nil {
-				query.Set("updated_after", collectorWithState.Since.Format(time.RFC3339))
-			}
-			return query, nil
-		},
+		ApiClient:         data.ApiClient,
+		PageSize:          100,
+		UrlTemplate:       "projects/{{ .Params.ProjectId }}/merge_requests",
+		GetTotalPages:     GetTotalPagesFromResponse,
+		ResponseParser:    GetRawMessageFromResponse,
+		Query:             GetQuery,
+		TimeWindowAdapter: GitlabTimeWindowAdapter,
 	})
 
 	if err != nil {
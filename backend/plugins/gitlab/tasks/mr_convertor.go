@@ -65,6 +65,7 @@ func ConvertApiMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
 		RawDataSubTaskArgs: *rawDataSubTaskArgs,
 		InputRowType:       reflect.TypeOf(models.GitlabMergeRequest{}),
 		Input:              cursor,
+		DetectChanges:      true,
 
 		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
 			gitlabMr := inputRow.(*models.GitlabMergeRequest)
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ReconcileMergeRequestsMeta)
+}
+
+var ReconcileMergeRequestsMeta = plugin.SubTaskMeta{
+	Name:             "reconcileMergeRequests",
+	EntryPoint:       ReconcileMergeRequests,
+	EnabledByDefault: false,
+	Description:      "Mark domain layer PullRequest as deleted when the merge request no longer exists in gitlab",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_REVIEW},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectApiMergeRequestIdsMeta},
+}
+
+func ReconcileMergeRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	idsArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_MERGE_REQUEST_ID_TABLE)
+	idsRawData, err := helper.NewRawDataSubTask(*idsArgs)
+	if err != nil {
+		return err
+	}
+	cursor, err := db.Cursor(
+		dal.From(idsRawData.GetTable()),
+		dal.Where("params = ?", idsRawData.GetParams()),
+	)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	domainMrIdGenerator := didgen.NewDomainIdGenerator(&models.GitlabMergeRequest{})
+	var aliveIds []string
+	for cursor.Next() {
+		row := &helper.RawData{}
+		err = db.Fetch(cursor, row)
+		if err != nil {
+			return err
+		}
+		mr := &struct {
+			GitlabId int `json:"id"`
+		}{}
+		err = errors.Convert(json.Unmarshal(row.Data, mr))
+		if err != nil {
+			return err
+		}
+		aliveIds = append(aliveIds, domainMrIdGenerator.Generate(data.Options.ConnectionId, mr.GitlabId))
+	}
+
+	// domain pull requests were converted from the merge requests raw data
+	rawDataSubTaskArgs, _ := CreateRawDataSubTaskArgs(taskCtx, RAW_MERGE_REQUEST_TABLE)
+	reconciler, err := helper.NewDataReconciler(helper.DataReconcilerArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		DomainTable:        &code.PullRequest{},
+		AliveIds:           aliveIds,
+	})
+	if err != nil {
+		return err
+	}
+
+	return reconciler.Execute()
+}
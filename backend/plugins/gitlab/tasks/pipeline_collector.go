@@ -18,8 +18,6 @@ limitations under the License.
 package tasks
 
 import (
-	"fmt"
-	"net/url"
 	"time"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -60,19 +58,10 @@ func CollectApiPipelines(taskCtx plugin.SubTaskContext) errors.Error {
 		MinTickInterval:    &tickInterval,
 		PageSize:           100,
 		UrlTemplate:        "projects/{{ .Params.ProjectId }}/pipelines",
-		Query: func(reqData *helper.RequestData) (url.Values, errors.Error) {
-			query := url.Values{}
-			if collectorWithState.Since != nil {
-				query.Set("updated_after", collectorWithState.Since.Format(time.RFC3339))
-			}
-			query.Set("with_stats", "true")
-			query.Set("sort", "asc")
-			query.Set("page", fmt.Sprintf("%v", reqData.Pager.Page))
-			query.Set("per_page", fmt.Sprintf("%v", reqData.Pager.Size))
-			return query, nil
-		},
-		ResponseParser: GetRawMessageFromResponse,
-		AfterResponse:  ignoreHTTPStatus403, // ignore 403 for CI/CD disable
+		Query:              GetQuery,
+		TimeWindowAdapter:  GitlabTimeWindowAdapter,
+		ResponseParser:     GetRawMessageFromResponse,
+		AfterResponse:      ignoreHTTPStatus403, // ignore 403 for CI/CD disable
 	})
 	if err != nil {
 		return err
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"fmt"
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ConvertProtectedBranchesMeta)
+}
+
+var ConvertProtectedBranchesMeta = plugin.SubTaskMeta{
+	Name:             "convertProtectedBranches",
+	EntryPoint:       ConvertProtectedBranches,
+	EnabledByDefault: false,
+	Description:      "Convert tool layer table _tool_gitlab_protected_branches and _tool_gitlab_push_rules into domain layer table branch_protections",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractPushRuleMeta},
+}
+
+type ProtectedBranchConverterModel struct {
+	models.GitlabProtectedBranch
+	DefaultBranch         string
+	RejectUnsignedCommits bool
+}
+
+func ConvertProtectedBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROTECTED_BRANCH_TABLE)
+	db := taskCtx.GetDal()
+	clauses := []dal.Clause{
+		dal.Select("pb.*, p.default_branch, COALESCE(pr.reject_unsigned_commits, false) AS reject_unsigned_commits"),
+		dal.From("_tool_gitlab_protected_branches pb"),
+		dal.Join(`left join _tool_gitlab_projects p on
+			p.connection_id = pb.connection_id and p.gitlab_id = pb.project_id`),
+		dal.Join(`left join _tool_gitlab_push_rules pr on
+			pr.connection_id = pb.connection_id and pr.project_id = pb.project_id`),
+		dal.Where("pb.project_id = ? and pb.connection_id = ?", data.Options.ProjectId, data.Options.ConnectionId),
+	}
+
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+
+	repoId := didgen.NewDomainIdGenerator(&models.GitlabProject{}).Generate(data.Options.ConnectionId, data.Options.ProjectId)
+
+	converter, err := helper.NewDataConverter(helper.DataConverterArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		InputRowType:       reflect.TypeOf(ProtectedBranchConverterModel{}),
+		Input:              cursor,
+
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			protectedBranch := inputRow.(*ProtectedBranchConverterModel)
+			domainProtection := &code.BranchProtection{
+				DomainEntity:           domainlayer.DomainEntity{Id: fmt.Sprintf("%s:%s", repoId, protectedBranch.Name)},
+				RepoId:                 repoId,
+				BranchPattern:          protectedBranch.Name,
+				IsDefaultBranch:        protectedBranch.Name == protectedBranch.DefaultBranch,
+				RequirePullRequest:     protectedBranch.NoOneCanPush,
+				RequireCodeOwnerReview: protectedBranch.CodeOwnerApprovalRequired,
+				RequireSignedCommits:   protectedBranch.RejectUnsignedCommits,
+				AllowForcePushes:       protectedBranch.AllowForcePush,
+			}
+			return []interface{}{
+				domainProtection,
+			}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
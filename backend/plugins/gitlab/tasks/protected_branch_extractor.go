@@ -0,0 +1,115 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractProtectedBranchesMeta)
+}
+
+type GitlabApiAccessLevel struct {
+	AccessLevel            int    `json:"access_level"`
+	AccessLevelDescription string `json:"access_level_description"`
+	UserId                 *int   `json:"user_id"`
+	GroupId                *int   `json:"group_id"`
+	DeployKeyId            *int   `json:"deploy_key_id"`
+}
+
+type GitlabApiProtectedBranch struct {
+	Id                        int                    `json:"id"`
+	Name                      string                 `json:"name"`
+	PushAccessLevels          []GitlabApiAccessLevel `json:"push_access_levels"`
+	MergeAccessLevels         []GitlabApiAccessLevel `json:"merge_access_levels"`
+	AllowForcePush            bool                   `json:"allow_force_push"`
+	CodeOwnerApprovalRequired bool                   `json:"code_owner_approval_required"`
+}
+
+var ExtractProtectedBranchesMeta = plugin.SubTaskMeta{
+	Name:             "extractApiProtectedBranches",
+	EntryPoint:       ExtractApiProtectedBranches,
+	EnabledByDefault: false,
+	Description:      "Extract raw protected branch data into tool layer table _tool_gitlab_protected_branches",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectProtectedBranchesMeta},
+}
+
+func ExtractApiProtectedBranches(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PROTECTED_BRANCH_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiProtectedBranch := &GitlabApiProtectedBranch{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiProtectedBranch))
+			if err != nil {
+				return nil, err
+			}
+			protectedBranch := convertProtectedBranch(apiProtectedBranch)
+			protectedBranch.ConnectionId = data.Options.ConnectionId
+			protectedBranch.ProjectId = data.Options.ProjectId
+			return []interface{}{protectedBranch}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+// Convert the API response to our DB model instance
+func convertProtectedBranch(branch *GitlabApiProtectedBranch) *models.GitlabProtectedBranch {
+	return &models.GitlabProtectedBranch{
+		ProtectedBranchId:         branch.Id,
+		Name:                      branch.Name,
+		PushAccessLevels:          joinAccessLevels(branch.PushAccessLevels),
+		MergeAccessLevels:         joinAccessLevels(branch.MergeAccessLevels),
+		NoOneCanPush:              noOneAllowed(branch.PushAccessLevels),
+		AllowForcePush:            branch.AllowForcePush,
+		CodeOwnerApprovalRequired: branch.CodeOwnerApprovalRequired,
+	}
+}
+
+func joinAccessLevels(levels []GitlabApiAccessLevel) string {
+	descriptions := make([]string, 0, len(levels))
+	for _, level := range levels {
+		descriptions = append(descriptions, level.AccessLevelDescription)
+	}
+	return strings.Join(descriptions, ",")
+}
+
+// noOneAllowed returns true if none of the roles, users, groups or deploy keys is granted the access,
+// which means changes can only land on the branch via merge requests when applied to push access
+func noOneAllowed(levels []GitlabApiAccessLevel) bool {
+	for _, level := range levels {
+		if level.AccessLevel > 0 || level.UserId != nil || level.GroupId != nil || level.DeployKeyId != nil {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,54 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestConvertProtectedBranch(t *testing.T) {
+	userId := 1
+	branch := convertProtectedBranch(&GitlabApiProtectedBranch{
+		Id:   10,
+		Name: "main",
+		PushAccessLevels: []GitlabApiAccessLevel{
+			{AccessLevel: 0, AccessLevelDescription: "No one"},
+		},
+		MergeAccessLevels: []GitlabApiAccessLevel{
+			{AccessLevel: 40, AccessLevelDescription: "Maintainers"},
+			{AccessLevel: 30, AccessLevelDescription: "Developers + Maintainers"},
+		},
+		CodeOwnerApprovalRequired: true,
+	})
+	assert.Equal(t, 10, branch.ProtectedBranchId)
+	assert.Equal(t, "No one", branch.PushAccessLevels)
+	assert.Equal(t, "Maintainers,Developers + Maintainers", branch.MergeAccessLevels)
+	assert.True(t, branch.NoOneCanPush)
+	assert.True(t, branch.CodeOwnerApprovalRequired)
+
+	branch = convertProtectedBranch(&GitlabApiProtectedBranch{
+		Name: "release/*",
+		PushAccessLevels: []GitlabApiAccessLevel{
+			{AccessLevel: 0, AccessLevelDescription: "No one"},
+			{AccessLevelDescription: "John", UserId: &userId},
+		},
+	})
+	assert.False(t, branch.NoOneCanPush)
+}
@@ -0,0 +1,78 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+func init() {
+	RegisterSubtaskMeta(&CollectPushRuleMeta)
+}
+
+const RAW_PUSH_RULE_TABLE = "gitlab_api_push_rules"
+
+var CollectPushRuleMeta = plugin.SubTaskMeta{
+	Name:             "collectApiPushRule",
+	EntryPoint:       CollectApiPushRule,
+	EnabledByDefault: false,
+	Description:      "Collect push rule data from gitlab api, does not support either timeFilter or diffSync.",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&ExtractProtectedBranchesMeta},
+}
+
+func CollectApiPushRule(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PUSH_RULE_TABLE)
+
+	collector, err := helper.NewApiCollector(helper.ApiCollectorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		ApiClient:          data.ApiClient,
+		Incremental:        false,
+		UrlTemplate:        "projects/{{ .Params.ProjectId }}/push_rule",
+		ResponseParser:     getPushRuleFromResponse,
+		// push rules are only available on GitLab Premium
+		AfterResponse: ignoreHTTPStatus403And404,
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return collector.Execute()
+}
+
+// getPushRuleFromResponse returns nothing when the project has no push rule, in which case Gitlab responds with `null`
+func getPushRuleFromResponse(res *http.Response) ([]json.RawMessage, errors.Error) {
+	body, err := io.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, errors.Convert(err)
+	}
+	body = bytes.TrimSpace(body)
+	if len(body) == 0 || bytes.Equal(body, []byte("null")) {
+		return nil, nil
+	}
+	return []json.RawMessage{body}, nil
+}
@@ -0,0 +1,91 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/gitlab/models"
+)
+
+func init() {
+	RegisterSubtaskMeta(&ExtractPushRuleMeta)
+}
+
+type GitlabApiPushRule struct {
+	Id                    int    `json:"id"`
+	CommitMessageRegex    string `json:"commit_message_regex"`
+	BranchNameRegex       string `json:"branch_name_regex"`
+	AuthorEmailRegex      string `json:"author_email_regex"`
+	FileNameRegex         string `json:"file_name_regex"`
+	MaxFileSize           int    `json:"max_file_size"`
+	DenyDeleteTag         bool   `json:"deny_delete_tag"`
+	MemberCheck           bool   `json:"member_check"`
+	PreventSecrets        bool   `json:"prevent_secrets"`
+	CommitCommitterCheck  bool   `json:"commit_committer_check"`
+	RejectUnsignedCommits bool   `json:"reject_unsigned_commits"`
+}
+
+var ExtractPushRuleMeta = plugin.SubTaskMeta{
+	Name:             "extractApiPushRule",
+	EntryPoint:       ExtractApiPushRule,
+	EnabledByDefault: false,
+	Description:      "Extract raw push rule data into tool layer table _tool_gitlab_push_rules",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE},
+	Dependencies:     []*plugin.SubTaskMeta{&CollectPushRuleMeta},
+}
+
+func ExtractApiPushRule(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PUSH_RULE_TABLE)
+
+	extractor, err := api.NewApiExtractor(api.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(row *api.RawData) ([]interface{}, errors.Error) {
+			apiPushRule := &GitlabApiPushRule{}
+			err := errors.Convert(json.Unmarshal(row.Data, apiPushRule))
+			if err != nil {
+				return nil, err
+			}
+			pushRule := &models.GitlabPushRule{
+				ConnectionId:          data.Options.ConnectionId,
+				ProjectId:             data.Options.ProjectId,
+				PushRuleId:            apiPushRule.Id,
+				CommitMessageRegex:    apiPushRule.CommitMessageRegex,
+				BranchNameRegex:       apiPushRule.BranchNameRegex,
+				AuthorEmailRegex:      apiPushRule.AuthorEmailRegex,
+				FileNameRegex:         apiPushRule.FileNameRegex,
+				MaxFileSize:           apiPushRule.MaxFileSize,
+				DenyDeleteTag:         apiPushRule.DenyDeleteTag,
+				MemberCheck:           apiPushRule.MemberCheck,
+				PreventSecrets:        apiPushRule.PreventSecrets,
+				CommitCommitterCheck:  apiPushRule.CommitCommitterCheck,
+				RejectUnsignedCommits: apiPushRule.RejectUnsignedCommits,
+			}
+			return []interface{}{pushRule}, nil
+		},
+	})
+
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
@@ -146,6 +146,9 @@ func GetRawMessageUpdatedAtAfter(timeAfter *time.Time) func(res *http.Response)
 	}
 }
 
+// GitlabTimeWindowAdapter maps the collector time window to the `updated_after` and `updated_before` params
+var GitlabTimeWindowAdapter = helper.NewTimeWindowAdapter("updated_after", "updated_before", time.RFC3339)
+
 func GetQuery(reqData *helper.RequestData) (url.Values, errors.Error) {
 	query := url.Values{}
 	query.Set("with_stats", "true")
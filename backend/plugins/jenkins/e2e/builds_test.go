@@ -47,6 +47,7 @@ func TestJenkinsBuildsDataFlow(t *testing.T) {
 
 	dataflowTester.FlushTabler(&models.JenkinsBuild{})
 	dataflowTester.FlushTabler(&models.JenkinsBuildCommit{})
+	dataflowTester.FlushTabler(&models.JenkinsJobDag{})
 	dataflowTester.FlushTabler(&models.JenkinsStage{})
 
 	// import raw data table
@@ -97,6 +97,7 @@ func (p Jenkins) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractApiStagesMeta,
 		tasks.EnrichApiBuildWithStagesMeta,
 		tasks.ConvertBuildsToCicdTasksMeta,
+		tasks.ConvertBuildRelationshipsMeta,
 		tasks.ConvertStagesMeta,
 		tasks.ConvertBuildReposMeta,
 	}
@@ -194,9 +195,10 @@ func (p Jenkins) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 			// GetScopeLatestSyncState "connections/:connectionId/scopes/:scopeId/latest-sync-state"
 			// GetScope "connections/:connectionId/scopes/:scopeId"
 			// Because there may be slash in scopeId, so we handle it manually.
+			// PATCH and DELETE of "connections/:connectionId/scopes/:scopeId/latest-sync-state" are dispatched the same way.
 			"GET":    api.GetScopeDispatcher,
-			"PATCH":  api.PatchScope,
-			"DELETE": api.DeleteScope,
+			"PATCH":  api.PatchScopeDispatcher,
+			"DELETE": api.DeleteScopeDispatcher,
 		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopeList,
@@ -39,6 +39,7 @@ type JenkinsBuild struct {
 	Type              string    `gorm:"index;type:varchar(255)"`
 	Class             string    `gorm:"index;type:varchar(255)" `
 	TriggeredBy       string    `gorm:"type:varchar(255)"`
+	UpstreamBuild     string    `gorm:"index;type:varchar(255)"` // full name of the build which triggered this one
 	Building          bool
 	HasStages         bool
 }
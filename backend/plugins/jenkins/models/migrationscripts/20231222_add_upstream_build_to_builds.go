@@ -0,0 +1,52 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addUpstreamBuildToBuilds)(nil)
+
+type build20231222 struct {
+	UpstreamBuild string `gorm:"index;type:varchar(255)"`
+}
+
+func (build20231222) TableName() string {
+	return "_tool_jenkins_builds"
+}
+
+type addUpstreamBuildToBuilds struct{}
+
+func (*addUpstreamBuildToBuilds) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&build20231222{},
+	)
+}
+
+func (*addUpstreamBuildToBuilds) Version() uint64 {
+	return 20231222000001
+}
+
+func (*addUpstreamBuildToBuilds) Name() string {
+	return "add upstream_build to _tool_jenkins_builds"
+}
@@ -0,0 +1,156 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+const blueOceanTimeLayout = "2006-01-02T15:04:05.000-0700"
+const multiBranchProjectClass = "org.jenkinsci.plugins.workflow.multibranch.WorkflowMultiBranchProject"
+
+// the classic classes of the runs, so the stages of the pipelines would still be collected
+var blueOceanRunClasses = map[string]string{
+	"io.jenkins.blueocean.rest.impl.pipeline.PipelineRunImpl":     "org.jenkinsci.plugins.workflow.job.WorkflowRun",
+	"io.jenkins.blueocean.service.embedded.rest.FreeStyleRunImpl": "hudson.model.FreeStyleBuild",
+}
+
+type blueOceanRun struct {
+	Class                     string         `json:"_class"`
+	Id                        string         `json:"id"`
+	Result                    string         `json:"result"`
+	State                     string         `json:"state"`
+	StartTime                 string         `json:"startTime"`
+	DurationInMillis          int64          `json:"durationInMillis"`
+	EstimatedDurationInMillis int64          `json:"estimatedDurationInMillis"`
+	CommitId                  string         `json:"commitId"`
+	Causes                    []models.Cause `json:"causes"`
+}
+
+// blueOceanPipelinePath returns the path of the job in the Blue Ocean REST API, the branches of
+// a multibranch project are listed under `branches` instead of `pipelines`
+func blueOceanPipelinePath(jobFullName string, multiBranch bool) string {
+	names := strings.Split(jobFullName, "/")
+	path := "blue/rest/organizations/jenkins"
+	for i, name := range names {
+		if i == len(names)-1 && multiBranch {
+			// branch names are already encoded by jenkins, i.e. feature%2Fx
+			path += "/branches/" + url.PathEscape(name)
+		} else {
+			path += "/pipelines/" + url.PathEscape(name)
+		}
+	}
+	return path
+}
+
+// getBlueOceanRunsUrl returns the url listing the runs of the job if it is a branch of a multibranch project,
+// the url is empty for the other jobs which keep using the classic api
+func getBlueOceanRunsUrl(apiClient *helper.ApiAsyncClient, op *JenkinsOptions) string {
+	if !strings.Contains(op.JobFullName, "/") {
+		return ""
+	}
+	var parent struct {
+		Class string `json:"_class"`
+	}
+	res, err := apiClient.Get(op.JobPath+"/api/json", url.Values{"tree": []string{"_class"}}, nil)
+	if err != nil || helper.UnmarshalResponse(res, &parent) != nil || parent.Class != multiBranchProjectClass {
+		return ""
+	}
+	return blueOceanPipelinePath(op.JobFullName, true) + "/runs/"
+}
+
+// getRemoteUrls returns the scm urls of the last build, Blue Ocean runs carry the commit only. All the
+// branches of a multibranch project build the same scm source, so the urls apply to every run
+func getRemoteUrls(apiClient *helper.ApiAsyncClient, op *JenkinsOptions) ([]string, errors.Error) {
+	var lastBuild struct {
+		Actions []models.Action `json:"actions"`
+	}
+	query := url.Values{"tree": []string{"actions[remoteUrls]"}}
+	res, err := apiClient.Get(fmt.Sprintf("%sjob/%s/lastBuild/api/json", op.JobPath, op.JobName), query, nil)
+	if err != nil {
+		// a job without any build
+		return nil, nil
+	}
+	err = helper.UnmarshalResponse(res, &lastBuild)
+	if err != nil {
+		return nil, err
+	}
+	for _, a := range lastBuild.Actions {
+		if len(a.RemoteUrls) > 0 {
+			return a.RemoteUrls, nil
+		}
+	}
+	return nil, nil
+}
+
+// convertBlueOceanRun converts the finished Blue Ocean run of a branch of a multibranch project into
+// the response of the classic api, so the raw data are extracted the same way, nil is returned for the
+// runs still running
+func convertBlueOceanRun(raw json.RawMessage, jobFullName string, branch string, remoteUrls []string) (json.RawMessage, errors.Error) {
+	run := &blueOceanRun{}
+	err := errors.Convert(json.Unmarshal(raw, run))
+	if err != nil {
+		return nil, err
+	}
+	if run.State != "FINISHED" {
+		return nil, nil
+	}
+	number, e := strconv.ParseInt(run.Id, 10, 64)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, fmt.Sprintf("invalid blue ocean run id %s", run.Id))
+	}
+	startTime, e := time.Parse(blueOceanTimeLayout, run.StartTime)
+	if e != nil {
+		return nil, errors.BadInput.Wrap(e, fmt.Sprintf("invalid blue ocean run start time %s", run.StartTime))
+	}
+	class := blueOceanRunClasses[run.Class]
+	if class == "" {
+		class = run.Class
+	}
+	build := &models.ApiBuildResponse{
+		Class:             class,
+		Number:            number,
+		Result:            run.Result,
+		Duration:          float64(run.DurationInMillis),
+		Timestamp:         startTime.UnixMilli(),
+		DisplayName:       fmt.Sprintf("%s #%d", jobFullName, number),
+		EstimatedDuration: float64(run.EstimatedDurationInMillis),
+	}
+	if run.CommitId != "" {
+		build.Actions = append(build.Actions, models.Action{
+			LastBuiltRevision: &models.LastBuiltRevision{
+				SHA1:     run.CommitId,
+				Branches: []models.Branch{{Name: branch}},
+			},
+			RemoteUrls: remoteUrls,
+		})
+	}
+	if len(run.Causes) > 0 {
+		build.Actions = append(build.Actions, models.Action{Causes: run.Causes})
+	}
+	return errors.Convert01(json.Marshal(build))
+}
@@ -0,0 +1,68 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBlueOceanPipelinePath(t *testing.T) {
+	assert.Equal(t, "blue/rest/organizations/jenkins/pipelines/build", blueOceanPipelinePath("build", false))
+	assert.Equal(t,
+		"blue/rest/organizations/jenkins/pipelines/folder/pipelines/my%20job",
+		blueOceanPipelinePath("folder/my job", false),
+	)
+	assert.Equal(t,
+		"blue/rest/organizations/jenkins/pipelines/folder/pipelines/app/branches/feature%252Fx",
+		blueOceanPipelinePath("folder/app/feature%2Fx", true),
+	)
+}
+
+func TestConvertBlueOceanRun(t *testing.T) {
+	running, err := convertBlueOceanRun(json.RawMessage(`{"id":"8","state":"RUNNING"}`), "app/main", "main", nil)
+	assert.Nil(t, err)
+	assert.Nil(t, running)
+
+	raw, err := convertBlueOceanRun(json.RawMessage(`{
+		"_class": "io.jenkins.blueocean.rest.impl.pipeline.PipelineRunImpl",
+		"id": "7",
+		"result": "SUCCESS",
+		"state": "FINISHED",
+		"startTime": "2023-12-20T10:00:00.000+0000",
+		"durationInMillis": 1500,
+		"estimatedDurationInMillis": 2000,
+		"commitId": "abc",
+		"causes": [{"shortDescription": "Branch indexing"}]
+	}`), "app/main", "main", []string{"https://github.com/apache/incubator-devlake.git"})
+	assert.Nil(t, err)
+	build := &models.ApiBuildResponse{}
+	assert.Nil(t, json.Unmarshal(raw, build))
+	assert.Equal(t, "org.jenkinsci.plugins.workflow.job.WorkflowRun", build.Class)
+	assert.Equal(t, int64(7), build.Number)
+	assert.Equal(t, "SUCCESS", build.Result)
+	assert.Equal(t, float64(1500), build.Duration)
+	assert.Equal(t, int64(1703066400000), build.Timestamp)
+	assert.Equal(t, "abc", build.Actions[0].LastBuiltRevision.SHA1)
+	assert.Equal(t, "main", build.Actions[0].LastBuiltRevision.Branches[0].Name)
+	assert.Equal(t, []string{"https://github.com/apache/incubator-devlake.git"}, build.Actions[0].RemoteUrls)
+	assert.Equal(t, "Branch indexing", build.Actions[1].Causes[0].ShortDescription)
+}
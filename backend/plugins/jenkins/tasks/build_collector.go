@@ -31,6 +31,9 @@ import (
 
 const RAW_BUILD_TABLE = "jenkins_api_builds"
 
+// JENKINS_USE_BLUE_OCEAN enables the Blue Ocean REST API to list the runs of the branches of multibranch projects
+const JENKINS_USE_BLUE_OCEAN = "JENKINS_USE_BLUE_OCEAN"
+
 var CollectApiBuildsMeta = plugin.SubTaskMeta{
 	Name:             "collectApiBuilds",
 	EntryPoint:       CollectApiBuilds,
@@ -51,6 +54,77 @@ type SimpleJenkinsApiBuild struct {
 
 func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 	data := taskCtx.GetData().(*JenkinsTaskData)
+	urlTemplate := fmt.Sprintf("%sjob/%s/api/json", data.Options.JobPath, data.Options.JobName)
+	query := func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+		query := url.Values{}
+		treeValue := fmt.Sprintf(
+			"allBuilds[timestamp,number,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}",
+			reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
+		query.Set("tree", treeValue)
+		return query, nil
+	}
+	responseParser := func(res *http.Response) ([]json.RawMessage, errors.Error) {
+		var data struct {
+			Builds []json.RawMessage `json:"allBuilds"`
+		}
+		err := helper.UnmarshalResponse(res, &data)
+		if err != nil {
+			return nil, err
+		}
+
+		builds := make([]json.RawMessage, 0, len(data.Builds))
+		for _, build := range data.Builds {
+			var buildObj map[string]interface{}
+			err := json.Unmarshal(build, &buildObj)
+			if err != nil {
+				return nil, errors.Convert(err)
+			}
+			if buildObj["result"] != nil {
+				builds = append(builds, build)
+			}
+		}
+
+		return builds, nil
+	}
+	// the Blue Ocean REST API pages the runs lazily while allBuilds loads every build of the job, it is opt-in and
+	// only used for the branches of multibranch projects since the runs lack the scm details of the other jobs
+	runsUrl := ""
+	if taskCtx.GetConfigReader().GetBool(JENKINS_USE_BLUE_OCEAN) {
+		runsUrl = getBlueOceanRunsUrl(data.ApiClient, data.Options)
+	}
+	if runsUrl != "" {
+		taskCtx.GetLogger().Info("collecting the runs of %s with the Blue Ocean REST API", data.Options.JobFullName)
+		remoteUrls, err := getRemoteUrls(data.ApiClient, data.Options)
+		if err != nil {
+			return err
+		}
+		branch, _ := url.PathUnescape(data.Options.JobName)
+		urlTemplate = runsUrl
+		query = func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
+			query := url.Values{}
+			query.Set("start", fmt.Sprintf("%d", reqData.Pager.Skip))
+			query.Set("limit", fmt.Sprintf("%d", reqData.Pager.Size))
+			return query, nil
+		}
+		responseParser = func(res *http.Response) ([]json.RawMessage, errors.Error) {
+			var runs []json.RawMessage
+			err := helper.UnmarshalResponse(res, &runs)
+			if err != nil {
+				return nil, err
+			}
+			builds := make([]json.RawMessage, 0, len(runs))
+			for _, run := range runs {
+				build, err := convertBlueOceanRun(run, data.Options.JobFullName, branch, remoteUrls)
+				if err != nil {
+					return nil, err
+				}
+				if build != nil {
+					builds = append(builds, build)
+				}
+			}
+			return builds, nil
+		}
+	}
 	collector, err := helper.NewStatefulApiCollectorForFinalizableEntity(helper.FinalizableApiCollectorArgs{
 		RawDataSubTaskArgs: helper.RawDataSubTaskArgs{
 			Params: JenkinsApiParams{
@@ -65,38 +139,9 @@ func CollectApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 			PageSize:    100,
 			Concurrency: 10,
 			FinalizableApiCollectorCommonArgs: helper.FinalizableApiCollectorCommonArgs{
-				UrlTemplate: fmt.Sprintf("%sjob/%s/api/json", data.Options.JobPath, data.Options.JobName),
-				Query: func(reqData *helper.RequestData, createdAfter *time.Time) (url.Values, errors.Error) {
-					query := url.Values{}
-					treeValue := fmt.Sprintf(
-						"allBuilds[timestamp,number,duration,building,estimatedDuration,fullDisplayName,result,actions[lastBuiltRevision[SHA1,branch[name]],remoteUrls,mercurialRevisionNumber,causes[*]],changeSet[kind,revisions[revision]]]{%d,%d}",
-						reqData.Pager.Skip, reqData.Pager.Skip+reqData.Pager.Size)
-					query.Set("tree", treeValue)
-					return query, nil
-				},
-				ResponseParser: func(res *http.Response) ([]json.RawMessage, errors.Error) {
-					var data struct {
-						Builds []json.RawMessage `json:"allBuilds"`
-					}
-					err := helper.UnmarshalResponse(res, &data)
-					if err != nil {
-						return nil, err
-					}
-
-					builds := make([]json.RawMessage, 0, len(data.Builds))
-					for _, build := range data.Builds {
-						var buildObj map[string]interface{}
-						err := json.Unmarshal(build, &buildObj)
-						if err != nil {
-							return nil, errors.Convert(err)
-						}
-						if buildObj["result"] != nil {
-							builds = append(builds, build)
-						}
-					}
-
-					return builds, nil
-				},
+				UrlTemplate:    urlTemplate,
+				Query:          query,
+				ResponseParser: responseParser,
 			},
 			GetCreated: func(item json.RawMessage) (time.Time, errors.Error) {
 				b := &SimpleJenkinsApiBuild{}
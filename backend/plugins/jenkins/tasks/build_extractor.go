@@ -55,66 +55,7 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 			if err != nil {
 				return nil, err
 			}
-
-			results := make([]interface{}, 0)
-			strList := strings.Split(body.Class, ".")
-			class := strList[len(strList)-1]
-			build := &models.JenkinsBuild{
-				ConnectionId:      data.Options.ConnectionId,
-				JobName:           data.Options.JobName,
-				JobPath:           data.Options.JobPath,
-				Duration:          body.Duration,
-				FullName:          fmt.Sprintf(`%s#%d`, data.Options.JobFullName, body.Number),
-				EstimatedDuration: body.EstimatedDuration,
-				Number:            body.Number,
-				Result:            body.Result,
-				Timestamp:         body.Timestamp,
-				Class:             class,
-				Building:          body.Building,
-				StartTime:         time.Unix(body.Timestamp/1000, 0),
-			}
-			// we also need to collect the commit info from the build which does not have changeSet
-			// changeSet describes the changes that were made in the build
-			for _, a := range body.Actions {
-				if a.LastBuiltRevision == nil {
-					continue
-				}
-				sha := ""
-				branch := ""
-				if a.LastBuiltRevision.SHA1 != "" {
-					sha = a.LastBuiltRevision.SHA1
-				}
-				if a.MercurialRevisionNumber != "" {
-					sha = a.MercurialRevisionNumber
-				}
-
-				if len(a.LastBuiltRevision.Branches) > 0 {
-					branch = a.LastBuiltRevision.Branches[0].Name
-				}
-				for _, url := range a.RemoteUrls {
-					if url != "" {
-						buildCommitRemoteUrl := models.JenkinsBuildCommit{
-							ConnectionId: data.Options.ConnectionId,
-							BuildName:    build.FullName,
-							CommitSha:    sha,
-							RepoUrl:      url,
-							Branch:       branch,
-						}
-						results = append(results, &buildCommitRemoteUrl)
-					}
-				}
-				if len(a.Causes) > 0 {
-					for _, cause := range a.Causes {
-						if cause.UpstreamProject != "" {
-							triggeredByBuild := fmt.Sprintf("%s #%d", cause.UpstreamProject, cause.UpstreamBuild)
-							build.TriggeredBy = triggeredByBuild
-						}
-					}
-				}
-			}
-
-			results = append(results, build)
-			return results, nil
+			return extractBuild(body, data.Options), nil
 		},
 	})
 
@@ -124,3 +65,82 @@ func ExtractApiBuilds(taskCtx plugin.SubTaskContext) errors.Error {
 
 	return extractor.Execute()
 }
+
+// extractBuild extracts the build and its commits from the classic build response, the runs of
+// the Blue Ocean REST API are converted into it beforehand
+func extractBuild(body *models.ApiBuildResponse, op *JenkinsOptions) []interface{} {
+	results := make([]interface{}, 0)
+	strList := strings.Split(body.Class, ".")
+	class := strList[len(strList)-1]
+	build := &models.JenkinsBuild{
+		ConnectionId:      op.ConnectionId,
+		JobName:           op.JobName,
+		JobPath:           op.JobPath,
+		Duration:          body.Duration,
+		FullName:          fmt.Sprintf(`%s#%d`, op.JobFullName, body.Number),
+		EstimatedDuration: body.EstimatedDuration,
+		Number:            body.Number,
+		Result:            body.Result,
+		Timestamp:         body.Timestamp,
+		Class:             class,
+		Building:          body.Building,
+		StartTime:         time.Unix(body.Timestamp/1000, 0),
+	}
+	// we also need to collect the commit info from the build which does not have changeSet
+	// changeSet describes the changes that were made in the build
+	for _, a := range body.Actions {
+		if a.LastBuiltRevision == nil {
+			continue
+		}
+		sha := ""
+		branch := ""
+		if a.LastBuiltRevision.SHA1 != "" {
+			sha = a.LastBuiltRevision.SHA1
+		}
+		if a.MercurialRevisionNumber != "" {
+			sha = a.MercurialRevisionNumber
+		}
+
+		if len(a.LastBuiltRevision.Branches) > 0 {
+			branch = a.LastBuiltRevision.Branches[0].Name
+		}
+		for _, url := range a.RemoteUrls {
+			if url != "" {
+				buildCommitRemoteUrl := models.JenkinsBuildCommit{
+					ConnectionId: op.ConnectionId,
+					BuildName:    build.FullName,
+					CommitSha:    sha,
+					RepoUrl:      url,
+					Branch:       branch,
+				}
+				results = append(results, &buildCommitRemoteUrl)
+			}
+		}
+	}
+	// causes are reported by the CauseAction which has no lastBuiltRevision
+	if upstream := getUpstreamCause(body.Actions); upstream != nil {
+		build.TriggeredBy = fmt.Sprintf("%s #%d", upstream.UpstreamProject, upstream.UpstreamBuild)
+		build.UpstreamBuild = fmt.Sprintf("%s#%d", upstream.UpstreamProject, upstream.UpstreamBuild)
+		results = append(results, &models.JenkinsJobDag{
+			ConnetionId:   op.ConnectionId,
+			UpstreamJob:   upstream.UpstreamProject,
+			DownstreamJob: op.JobFullName,
+		})
+	}
+
+	results = append(results, build)
+	return results
+}
+
+// getUpstreamCause returns the last cause pointing to an upstream build, nil if the build was not triggered by another job
+func getUpstreamCause(actions []models.Action) *models.Cause {
+	var upstream *models.Cause
+	for _, a := range actions {
+		for i := range a.Causes {
+			if a.Causes[i].UpstreamProject != "" {
+				upstream = &a.Causes[i]
+			}
+		}
+	}
+	return upstream
+}
@@ -0,0 +1,94 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetUpstreamCause(t *testing.T) {
+	assert.Nil(t, getUpstreamCause([]models.Action{
+		{Causes: []models.Cause{{ShortDescription: "Started by user admin"}}},
+	}))
+
+	upstream := getUpstreamCause([]models.Action{
+		{LastBuiltRevision: &models.LastBuiltRevision{SHA1: "abc"}},
+		{Causes: []models.Cause{
+			{ShortDescription: "Started by user admin"},
+			{UpstreamProject: "folder/build", UpstreamBuild: 42},
+		}},
+	})
+	assert.NotNil(t, upstream)
+	assert.Equal(t, "folder/build", upstream.UpstreamProject)
+	assert.Equal(t, 42, upstream.UpstreamBuild)
+}
+
+func TestExtractBuildBlueOceanMatchesClassic(t *testing.T) {
+	op := &JenkinsOptions{ConnectionId: 1, JobName: "main", JobFullName: "app/main", JobPath: "job/app/"}
+	remoteUrls := []string{"https://github.com/apache/incubator-devlake.git"}
+
+	classic := &models.ApiBuildResponse{}
+	assert.Nil(t, json.Unmarshal([]byte(`{
+		"_class": "org.jenkinsci.plugins.workflow.job.WorkflowRun",
+		"number": 7,
+		"result": "SUCCESS",
+		"duration": 1500,
+		"timestamp": 1703066400000,
+		"actions": [
+			{"lastBuiltRevision": {"SHA1": "abc", "branch": [{"name": "main"}]}, "remoteUrls": ["https://github.com/apache/incubator-devlake.git"]},
+			{"causes": [{"shortDescription": "Branch indexing"}]}
+		]
+	}`), classic))
+
+	raw, err := convertBlueOceanRun(json.RawMessage(`{
+		"_class": "io.jenkins.blueocean.rest.impl.pipeline.PipelineRunImpl",
+		"id": "7",
+		"result": "SUCCESS",
+		"state": "FINISHED",
+		"startTime": "2023-12-20T10:00:00.000+0000",
+		"durationInMillis": 1500,
+		"commitId": "abc",
+		"causes": [{"shortDescription": "Branch indexing"}]
+	}`), op.JobFullName, op.JobName, remoteUrls)
+	assert.Nil(t, err)
+	blueOcean := &models.ApiBuildResponse{}
+	assert.Nil(t, json.Unmarshal(raw, blueOcean))
+
+	buildCommits := func(results []interface{}) []*models.JenkinsBuildCommit {
+		commits := make([]*models.JenkinsBuildCommit, 0)
+		for _, result := range results {
+			if commit, ok := result.(*models.JenkinsBuildCommit); ok {
+				commits = append(commits, commit)
+			}
+		}
+		return commits
+	}
+	expected := buildCommits(extractBuild(classic, op))
+	assert.Equal(t, []*models.JenkinsBuildCommit{{
+		ConnectionId: 1,
+		BuildName:    "app/main#7",
+		CommitSha:    "abc",
+		RepoUrl:      "https://github.com/apache/incubator-devlake.git",
+		Branch:       "main",
+	}}, expected)
+	assert.Equal(t, expected, buildCommits(extractBuild(blueOcean, op)))
+}
@@ -0,0 +1,85 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"reflect"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/didgen"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jenkins/models"
+)
+
+var ConvertBuildRelationshipsMeta = plugin.SubTaskMeta{
+	Name:             "convertBuildRelationships",
+	EntryPoint:       ConvertBuildRelationships,
+	EnabledByDefault: true,
+	Description:      "convert upstream builds to cicd_pipeline_relationships",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CICD},
+}
+
+func ConvertBuildRelationships(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*JenkinsTaskData)
+	clauses := []dal.Clause{
+		dal.From("_tool_jenkins_builds"),
+		dal.Where(`_tool_jenkins_builds.connection_id = ?
+						and _tool_jenkins_builds.job_path = ?
+						and _tool_jenkins_builds.job_name = ?
+						and _tool_jenkins_builds.upstream_build != ''`,
+			data.Options.ConnectionId, data.Options.JobPath, data.Options.JobName),
+	}
+	cursor, err := db.Cursor(clauses...)
+	if err != nil {
+		return err
+	}
+	defer cursor.Close()
+	buildIdGen := didgen.NewDomainIdGenerator(&models.JenkinsBuild{})
+
+	converter, err := api.NewDataConverter(api.DataConverterArgs{
+		InputRowType: reflect.TypeOf(models.JenkinsBuild{}),
+		Input:        cursor,
+		RawDataSubTaskArgs: api.RawDataSubTaskArgs{
+			Params: JenkinsApiParams{
+				ConnectionId: data.Options.ConnectionId,
+				FullName:     data.Options.JobFullName,
+			},
+			Ctx:   taskCtx,
+			Table: RAW_BUILD_TABLE,
+		},
+		Convert: func(inputRow interface{}) ([]interface{}, errors.Error) {
+			jenkinsBuild := inputRow.(*models.JenkinsBuild)
+			// the upstream build might belong to a job which is not collected, the relationship is kept anyway
+			// so the chain can be completed once that job is added to the scopes
+			relationship := &devops.CICDPipelineRelationship{
+				ParentPipelineId: buildIdGen.Generate(jenkinsBuild.ConnectionId, jenkinsBuild.UpstreamBuild),
+				ChildPipelineId:  buildIdGen.Generate(jenkinsBuild.ConnectionId, jenkinsBuild.FullName),
+			}
+			return []interface{}{relationship}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return converter.Execute()
+}
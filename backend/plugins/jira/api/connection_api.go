@@ -36,18 +36,21 @@ import (
 type JiraTestConnResponse struct {
 	shared.ApiBody
 	Connection *models.JiraConn
+	// RefreshToken is the OAuth2 refresh token rotated while testing a connection not saved yet,
+	// it is to be saved in place of the tested one which is no longer valid
+	RefreshToken string `json:"refreshToken,omitempty"`
 }
 
-func testConnection(ctx context.Context, connection models.JiraConn) (*JiraTestConnResponse, errors.Error) {
+func testConnection(ctx context.Context, connection *models.JiraConnection) (*JiraTestConnResponse, errors.Error) {
 	// validate
 	if vld != nil {
-		e := vld.StructExcept(connection, "BasicAuth", "AccessToken")
+		e := vld.StructExcept(connection.JiraConn, "BasicAuth", "AccessToken", "JiraOAuth2")
 		if e != nil {
 			return nil, errors.Convert(e)
 		}
 	}
 	// test connection
-	apiClient, err := api.NewApiClientFromConnection(ctx, basicRes, &connection)
+	apiClient, err := api.NewApiClientFromConnection(ctx, basicRes, connection)
 	if err != nil {
 		return nil, err
 	}
@@ -106,11 +109,14 @@ func testConnection(ctx context.Context, connection models.JiraConn) (*JiraTestC
 	if res.StatusCode != http.StatusOK {
 		return nil, errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("%s Unexpected [%s] status code: %d %s", getStatusFail, res.Request.URL, res.StatusCode, errMsg))
 	}
-	connection = connection.Sanitize()
 	body := JiraTestConnResponse{}
+	if connection.AuthMethod == plugin.AUTH_METHOD_OAUTH2 && connection.ID == 0 {
+		body.RefreshToken = connection.RefreshToken
+	}
+	sanitizedConn := connection.JiraConn.Sanitize()
 	body.Success = true
 	body.Message = "success"
-	body.Connection = &connection
+	body.Connection = &sanitizedConn
 	if err != nil {
 		return nil, err
 	}
@@ -135,7 +141,7 @@ func TestConnection(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput,
 		return nil, errors.Convert(e)
 	}
 	// test connection
-	result, err := testConnection(context.TODO(), connection)
+	result, err := testConnection(context.TODO(), &models.JiraConnection{JiraConn: connection})
 	if err != nil {
 		return nil, err
 	}
@@ -156,7 +162,7 @@ func TestExistingConnection(input *plugin.ApiResourceInput) (*plugin.ApiResource
 		return nil, err
 	}
 	// test connection
-	result, err := testConnection(context.TODO(), connection.JiraConn)
+	result, err := testConnection(context.TODO(), connection)
 	if err != nil {
 		return nil, err
 	}
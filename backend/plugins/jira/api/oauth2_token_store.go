@@ -0,0 +1,46 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+var _ models.JiraOAuth2TokenStore = (*oauth2TokenStore)(nil)
+
+// oauth2TokenStore keeps the rotated refresh tokens in the connections table
+type oauth2TokenStore struct{}
+
+func (*oauth2TokenStore) GetRefreshToken(connectionId uint64) (string, errors.Error) {
+	connection, err := dsHelper.ConnSrv.FindByPk(connectionId)
+	if err != nil {
+		return "", err
+	}
+	return connection.RefreshToken, nil
+}
+
+func (*oauth2TokenStore) SaveRefreshToken(connectionId uint64, refreshToken string) errors.Error {
+	// reload the connection so the changes made since it was loaded would not be overwritten
+	connection, err := dsHelper.ConnSrv.FindByPk(connectionId)
+	if err != nil {
+		return err
+	}
+	connection.RefreshToken = refreshToken
+	return basicRes.GetDal().Update(connection)
+}
@@ -0,0 +1,101 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/jira/models"
+)
+
+type PutProjectScopesReqBody struct {
+	ScopeConfigId uint64 `json:"scopeConfigId" mapstructure:"scopeConfigId"`
+}
+
+// PutProjectScopes add all boards of a jira project as scopes
+// @Summary add all boards of a jira project as scopes
+// @Description Create or update all boards of the Jira project as scopes sharing the same scope config, the project key of every board is recorded as its parent
+// @Tags plugins/jira
+// @Accept application/json
+// @Param connectionId path int true "connection ID"
+// @Param projectKey path string true "project key"
+// @Param body body PutProjectScopesReqBody true "json"
+// @Success 200  {object} []models.JiraBoard
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router /plugins/jira/connections/{connectionId}/projects/{projectKey}/scopes [PUT]
+func PutProjectScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	projectKey := input.Params["projectKey"]
+	if projectKey == "" {
+		return nil, errors.BadInput.New("projectKey is required")
+	}
+	var body PutProjectScopesReqBody
+	err := api.Decode(input.Body, &body, nil)
+	if err != nil {
+		return nil, err
+	}
+	connection, err := raProxy.FindByPk(input)
+	if err != nil {
+		return nil, err
+	}
+	if body.ScopeConfigId != 0 {
+		scopeConfig, err := dsHelper.ScopeConfigSrv.FindByPk(body.ScopeConfigId)
+		if err != nil {
+			return nil, err
+		}
+		if scopeConfig.ConnectionId != connection.ID {
+			return nil, errors.BadInput.New("the scope config does not belong to the connection")
+		}
+	}
+	apiClient, err := api.NewApiClientFromConnection(context.TODO(), basicRes, connection)
+	if err != nil {
+		return nil, err
+	}
+
+	boards := make([]*models.JiraBoard, 0)
+	page := JiraRemotePagination{MaxResults: 50}
+	for {
+		children, nextPage, err := queryJiraAgileBoards(apiClient, "", projectKey, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, child := range children {
+			board := child.Data
+			board.ConnectionId = connection.ID
+			board.ScopeConfigId = body.ScopeConfigId
+			// a board might be listed under several projects, it belongs to the one it is located in,
+			// boards without location (i.e. located in a user) belong to the selected project
+			if board.ProjectKey == "" {
+				board.ProjectKey = projectKey
+			}
+			err = dsHelper.ScopeSrv.CreateOrUpdate(board)
+			if err != nil {
+				return nil, err
+			}
+			boards = append(boards, board)
+		}
+		if nextPage == nil {
+			break
+		}
+		page = *nextPage
+	}
+	return &plugin.ApiResourceOutput{Body: boards}, nil
+}
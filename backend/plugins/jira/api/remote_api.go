@@ -19,6 +19,7 @@ package api
 
 import (
 	"fmt"
+	"net/http"
 	"net/url"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -37,6 +38,7 @@ type JiraRemotePagination struct {
 func queryJiraAgileBoards(
 	apiClient plugin.ApiClient,
 	keyword string,
+	projectKey string,
 	page JiraRemotePagination,
 ) (
 	children []dsmodels.DsRemoteApiScopeListEntry[models.JiraBoard],
@@ -46,11 +48,17 @@ func queryJiraAgileBoards(
 	if page.MaxResults == 0 {
 		page.MaxResults = 50
 	}
-	res, err := apiClient.Get("agile/1.0/board", url.Values{
+	query := url.Values{
 		"maxResults": {fmt.Sprintf("%v", page.MaxResults)},
 		"startAt":    {fmt.Sprintf("%v", page.StartAt)},
 		"name":       {keyword},
-	}, nil)
+	}
+	var parentId *string
+	if projectKey != "" {
+		query.Set("projectKeyOrId", projectKey)
+		parentId = &projectKey
+	}
+	res, err := apiClient.Get("agile/1.0/board", query, nil)
 	if err != nil {
 		return
 	}
@@ -70,7 +78,7 @@ func queryJiraAgileBoards(
 		children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.JiraBoard]{
 			Type:     api.RAS_ENTRY_TYPE_SCOPE,
 			Id:       fmt.Sprintf("%v", board.ID),
-			ParentId: nil,
+			ParentId: parentId,
 			Name:     board.Name,
 			FullName: board.Name,
 			Data:     board.ToToolLayer(0),
@@ -87,6 +95,10 @@ func queryJiraAgileBoards(
 	return
 }
 
+// allBoardsGroupId is the group listing all boards regardless of their projects, like before the projects were
+// listed, so the boards without a project or of the projects the user can't browse are still reachable
+const allBoardsGroupId = "*"
+
 func listJiraRemoteScopes(
 	_ *models.JiraConnection,
 	apiClient plugin.ApiClient,
@@ -97,7 +109,84 @@ func listJiraRemoteScopes(
 	nextPage *JiraRemotePagination,
 	err errors.Error,
 ) {
-	return queryJiraAgileBoards(apiClient, "", page)
+	switch groupId {
+	case "":
+		if page.StartAt == 0 {
+			children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.JiraBoard]{
+				Type:     api.RAS_ENTRY_TYPE_GROUP,
+				Id:       allBoardsGroupId,
+				Name:     "All boards",
+				FullName: "All boards",
+			})
+		}
+		var projects []dsmodels.DsRemoteApiScopeListEntry[models.JiraBoard]
+		projects, nextPage, err = queryJiraProjects(apiClient, page)
+		children = append(children, projects...)
+		return
+	case allBoardsGroupId:
+		children, nextPage, err = queryJiraAgileBoards(apiClient, "", "", page)
+		parentId := allBoardsGroupId
+		for i := range children {
+			children[i].ParentId = &parentId
+		}
+		return
+	default:
+		return queryJiraAgileBoards(apiClient, "", groupId, page)
+	}
+}
+
+// queryJiraProjects lists the projects page by page as groups, so boards could be browsed and selected project by
+// project. Jira Server lacks the paginated project search, all projects are listed at once there
+func queryJiraProjects(apiClient plugin.ApiClient, page JiraRemotePagination) (
+	children []dsmodels.DsRemoteApiScopeListEntry[models.JiraBoard],
+	nextPage *JiraRemotePagination,
+	err errors.Error,
+) {
+	if page.MaxResults == 0 {
+		page.MaxResults = 50
+	}
+	res, err := apiClient.Get("api/2/project/search", url.Values{
+		"maxResults": {fmt.Sprintf("%v", page.MaxResults)},
+		"startAt":    {fmt.Sprintf("%v", page.StartAt)},
+		"orderBy":    {"name"},
+	}, nil)
+	if err != nil {
+		return
+	}
+	var projects []apiv2models.Project
+	if res.StatusCode == http.StatusNotFound {
+		res.Body.Close()
+		res, err = apiClient.Get("api/2/project", nil, nil)
+		if err != nil {
+			return
+		}
+		err = api.UnmarshalResponse(res, &projects)
+	} else {
+		resBody := struct {
+			IsLast bool                  `json:"isLast"`
+			Values []apiv2models.Project `json:"values"`
+		}{}
+		err = api.UnmarshalResponse(res, &resBody)
+		projects = resBody.Values
+		if err == nil && !resBody.IsLast {
+			nextPage = &JiraRemotePagination{
+				MaxResults: page.MaxResults,
+				StartAt:    page.StartAt + page.MaxResults,
+			}
+		}
+	}
+	if err != nil {
+		return
+	}
+	for _, project := range projects {
+		children = append(children, dsmodels.DsRemoteApiScopeListEntry[models.JiraBoard]{
+			Type:     api.RAS_ENTRY_TYPE_GROUP,
+			Id:       project.Key,
+			Name:     project.Name,
+			FullName: project.Name,
+		})
+	}
+	return
 }
 
 // RemoteScopes list all available scopes on the remote server
@@ -105,7 +194,7 @@ func listJiraRemoteScopes(
 // @Description list all available scopes on the remote server
 // @Accept application/json
 // @Param connectionId path int false "connection ID"
-// @Param groupId query string false "group ID"
+// @Param groupId query string false "project key, or * for all boards, projects are listed as groups when omitted"
 // @Param pageToken query string false "page Token"
 // @Failure 400  {object} shared.ApiBody "Bad Request"
 // @Failure 500  {object} shared.ApiBody "Internal Error"
@@ -130,7 +219,7 @@ func searchJiraRemoteBoards(
 		MaxResults: params.PageSize,
 		StartAt:    (params.Page - 1) * params.PageSize,
 	}
-	children, _, err = queryJiraAgileBoards(apiClient, params.Search, page)
+	children, _, err = queryJiraAgileBoards(apiClient, params.Search, "", page)
 	return
 }
 
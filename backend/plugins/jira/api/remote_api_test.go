@@ -0,0 +1,67 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	mockplugin "github.com/apache/incubator-devlake/mocks/core/plugin"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func jsonResponse(status int, body string) *http.Response {
+	return &http.Response{StatusCode: status, Body: io.NopCloser(strings.NewReader(body))}
+}
+
+func TestListJiraRemoteScopes(t *testing.T) {
+	apiClient := new(mockplugin.ApiClient)
+	apiClient.On("Get", "api/2/project/search", mock.Anything, mock.Anything).Return(
+		jsonResponse(http.StatusOK, `{"isLast":false,"values":[{"key":"DL","name":"DevLake"}]}`), nil)
+	apiClient.On("Get", "agile/1.0/board", mock.Anything, mock.Anything).Return(
+		jsonResponse(http.StatusOK, `{"isLast":true,"values":[{"id":1,"name":"Board"}]}`), nil)
+
+	// the projects are paginated, the group of all boards comes first
+	children, nextPage, err := listJiraRemoteScopes(nil, apiClient, "", JiraRemotePagination{})
+	assert.Nil(t, err)
+	assert.Equal(t, []string{allBoardsGroupId, "DL"}, []string{children[0].Id, children[1].Id})
+	assert.Equal(t, &JiraRemotePagination{MaxResults: 50, StartAt: 50}, nextPage)
+
+	children, nextPage, err = listJiraRemoteScopes(nil, apiClient, allBoardsGroupId, JiraRemotePagination{})
+	assert.Nil(t, err)
+	assert.Nil(t, nextPage)
+	assert.Len(t, children, 1)
+	assert.Equal(t, allBoardsGroupId, *children[0].ParentId)
+}
+
+func TestQueryJiraProjectsOnJiraServer(t *testing.T) {
+	apiClient := new(mockplugin.ApiClient)
+	apiClient.On("Get", "api/2/project/search", mock.Anything, mock.Anything).Return(
+		jsonResponse(http.StatusNotFound, `{}`), nil)
+	apiClient.On("Get", "api/2/project", mock.Anything, mock.Anything).Return(
+		jsonResponse(http.StatusOK, `[{"key":"DL","name":"DevLake"}]`), nil)
+
+	children, nextPage, err := queryJiraProjects(apiClient, JiraRemotePagination{})
+	assert.Nil(t, err)
+	assert.Nil(t, nextPage)
+	assert.Len(t, children, 1)
+	assert.Equal(t, "DL", children[0].Id)
+}
@@ -292,12 +292,17 @@ func (p Jira) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"DELETE": api.DeleteScope,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
-			"GET": api.GetScopeLatestSyncState,
+			"GET":    api.GetScopeLatestSyncState,
+			"PATCH":  api.PatchScopeLatestSyncState,
+			"DELETE": api.DeleteScopeLatestSyncState,
 		},
 		"connections/:connectionId/scopes": {
 			"GET": api.GetScopeList,
 			"PUT": api.PutScope,
 		},
+		"connections/:connectionId/projects/:projectKey/scopes": {
+			"PUT": api.PutProjectScopes,
+		},
 		"connections/:connectionId/scope-configs": {
 			"POST": api.CreateScopeConfig,
 			"GET":  api.GetScopeConfigList,
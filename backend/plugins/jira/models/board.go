@@ -30,6 +30,7 @@ type JiraBoard struct {
 	common.Scope `mapstructure:",squash"`
 	BoardId      uint64 `json:"boardId" mapstructure:"boardId" validate:"required" gorm:"primaryKey"`
 	ProjectId    uint   `json:"projectId" mapstructure:"projectId"`
+	ProjectKey   string `json:"projectKey" mapstructure:"projectKey" gorm:"index;type:varchar(255)"`
 	Name         string `json:"name" mapstructure:"name" gorm:"type:varchar(255)"`
 	Self         string `json:"self" mapstructure:"self" gorm:"type:varchar(255)"`
 	Type         string `json:"type" mapstructure:"type" gorm:"type:varchar(100)"`
@@ -22,6 +22,7 @@ import (
 	"net/http"
 
 	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 )
 
@@ -43,14 +44,24 @@ type JiraConn struct {
 	helper.MultiAuth      `mapstructure:",squash"`
 	helper.BasicAuth      `mapstructure:",squash"`
 	helper.AccessToken    `mapstructure:",squash"`
+	JiraOAuth2            `mapstructure:",squash" authMethod:"OAuth2"`
 }
 
 func (jc *JiraConn) Sanitize() JiraConn {
 	jc.Password = ""
 	jc.AccessToken.Token = utils.SanitizeString(jc.AccessToken.Token)
+	jc.ClientSecret = ""
+	jc.RefreshToken = utils.SanitizeString(jc.RefreshToken)
 	return *jc
 }
 
+// GetOAuth2Authenticator returns the OAuth2 authenticator, rotated refresh tokens are not persisted
+// since the connection is not saved yet
+func (jc *JiraConn) GetOAuth2Authenticator() plugin.ApiAuthenticator {
+	jc.JiraOAuth2.proxy = jc.Proxy
+	return &jc.JiraOAuth2
+}
+
 // SetupAuthentication implements the `IAuthentication` interface by delegating
 // the actual logic to the `MultiAuth` struct to help us write less code
 func (jc *JiraConn) SetupAuthentication(req *http.Request) errors.Error {
@@ -63,6 +74,19 @@ type JiraConnection struct {
 	JiraConn              `mapstructure:",squash"`
 }
 
+// SetupAuthentication delegates to the `MultiAuth` struct with the saved connection
+func (connection *JiraConnection) SetupAuthentication(req *http.Request) errors.Error {
+	return connection.MultiAuth.SetupAuthenticationForConnection(connection, req)
+}
+
+// GetOAuth2Authenticator returns the OAuth2 authenticator bound to the saved connection, so the
+// rotated refresh tokens are persisted
+func (connection *JiraConnection) GetOAuth2Authenticator() plugin.ApiAuthenticator {
+	connection.JiraOAuth2.connectionId = connection.ID
+	connection.JiraOAuth2.proxy = connection.Proxy
+	return &connection.JiraOAuth2
+}
+
 func (JiraConnection) TableName() string {
 	return "_tool_jira_connections"
 }
@@ -45,5 +45,7 @@ func All() []plugin.MigrationScript {
 		new(addIssueRelationship),
 		new(dropIssueAllFields),
 		new(modifyIssueRelationship),
+		new(addProjectKeyToBoards),
+		new(addOAuth2ToConnections),
 	}
 }
@@ -0,0 +1,158 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// JiraOAuth2TokenUrl is where the refresh token is exchanged for access tokens
+const JiraOAuth2TokenUrl = "https://auth.atlassian.com/oauth/token"
+
+// JiraOAuth2TokenStore keeps the refresh tokens of the saved connections up to date, Atlassian rotates
+// the refresh token on every exchange, a connection would be locked out if the new one got lost
+type JiraOAuth2TokenStore interface {
+	GetRefreshToken(connectionId uint64) (string, errors.Error)
+	SaveRefreshToken(connectionId uint64, refreshToken string) errors.Error
+}
+
+// oauth2TokenUrl is overridden by tests
+var oauth2TokenUrl = JiraOAuth2TokenUrl
+
+// OAuth2TokenStore is set by the plugin on init
+var OAuth2TokenStore JiraOAuth2TokenStore
+
+// refreshing the token of a saved connection is serialized by connection id, so the copies of the connection loaded
+// by concurrent pipelines don't race for rotation, the unsaved connections being tested share a single lock
+var oauth2RefreshLocks sync.Map
+var unsavedOAuth2RefreshLock sync.Mutex
+
+// JiraOAuth2 implements Atlassian OAuth 2.0 (3LO) for Jira Cloud, the Endpoint of such a connection
+// should be https://api.atlassian.com/ex/jira/<cloudId>/rest/
+type JiraOAuth2 struct {
+	ClientId     string `mapstructure:"clientId" validate:"required" json:"clientId"`
+	ClientSecret string `mapstructure:"clientSecret" validate:"required" json:"clientSecret" gorm:"serializer:encdec"`
+	RefreshToken string `mapstructure:"refreshToken" validate:"required" json:"refreshToken" gorm:"serializer:encdec"`
+
+	connectionId uint64
+	proxy        string
+	accessToken  string
+	expiresAt    time.Time
+}
+
+type jiraOAuth2TokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int    `json:"expires_in"`
+}
+
+// SetupAuthentication sets up the request headers for authentication, the access token is refreshed
+// a minute before it expires
+func (o *JiraOAuth2) SetupAuthentication(request *http.Request) errors.Error {
+	lock := o.refreshLock()
+	lock.Lock()
+	defer lock.Unlock()
+	if o.accessToken == "" || time.Now().After(o.expiresAt) {
+		if err := o.refresh(); err != nil {
+			return err
+		}
+	}
+	request.Header.Set("Authorization", fmt.Sprintf("Bearer %v", o.accessToken))
+	return nil
+}
+
+func (o *JiraOAuth2) refreshLock() *sync.Mutex {
+	if o.connectionId == 0 {
+		return &unsavedOAuth2RefreshLock
+	}
+	lock, _ := oauth2RefreshLocks.LoadOrStore(o.connectionId, &sync.Mutex{})
+	return lock.(*sync.Mutex)
+}
+
+// httpClient goes through the proxy of the connection like the api client does
+func (o *JiraOAuth2) httpClient() (*http.Client, errors.Error) {
+	transport := &http.Transport{}
+	if o.proxy != "" {
+		proxyUrl, err := url.Parse(o.proxy)
+		if err != nil {
+			return nil, errors.BadInput.Wrap(err, "invalid proxy")
+		}
+		if proxyUrl.Scheme == "http" || proxyUrl.Scheme == "socks5" {
+			transport.Proxy = http.ProxyURL(proxyUrl)
+		}
+	}
+	return &http.Client{Transport: transport, Timeout: 30 * time.Second}, nil
+}
+
+func (o *JiraOAuth2) refresh() errors.Error {
+	// the refresh token might have been rotated by another pipeline since the connection was loaded
+	if o.connectionId != 0 && OAuth2TokenStore != nil {
+		refreshToken, err := OAuth2TokenStore.GetRefreshToken(o.connectionId)
+		if err != nil {
+			return err
+		}
+		o.RefreshToken = refreshToken
+	}
+	reqBody, e := json.Marshal(map[string]string{
+		"grant_type":    "refresh_token",
+		"client_id":     o.ClientId,
+		"client_secret": o.ClientSecret,
+		"refresh_token": o.RefreshToken,
+	})
+	if e != nil {
+		return errors.Convert(e)
+	}
+	client, err := o.httpClient()
+	if err != nil {
+		return err
+	}
+	res, e := client.Post(oauth2TokenUrl, "application/json", bytes.NewReader(reqBody))
+	if e != nil {
+		return errors.Default.Wrap(e, "failed to refresh the OAuth2 access token")
+	}
+	defer res.Body.Close()
+	resBody, e := io.ReadAll(res.Body)
+	if e != nil {
+		return errors.Convert(e)
+	}
+	if res.StatusCode != http.StatusOK {
+		return errors.HttpStatus(res.StatusCode).New(fmt.Sprintf("failed to refresh the OAuth2 access token: %s", resBody))
+	}
+	token := &jiraOAuth2TokenResponse{}
+	if e = json.Unmarshal(resBody, token); e != nil {
+		return errors.Convert(e)
+	}
+	o.accessToken = token.AccessToken
+	o.expiresAt = time.Now().Add(time.Duration(token.ExpiresIn)*time.Second - time.Minute)
+	if token.RefreshToken != "" && token.RefreshToken != o.RefreshToken {
+		o.RefreshToken = token.RefreshToken
+		if o.connectionId != 0 && OAuth2TokenStore != nil {
+			return OAuth2TokenStore.SaveRefreshToken(o.connectionId, o.RefreshToken)
+		}
+	}
+	return nil
+}
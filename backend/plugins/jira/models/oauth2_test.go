@@ -0,0 +1,107 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/stretchr/testify/assert"
+)
+
+type memoryTokenStore map[uint64]string
+
+func (s memoryTokenStore) GetRefreshToken(connectionId uint64) (string, errors.Error) {
+	return s[connectionId], nil
+}
+
+func (s memoryTokenStore) SaveRefreshToken(connectionId uint64, refreshToken string) errors.Error {
+	s[connectionId] = refreshToken
+	return nil
+}
+
+func TestJiraOAuth2RotatesRefreshToken(t *testing.T) {
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		req := map[string]string{}
+		assert.Nil(t, json.NewDecoder(r.Body).Decode(&req))
+		assert.Equal(t, "refresh_token", req["grant_type"])
+		exchanges++
+		if req["refresh_token"] != "r1" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token":  "a2",
+			"refresh_token": "r2",
+			"expires_in":    3600,
+		})
+	}))
+	defer server.Close()
+	oauth2TokenUrl = server.URL
+	defer func() { oauth2TokenUrl = JiraOAuth2TokenUrl }()
+	store := memoryTokenStore{1: "r1"}
+	OAuth2TokenStore = store
+	defer func() { OAuth2TokenStore = nil }()
+
+	// the saved connection was rotated by another pipeline, the latest refresh token is used
+	connection := &JiraConnection{JiraConn: JiraConn{JiraOAuth2: JiraOAuth2{ClientId: "c", ClientSecret: "s", RefreshToken: "r0"}}}
+	connection.ID = 1
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, connection.GetOAuth2Authenticator().SetupAuthentication(req))
+	assert.Equal(t, "Bearer a2", req.Header.Get("Authorization"))
+	assert.Equal(t, "r2", store[1])
+
+	// the access token is cached until it expires
+	assert.Nil(t, connection.GetOAuth2Authenticator().SetupAuthentication(req))
+	assert.Equal(t, 1, exchanges)
+
+	// the rejected refresh token fails the request
+	conn := &JiraConn{JiraOAuth2: JiraOAuth2{ClientId: "c", ClientSecret: "s", RefreshToken: "r0"}}
+	assert.NotNil(t, conn.GetOAuth2Authenticator().SetupAuthentication(req))
+}
+
+func TestJiraOAuth2GoesThroughProxy(t *testing.T) {
+	var proxiedHost string
+	proxy := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		proxiedHost = r.URL.Host
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"access_token": "a1",
+			"expires_in":   3600,
+		})
+	}))
+	defer proxy.Close()
+	oauth2TokenUrl = "http://auth.example.com/oauth/token"
+	defer func() { oauth2TokenUrl = JiraOAuth2TokenUrl }()
+
+	conn := &JiraConn{JiraOAuth2: JiraOAuth2{ClientId: "c", ClientSecret: "s", RefreshToken: "r1"}}
+	conn.Proxy = proxy.URL
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	assert.Nil(t, conn.GetOAuth2Authenticator().SetupAuthentication(req))
+	assert.Equal(t, "Bearer a1", req.Header.Get("Authorization"))
+	assert.Equal(t, "auth.example.com", proxiedHost)
+}
+
+func TestJiraOAuth2RefreshLock(t *testing.T) {
+	// the copies of a saved connection share the lock, other connections don't wait for it
+	assert.Same(t, (&JiraOAuth2{connectionId: 1}).refreshLock(), (&JiraOAuth2{connectionId: 1}).refreshLock())
+	assert.NotSame(t, (&JiraOAuth2{connectionId: 1}).refreshLock(), (&JiraOAuth2{connectionId: 2}).refreshLock())
+}
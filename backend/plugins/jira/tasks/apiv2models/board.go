@@ -47,6 +47,7 @@ func (b Board) ConvertApiScope() plugin.ToolLayerScope {
 	}
 	if b.Location != nil {
 		result.ProjectId = b.Location.ProjectId
+		result.ProjectKey = b.Location.ProjectKey
 	}
 	return result
 }
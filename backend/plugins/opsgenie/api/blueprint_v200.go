@@ -26,6 +26,7 @@ import (
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/core/utils"
 	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/helpers/srvhelper"
 	"github.com/apache/incubator-devlake/plugins/opsgenie/models"
 	"github.com/apache/incubator-devlake/plugins/opsgenie/tasks"
 )
@@ -67,6 +68,10 @@ func makeDataSourcePipelinePlanV200(
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(bpScope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 		// construct task options for opsgenie
 		op := &tasks.OpsgenieOptions{
 			ConnectionId: service.ConnectionId,
@@ -104,6 +109,10 @@ func makeScopesV200(bpScopes []*coreModels.BlueprintScope, connection *models.Op
 		if err != nil {
 			return nil, err
 		}
+		err = srvhelper.ApplyScopeConfigOverride(bpScope, scopeConfig)
+		if err != nil {
+			return nil, err
+		}
 		// add board to scopes
 		if utils.StringsContains(scopeConfig.Entities, plugin.DOMAIN_TYPE_TICKET) {
 			scopeTicket := &ticket.Board{
@@ -119,6 +119,7 @@ func TestIncidentDataFlow(t *testing.T) {
 	)
 	dataflowTester.FlushTabler(&ticket.Issue{})
 	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.FlushTabler(&ticket.IncidentTimelineEvent{})
 	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(
 		ticket.Issue{},
@@ -140,4 +141,8 @@ func TestIncidentDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/issue_assignees.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+	dataflowTester.VerifyTableWithOptions(ticket.IncidentTimelineEvent{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/incident_timeline_events.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
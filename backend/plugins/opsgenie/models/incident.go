@@ -47,6 +47,8 @@ type (
 		Status       IncidentStatus
 		CreatedDate  time.Time
 		UpdatedDate  time.Time
+		// ImpactEndDate is when the impact on the services ended, which might be before the incident is resolved
+		ImpactEndDate *time.Time
 	}
 )
 
@@ -29,6 +29,8 @@ type Incident struct {
 	Tags             []any      `json:"tags"`
 	CreatedAt        *time.Time `json:"createdAt"`
 	UpdatedAt        *time.Time `json:"updatedAt"`
+	ImpactStartDate  *time.Time `json:"impactStartDate"`
+	ImpactEndDate    *time.Time `json:"impactEndDate"`
 	Priority         *string    `json:"priority"`
 	OwnerTeam        *string    `json:"ownerTeam"`
 	Responders       *[]struct {
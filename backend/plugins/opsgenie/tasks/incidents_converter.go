@@ -36,7 +36,7 @@ var ConvertIncidentsMeta = plugin.SubTaskMeta{
 	Name:             "convertIncidents",
 	EntryPoint:       ConvertIncidents,
 	EnabledByDefault: true,
-	Description:      "Convert Incidents into domain layer table issues",
+	Description:      "Convert Incidents into domain layer table issues and incident_timeline_events",
 	Dependencies: []*plugin.SubTaskMeta{
 		&ExtractUsersMeta,
 		&ExtractTeamsMeta,
@@ -135,6 +135,11 @@ func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 				IssueId: domainIssue.Id,
 			}
 			result = append(result, boardIssue)
+			result = append(result, ticket.NewIncidentTimelineEvents(domainIssue.Id,
+				ticket.IncidentMilestone{EventType: ticket.INCIDENT_DETECTED, EventDate: &incident.CreatedDate, OriginalEvent: "open"},
+				ticket.IncidentMilestone{EventType: ticket.INCIDENT_MITIGATED, EventDate: incident.ImpactEndDate, OriginalEvent: "impactEnd"},
+				ticket.IncidentMilestone{EventType: ticket.INCIDENT_RESOLVED, EventDate: resolutionDate, OriginalEvent: string(incident.Status)},
+			)...)
 			return result, nil
 		},
 	})
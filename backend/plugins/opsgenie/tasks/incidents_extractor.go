@@ -56,18 +56,19 @@ func ExtractIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 
 			results := make([]interface{}, 0, 1)
 			incident := models.Incident{
-				ConnectionId: data.Options.ConnectionId,
-				Id:           *incidentRaw.Id,
-				Url:          resolve(incidentRaw.Links.Web),
-				Message:      *incidentRaw.Message,
-				OwnerTeam:    resolve(incidentRaw.OwnerTeam),
-				Description:  resolve(incidentRaw.Description),
-				ServiceId:    data.Options.ServiceId,
-				ServiceName:  data.Options.ServiceName,
-				Status:       models.IncidentStatus(*incidentRaw.Status),
-				Priority:     models.IncidentPriority(*incidentRaw.Priority),
-				CreatedDate:  *incidentRaw.CreatedAt,
-				UpdatedDate:  *incidentRaw.UpdatedAt,
+				ConnectionId:  data.Options.ConnectionId,
+				Id:            *incidentRaw.Id,
+				Url:           resolve(incidentRaw.Links.Web),
+				Message:       *incidentRaw.Message,
+				OwnerTeam:     resolve(incidentRaw.OwnerTeam),
+				Description:   resolve(incidentRaw.Description),
+				ServiceId:     data.Options.ServiceId,
+				ServiceName:   data.Options.ServiceName,
+				Status:        models.IncidentStatus(*incidentRaw.Status),
+				Priority:      models.IncidentPriority(*incidentRaw.Priority),
+				CreatedDate:   *incidentRaw.CreatedAt,
+				UpdatedDate:   *incidentRaw.UpdatedAt,
+				ImpactEndDate: incidentRaw.ImpactEndDate,
 			}
 			results = append(results, &incident)
 			for _, responderRaw := range *incidentRaw.Responders {
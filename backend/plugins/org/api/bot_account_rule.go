@@ -0,0 +1,93 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/gocarina/gocsv"
+)
+
+// GetBotAccountRules returns all bot account rules in csv format
+// @Summary      Get bot_account_rules.csv file
+// @Description  get bot_account_rules.csv file, accounts matching a rule with IsBot=true are flagged as bots and the ones matching IsBot=false are never flagged
+// @Tags 		 plugins/org
+// @Produce      text/csv
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/bot_account_rules.csv [get]
+func (h *Handlers) GetBotAccountRules(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	rules, err := h.store.findAllBotAccountRules()
+	if err != nil {
+		return nil, err
+	}
+	blob, err := errors.Convert01(gocsv.MarshalBytes(rules))
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{
+		Body:   nil,
+		Status: http.StatusOK,
+		File: &plugin.OutputFile{
+			ContentType: "text/csv",
+			Data:        blob,
+		},
+	}, nil
+}
+
+// CreateBotAccountRules accepts a CSV file containing bot account rules and replaces the existing ones with it,
+// the accounts are flagged accordingly by the next pipeline of the projects
+// @Summary      Upload bot_account_rules.csv file
+// @Description  upload bot_account_rules.csv file
+// @Tags 		 plugins/org
+// @Accept       multipart/form-data
+// @Param        file formData file true "select file to upload"
+// @Produce      json
+// @Success      200
+// @Failure 400  {object} shared.ApiBody "Bad Request"
+// @Failure 500  {object} shared.ApiBody "Internal Error"
+// @Router       /plugins/org/bot_account_rules.csv [put]
+func (h *Handlers) CreateBotAccountRules(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	var rr []botAccountRule
+	err := h.unmarshal(input.Request, &rr)
+	if err != nil {
+		return nil, err
+	}
+	var r *botAccountRule
+	var items []interface{}
+	for _, rule := range r.toDomainLayer(rr) {
+		err = rule.Validate()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, rule)
+	}
+	err = h.store.deleteAll(&crossdomain.BotAccountRule{})
+	if err != nil {
+		return nil, err
+	}
+	err = h.store.save(items)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Status: http.StatusOK}, nil
+}
@@ -32,6 +32,7 @@ type store interface {
 	findAllAccounts() ([]account, errors.Error)
 	findAllUserAccounts() ([]userAccount, errors.Error)
 	findAllProjectMapping() ([]projectMapping, errors.Error)
+	findAllBotAccountRules() ([]botAccountRule, errors.Error)
 	deleteAll(i interface{}) errors.Error
 	save(items []interface{}) errors.Error
 }
@@ -104,6 +105,17 @@ func (d *dbStore) findAllProjectMapping() ([]projectMapping, errors.Error) {
 	var pm *projectMapping
 	return pm.fromDomainLayer(mapping), nil
 }
+
+func (d *dbStore) findAllBotAccountRules() ([]botAccountRule, errors.Error) {
+	var rules []crossdomain.BotAccountRule
+	err := d.db.All(&rules)
+	if err != nil {
+		return nil, err
+	}
+	var r *botAccountRule
+	return r.fromDomainLayer(rules), nil
+}
+
 func (d *dbStore) deleteAll(i interface{}) errors.Error {
 	return d.db.Delete(i, dal.Where("1=1"))
 }
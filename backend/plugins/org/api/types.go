@@ -283,3 +283,33 @@ func (*projectMapping) toDomainLayer(tt []projectMapping) []*crossdomain.Project
 // func (m *projectMapping) fakeData() []projectMapping {
 // 	return fakeProjectMapping
 // }
+
+type botAccountRule struct {
+	Pattern string
+	IsBot   bool
+}
+
+func (*botAccountRule) fromDomainLayer(rules []crossdomain.BotAccountRule) []botAccountRule {
+	result := make([]botAccountRule, 0, len(rules))
+	for _, r := range rules {
+		result = append(result, botAccountRule{
+			Pattern: r.Pattern,
+			IsBot:   r.IsBot,
+		})
+	}
+	return result
+}
+
+func (*botAccountRule) toDomainLayer(rules []botAccountRule) []*crossdomain.BotAccountRule {
+	var result []*crossdomain.BotAccountRule
+	for _, r := range rules {
+		if r.Pattern == "" {
+			continue
+		}
+		result = append(result, &crossdomain.BotAccountRule{
+			Pattern: r.Pattern,
+			IsBot:   r.IsBot,
+		})
+	}
+	return result
+}
@@ -120,5 +120,9 @@ func (p Org) ApiResources() map[string]map[string]plugin.ApiResourceHandler {
 			"GET": p.handlers.GetProjectMapping,
 			"PUT": p.handlers.CreateProjectMapping,
 		},
+		"bot_account_rules.csv": {
+			"GET": p.handlers.GetBotAccountRules,
+			"PUT": p.handlers.CreateBotAccountRules,
+		},
 	}
 }
@@ -88,6 +88,7 @@ func TestIncidentDataFlow(t *testing.T) {
 	)
 	dataflowTester.FlushTabler(&ticket.Issue{})
 	dataflowTester.FlushTabler(&ticket.IssueAssignee{})
+	dataflowTester.FlushTabler(&ticket.IncidentTimelineEvent{})
 	dataflowTester.Subtask(tasks.ConvertIncidentsMeta, taskData)
 	dataflowTester.VerifyTableWithOptions(
 		ticket.Issue{},
@@ -101,4 +102,8 @@ func TestIncidentDataFlow(t *testing.T) {
 		CSVRelPath:  "./snapshot_tables/issue_assignees.csv",
 		IgnoreTypes: []interface{}{common.NoPKModel{}},
 	})
+	dataflowTester.VerifyTableWithOptions(ticket.IncidentTimelineEvent{}, e2ehelper.TableOptions{
+		CSVRelPath:  "./snapshot_tables/incident_timeline_events.csv",
+		IgnoreTypes: []interface{}{common.NoPKModel{}},
+	})
 }
@@ -44,6 +44,10 @@ type (
 		Priority     string
 		CreatedDate  time.Time
 		UpdatedDate  time.Time
+		// the first acknowledgement, pagerduty only lists them while the incident is acknowledged
+		AcknowledgedDate *time.Time
+		AcknowledgerId   string `gorm:"type:varchar(255)"`
+		AcknowledgerName string `gorm:"type:varchar(255)"`
 	}
 )
 
@@ -37,7 +37,7 @@ var ConvertIncidentsMeta = plugin.SubTaskMeta{
 	Name:             "convertIncidents",
 	EntryPoint:       ConvertIncidents,
 	EnabledByDefault: true,
-	Description:      "Convert incidents into domain layer table issues",
+	Description:      "Convert incidents into domain layer table issues and incident_timeline_events",
 	DomainTypes:      []string{plugin.DOMAIN_TYPE_TICKET},
 }
 
@@ -122,6 +122,17 @@ func ConvertIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 				IssueId: domainIssue.Id,
 			}
 			result = append(result, boardIssue)
+			result = append(result, ticket.NewIncidentTimelineEvents(domainIssue.Id,
+				ticket.IncidentMilestone{EventType: ticket.INCIDENT_DETECTED, EventDate: &incident.CreatedDate, OriginalEvent: "triggered"},
+				ticket.IncidentMilestone{
+					EventType:     ticket.INCIDENT_ACKNOWLEDGED,
+					EventDate:     incident.AcknowledgedDate,
+					OriginalEvent: string(models.IncidentStatusAcknowledged),
+					ActorId:       incident.AcknowledgerId,
+					ActorName:     incident.AcknowledgerName,
+				},
+				ticket.IncidentMilestone{EventType: ticket.INCIDENT_RESOLVED, EventDate: resolutionDate, OriginalEvent: string(incident.Status)},
+			)...)
 			return result, nil
 		},
 	})
@@ -71,6 +71,20 @@ func ExtractIncidents(taskCtx plugin.SubTaskContext) errors.Error {
 			if incidentRaw.Priority != nil {
 				incident.Priority = *incidentRaw.Priority.Name
 			}
+			for _, acknowledgementRaw := range incidentRaw.Acknowledgements {
+				if acknowledgementRaw.At == nil {
+					continue
+				}
+				if incident.AcknowledgedDate == nil || acknowledgementRaw.At.Before(*incident.AcknowledgedDate) {
+					incident.AcknowledgedDate = acknowledgementRaw.At
+					incident.AcknowledgerId = ""
+					incident.AcknowledgerName = ""
+					if acknowledgementRaw.Acknowledger != nil {
+						incident.AcknowledgerId = resolve(acknowledgementRaw.Acknowledger.Id)
+						incident.AcknowledgerName = resolve(acknowledgementRaw.Acknowledger.Summary)
+					}
+				}
+			}
 			for _, assignmentRaw := range incidentRaw.Assignments {
 				userRaw := assignmentRaw.Assignee
 				results = append(results, &models.Assignment{
@@ -0,0 +1,229 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/refdiff/tasks"
+)
+
+const (
+	defaultDiffLimit = 500
+	maxDiffLimit     = 5000
+	// diffTimeout bounds the time spent on building the commit graph, the api answers synchronously
+	diffTimeout = 30 * time.Second
+)
+
+var shaPattern = regexp.MustCompile(`^[0-9a-f]{40}$`)
+
+type RefDiffCommit struct {
+	Sha          string    `json:"sha"`
+	Message      string    `json:"message"`
+	AuthorName   string    `json:"authorName"`
+	AuthorEmail  string    `json:"authorEmail"`
+	AuthoredDate time.Time `json:"authoredDate"`
+}
+
+type RefDiffPullRequest struct {
+	Id             string     `json:"id"`
+	PullRequestKey int        `json:"pullRequestKey"`
+	Title          string     `json:"title"`
+	Url            string     `json:"url"`
+	AuthorName     string     `json:"authorName"`
+	MergedDate     *time.Time `json:"mergedDate"`
+	MergeCommitSha string     `json:"mergeCommitSha"`
+}
+
+type RefDiffResult struct {
+	RepoId       string                `json:"repoId"`
+	NewRef       string                `json:"newRef"`
+	OldRef       string                `json:"oldRef"`
+	NewCommitSha string                `json:"newCommitSha"`
+	OldCommitSha string                `json:"oldCommitSha"`
+	CommitsCount int                   `json:"commitsCount"`
+	Truncated    bool                  `json:"truncated"`
+	Commits      []*RefDiffCommit      `json:"commits"`
+	PullRequests []*RefDiffPullRequest `json:"pullRequests"`
+}
+
+// GetRefDiff
+// @Summary calculate the commits and pull requests between two refs
+// @Description Calculate on demand the commits reachable from newRef but not from oldRef and the pull requests merged by them,
+// @Description i.e. for generating release notes. Refs can be names of branches/tags (refs/tags/v1.0) or full commit shas.
+// @Description Nothing is persisted, use the refdiff plugin in a pipeline to store the diffs.
+// @Tags plugins/refdiff
+// @Param repoId query string true "repo id"
+// @Param newRef query string true "new ref"
+// @Param oldRef query string true "old ref"
+// @Param limit query int false "max number of commits to return, 500 by default and 5000 at most"
+// @Success 200  {object} RefDiffResult
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /plugins/refdiff/diff [GET]
+func GetRefDiff(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	repoId := input.Query.Get("repoId")
+	newRef := input.Query.Get("newRef")
+	oldRef := input.Query.Get("oldRef")
+	if repoId == "" || newRef == "" || oldRef == "" {
+		return nil, errors.BadInput.New("repoId, newRef and oldRef are required")
+	}
+	limit, err := parseLimit(input.Query.Get("limit"))
+	if err != nil {
+		return nil, err
+	}
+	ctx := context.Background()
+	if input.Request != nil {
+		ctx = input.Request.Context()
+	}
+	ctx, cancel := context.WithTimeout(ctx, diffTimeout)
+	defer cancel()
+
+	db := basicRes.GetDal()
+	result := &RefDiffResult{RepoId: repoId, NewRef: newRef, OldRef: oldRef}
+	if result.NewCommitSha, err = resolveRef(db, repoId, newRef); err != nil {
+		return nil, err
+	}
+	if result.OldCommitSha, err = resolveRef(db, repoId, oldRef); err != nil {
+		return nil, err
+	}
+	graph, err := tasks.LoadCommitNodeGraph(ctx, db, repoId)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "failed to load the commit graph, the repo might be too large to diff on demand")
+	}
+	shas, _, _ := graph.CalculateLostSha(result.OldCommitSha, result.NewCommitSha)
+	result.CommitsCount = len(shas)
+	if len(shas) > limit {
+		shas = shas[:limit]
+		result.Truncated = true
+	}
+	if result.Commits, err = loadCommits(db, shas); err != nil {
+		return nil, err
+	}
+	if result.PullRequests, err = loadPullRequests(db, repoId, shas); err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: result, Status: http.StatusOK}, nil
+}
+
+func parseLimit(s string) (int, errors.Error) {
+	if s == "" {
+		return defaultDiffLimit, nil
+	}
+	limit, err := strconv.Atoi(s)
+	if err != nil || limit <= 0 {
+		return 0, errors.BadInput.New("limit must be a positive integer")
+	}
+	if limit > maxDiffLimit {
+		limit = maxDiffLimit
+	}
+	return limit, nil
+}
+
+// resolveRef finds the commit sha of the ref, which could be the name of a branch/tag or a commit sha
+func resolveRef(db dal.Dal, repoId string, refName string) (string, errors.Error) {
+	ref := &code.Ref{}
+	err := db.First(ref, dal.Where("id = ? OR (repo_id = ? AND name = ?)", fmt.Sprintf("%s:%s", repoId, refName), repoId, refName))
+	if err == nil {
+		return ref.CommitSha, nil
+	}
+	if !db.IsErrorNotFound(err) {
+		return "", err
+	}
+	if shaPattern.MatchString(refName) {
+		count, err := db.Count(dal.From(&code.RepoCommit{}), dal.Where("repo_id = ? AND commit_sha = ?", repoId, refName))
+		if err != nil {
+			return "", err
+		}
+		if count > 0 {
+			return refName, nil
+		}
+	}
+	return "", errors.NotFound.New(fmt.Sprintf("ref %s not found in repo %s", refName, repoId))
+}
+
+// loadCommits loads the commits in the order of `shas`
+func loadCommits(db dal.Dal, shas []string) ([]*RefDiffCommit, errors.Error) {
+	result := make([]*RefDiffCommit, 0, len(shas))
+	if len(shas) == 0 {
+		return result, nil
+	}
+	var commits []*code.Commit
+	err := db.All(&commits, dal.Where("sha IN ?", shas))
+	if err != nil {
+		return nil, err
+	}
+	bySha := make(map[string]*code.Commit, len(commits))
+	for _, commit := range commits {
+		bySha[commit.Sha] = commit
+	}
+	for _, sha := range shas {
+		commit, ok := bySha[sha]
+		if !ok {
+			result = append(result, &RefDiffCommit{Sha: sha})
+			continue
+		}
+		result = append(result, &RefDiffCommit{
+			Sha:          commit.Sha,
+			Message:      commit.Message,
+			AuthorName:   commit.AuthorName,
+			AuthorEmail:  commit.AuthorEmail,
+			AuthoredDate: commit.AuthoredDate,
+		})
+	}
+	return result, nil
+}
+
+// loadPullRequests loads the pull requests of the repo merged by any of the commits
+func loadPullRequests(db dal.Dal, repoId string, shas []string) ([]*RefDiffPullRequest, errors.Error) {
+	result := make([]*RefDiffPullRequest, 0)
+	if len(shas) == 0 {
+		return result, nil
+	}
+	var prs []*code.PullRequest
+	err := db.All(
+		&prs,
+		dal.Where("base_repo_id = ? AND merge_commit_sha IN ?", repoId, shas),
+		dal.Orderby("merged_date"),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, pr := range prs {
+		result = append(result, &RefDiffPullRequest{
+			Id:             pr.Id,
+			PullRequestKey: pr.PullRequestKey,
+			Title:          pr.Title,
+			Url:            pr.Url,
+			AuthorName:     pr.AuthorName,
+			MergedDate:     pr.MergedDate,
+			MergeCommitSha: pr.MergeCommitSha,
+		})
+	}
+	return result, nil
+}
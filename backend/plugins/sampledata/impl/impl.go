@@ -0,0 +1,73 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package impl
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sampledata/tasks"
+)
+
+// make sure interface is implemented
+var _ interface {
+	plugin.PluginMeta
+	plugin.PluginTask
+	plugin.PluginModel
+} = (*SampleData)(nil)
+
+// SampleData simulates teams working on a project so DevLake can be demoed without connecting real tools
+type SampleData struct{}
+
+func (p SampleData) GetTablesInfo() []dal.Tabler {
+	return []dal.Tabler{}
+}
+
+func (p SampleData) Description() string {
+	return "Generate synthetic data of configurable team profiles for demo purposes"
+}
+
+func (p SampleData) Name() string {
+	return "sampledata"
+}
+
+func (p SampleData) SubTaskMetas() []plugin.SubTaskMeta {
+	return []plugin.SubTaskMeta{
+		tasks.GenerateSampleDataMeta,
+	}
+}
+
+func (p SampleData) PrepareTaskData(taskCtx plugin.TaskContext, options map[string]interface{}) (interface{}, errors.Error) {
+	var op tasks.SampleDataOptions
+	err := helper.Decode(options, &op, nil)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "could not decode options")
+	}
+	err = op.Normalize()
+	if err != nil {
+		return nil, err
+	}
+	return &tasks.SampleDataTaskData{
+		Options: &op,
+	}, nil
+}
+
+func (p SampleData) RootPkgPath() string {
+	return "github.com/apache/incubator-devlake/plugins/sampledata"
+}
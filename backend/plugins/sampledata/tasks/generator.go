@@ -0,0 +1,452 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"crypto/sha1"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/models/domainlayer"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// RAW_SAMPLE_DATA_TABLE doesn't exist, it only marks the generated records so they can be replaced by the next run
+const RAW_SAMPLE_DATA_TABLE = "_raw_sampledata"
+
+var services = []string{"api", "web", "worker", "gateway", "billing", "search", "auth", "notifier"}
+
+// dataset holds the generated records, rows are saved via BatchSaveDivider while commitsDiffs
+// carry no RawDataOrigin and have to be handled separately
+type dataset struct {
+	rows         []interface{}
+	commitsDiffs []*code.CommitsDiff
+}
+
+type generator struct {
+	rng     *rand.Rand
+	project string
+	origin  common.RawDataOrigin
+	start   time.Time
+	end     time.Time
+	out     *dataset
+}
+
+type sampleRepo struct {
+	team       TeamProfile
+	name       string
+	id         string
+	url        string
+	scopeId    string
+	boardId    string
+	developers []*crossdomain.Account
+}
+
+type samplePr struct {
+	pr   *code.PullRequest
+	shas []string
+}
+
+// generate simulates `op.Days` days of activity until `end` for all teams, the output is fully determined by the options
+func generate(op *SampleDataOptions, params string, end time.Time) *dataset {
+	end = end.Truncate(time.Hour)
+	g := &generator{
+		rng:     rand.New(rand.NewSource(op.Seed)),
+		project: op.ProjectName,
+		origin:  common.RawDataOrigin{RawDataTable: RAW_SAMPLE_DATA_TABLE, RawDataParams: params},
+		start:   end.AddDate(0, 0, -op.Days),
+		end:     end,
+		out:     &dataset{},
+	}
+	for _, team := range op.Teams {
+		g.generateTeam(team)
+	}
+	return g.out
+}
+
+func (g *generator) id(entity string, keys ...interface{}) string {
+	id := fmt.Sprintf("sampledata:%s:%s", entity, g.project)
+	for _, key := range keys {
+		id += fmt.Sprintf(":%v", key)
+	}
+	return id
+}
+
+func (g *generator) sha(keys ...interface{}) string {
+	return fmt.Sprintf("%x", sha1.Sum([]byte(g.id("sha", keys...))))
+}
+
+func (g *generator) entity(id string) domainlayer.DomainEntity {
+	return domainlayer.DomainEntity{Id: id, NoPKModel: common.NoPKModel{RawDataOrigin: g.origin}}
+}
+
+func (g *generator) mapProject(table string, rowId string) {
+	g.out.rows = append(g.out.rows, &crossdomain.ProjectMapping{
+		ProjectName: g.project,
+		Table:       table,
+		RowId:       rowId,
+		NoPKModel:   common.NoPKModel{RawDataOrigin: g.origin},
+	})
+}
+
+// count turns an average rate into an integer, the fraction decides the odds of one more
+func (g *generator) count(rate float64) int {
+	n := int(rate)
+	if g.rng.Float64() < rate-float64(n) {
+		n++
+	}
+	return n
+}
+
+// workingTime picks a random moment within the working hours of the day
+func (g *generator) workingTime(day time.Time) time.Time {
+	return day.Add(time.Duration(9*60+g.rng.Intn(9*60)) * time.Minute)
+}
+
+// exponential returns a duration with exponential distribution and the given mean, but not shorter than `min`
+func (g *generator) exponential(meanHours float64, min time.Duration) time.Duration {
+	d := time.Duration(g.rng.ExpFloat64() * meanHours * float64(time.Hour))
+	if d < min {
+		return min
+	}
+	return d
+}
+
+func (g *generator) generateTeam(team TeamProfile) {
+	boardId := g.id("Board", team.Name)
+	g.out.rows = append(g.out.rows, &ticket.Board{
+		DomainEntity: g.entity(boardId),
+		Name:         team.Name,
+		Description:  fmt.Sprintf("Simulated board of team %s (%s)", team.Name, team.Preset),
+		Url:          fmt.Sprintf("https://tickets.example.com/%s/%s", g.project, team.Name),
+		CreatedDate:  &g.start,
+	})
+	g.mapProject("boards", boardId)
+
+	developers := make([]*crossdomain.Account, team.Developers)
+	for i := range developers {
+		userName := fmt.Sprintf("%s-dev-%d", team.Name, i+1)
+		developers[i] = &crossdomain.Account{
+			DomainEntity: g.entity(g.id("Account", userName)),
+			Email:        fmt.Sprintf("%s@example.com", userName),
+			FullName:     fmt.Sprintf("Developer %d of %s", i+1, team.Name),
+			UserName:     userName,
+			Organization: team.Name,
+			CreatedDate:  &g.start,
+		}
+		g.out.rows = append(g.out.rows, developers[i])
+	}
+	for i := 0; i < team.Repos; i++ {
+		name := fmt.Sprintf("%s-%s", team.Name, services[i%len(services)])
+		if i >= len(services) {
+			name = fmt.Sprintf("%s%d", name, i/len(services)+1)
+		}
+		g.generateRepo(&sampleRepo{
+			team:       team,
+			name:       name,
+			id:         g.id("Repo", name),
+			url:        fmt.Sprintf("https://git.example.com/%s/%s", g.project, name),
+			scopeId:    g.id("CicdScope", name),
+			boardId:    boardId,
+			developers: developers,
+		})
+	}
+}
+
+func (g *generator) generateRepo(repo *sampleRepo) {
+	g.out.rows = append(g.out.rows,
+		&code.Repo{
+			DomainEntity: g.entity(repo.id),
+			Name:         repo.name,
+			Url:          repo.url,
+			Description:  fmt.Sprintf("Simulated service of team %s", repo.team.Name),
+			Language:     "Go",
+			CreatedDate:  &g.start,
+			UpdatedDate:  &g.end,
+		},
+		&devops.CicdScope{
+			DomainEntity: g.entity(repo.scopeId),
+			Name:         repo.name,
+			Url:          repo.url + "/pipelines",
+			CreatedDate:  &g.start,
+			UpdatedDate:  &g.end,
+		},
+	)
+	g.mapProject("repos", repo.id)
+	g.mapProject("cicd_scopes", repo.scopeId)
+
+	// work happens on weekdays only, so the weekly rates are spread over 5 days
+	prRate := repo.team.PullRequestsPerWeek / 5 / float64(repo.team.Repos)
+	deployRate := repo.team.DeploymentsPerWeek / 5 / float64(repo.team.Repos)
+	var pending []*samplePr
+	prKey, deployKey, lastDeployedSha := 0, 0, ""
+	for day := g.start.Truncate(24 * time.Hour); day.Before(g.end); day = day.AddDate(0, 0, 1) {
+		if day.Weekday() == time.Saturday || day.Weekday() == time.Sunday {
+			continue
+		}
+		for n := g.count(prRate); n > 0; n-- {
+			prKey++
+			if pr := g.generatePullRequest(repo, prKey, g.workingTime(day)); pr != nil {
+				pending = append(pending, pr)
+			}
+		}
+		for n := g.count(deployRate); n > 0; n-- {
+			deployedAt := g.workingTime(day)
+			if deployedAt.After(g.end) {
+				break
+			}
+			var shipped []*samplePr
+			shipped, pending = partitionMerged(pending, deployedAt)
+			if len(shipped) == 0 {
+				continue
+			}
+			deployKey++
+			lastDeployedSha = g.generateDeployment(repo, deployKey, deployedAt, shipped, lastDeployedSha)
+		}
+	}
+}
+
+// partitionMerged splits the pull requests into the ones merged before `t` and the rest,
+// pull requests closed without merging are dropped
+func partitionMerged(prs []*samplePr, t time.Time) (merged []*samplePr, rest []*samplePr) {
+	for _, pr := range prs {
+		switch {
+		case pr.pr.MergedDate != nil && !pr.pr.MergedDate.After(t):
+			merged = append(merged, pr)
+		case pr.pr.Status != code.CLOSED:
+			rest = append(rest, pr)
+		}
+	}
+	return
+}
+
+func (g *generator) addCommit(repo *sampleRepo, sha string, message string, author *crossdomain.Account, date time.Time) {
+	g.out.rows = append(g.out.rows,
+		&code.Commit{
+			NoPKModel:      common.NoPKModel{RawDataOrigin: g.origin},
+			Sha:            sha,
+			Additions:      1 + g.rng.Intn(300),
+			Deletions:      g.rng.Intn(100),
+			Message:        message,
+			AuthorName:     author.UserName,
+			AuthorEmail:    author.Email,
+			AuthoredDate:   date,
+			AuthorId:       author.Id,
+			CommitterName:  author.UserName,
+			CommitterEmail: author.Email,
+			CommittedDate:  date,
+			CommitterId:    author.Id,
+		},
+		&code.RepoCommit{
+			RepoId:    repo.id,
+			CommitSha: sha,
+			NoPKModel: common.NoPKModel{RawDataOrigin: g.origin},
+		},
+	)
+}
+
+func (g *generator) generatePullRequest(repo *sampleRepo, key int, createdDate time.Time) *samplePr {
+	if createdDate.After(g.end) {
+		return nil
+	}
+	author := repo.developers[g.rng.Intn(len(repo.developers))]
+	prId := g.id("PullRequest", repo.name, key)
+	pr := &code.PullRequest{
+		DomainEntity:   g.entity(prId),
+		BaseRepoId:     repo.id,
+		HeadRepoId:     repo.id,
+		Status:         code.OPEN,
+		OriginalStatus: "open",
+		Title:          fmt.Sprintf("Change #%d of %s", key, repo.name),
+		Url:            fmt.Sprintf("%s/pulls/%d", repo.url, key),
+		AuthorName:     author.UserName,
+		AuthorId:       author.Id,
+		PullRequestKey: key,
+		CreatedDate:    createdDate,
+		HeadRef:        fmt.Sprintf("feature/%d", key),
+		BaseRef:        "main",
+	}
+	g.out.rows = append(g.out.rows, pr)
+	sample := &samplePr{pr: pr}
+	commitCount := 1 + g.rng.Intn(3)
+	for i := 0; i < commitCount; i++ {
+		sha := g.sha(repo.name, key, i)
+		authoredDate := createdDate.Add(-time.Duration(commitCount-i) * time.Duration(1+g.rng.Intn(240)) * time.Minute)
+		g.addCommit(repo, sha, pr.Title, author, authoredDate)
+		g.out.rows = append(g.out.rows, &code.PullRequestCommit{
+			CommitSha:          sha,
+			PullRequestId:      prId,
+			CommitAuthorName:   author.UserName,
+			CommitAuthorEmail:  author.Email,
+			CommitAuthoredDate: authoredDate,
+			NoPKModel:          common.NoPKModel{RawDataOrigin: g.origin},
+		})
+		sample.shas = append(sample.shas, sha)
+	}
+	pr.HeadCommitSha = sample.shas[len(sample.shas)-1]
+	g.generateBuild(repo, key, pr.HeadCommitSha, createdDate)
+
+	closedDate := createdDate.Add(g.exponential(repo.team.HoursToMerge, 10*time.Minute))
+	if closedDate.After(g.end) {
+		return sample
+	}
+	pr.ClosedDate = &closedDate
+	// one out of ten pull requests gets abandoned
+	if g.rng.Intn(10) == 0 {
+		pr.Status = code.CLOSED
+		pr.OriginalStatus = "closed"
+		return sample
+	}
+	pr.Status = code.MERGED
+	pr.OriginalStatus = "merged"
+	pr.MergedDate = &closedDate
+	pr.MergeCommitSha = g.sha(repo.name, key, "merge")
+	g.addCommit(repo, pr.MergeCommitSha, fmt.Sprintf("Merge pull request #%d", key), author, closedDate)
+	sample.shas = append(sample.shas, pr.MergeCommitSha)
+	return sample
+}
+
+// generateBuild adds the CI pipeline triggered by the pull request, a few of them fail and get retried
+func (g *generator) generateBuild(repo *sampleRepo, key int, sha string, createdDate time.Time) {
+	for attempt := 1; ; attempt++ {
+		startedDate := createdDate.Add(time.Duration(attempt*10) * time.Minute)
+		duration := time.Duration(5+g.rng.Intn(10)) * time.Minute
+		finishedDate := startedDate.Add(duration)
+		result := devops.RESULT_SUCCESS
+		if attempt < 3 && g.rng.Intn(10) == 0 {
+			result = devops.RESULT_FAILURE
+		}
+		g.out.rows = append(g.out.rows, &devops.CICDPipeline{
+			DomainEntity:   g.entity(g.id("CICDPipeline", repo.name, "build", key, attempt)),
+			Name:           fmt.Sprintf("build #%d", key),
+			Result:         result,
+			Status:         devops.STATUS_DONE,
+			OriginalResult: result,
+			OriginalStatus: devops.STATUS_DONE,
+			Type:           devops.BUILD,
+			DurationSec:    duration.Seconds(),
+			TaskDatesInfo: devops.TaskDatesInfo{
+				CreatedDate:  startedDate,
+				StartedDate:  &startedDate,
+				FinishedDate: &finishedDate,
+			},
+			CicdScopeId: repo.scopeId,
+		})
+		if result == devops.RESULT_SUCCESS {
+			return
+		}
+	}
+}
+
+// generateDeployment ships the merged pull requests to production, it returns the deployed commit sha
+// and may cause an incident according to the team's change failure rate
+func (g *generator) generateDeployment(repo *sampleRepo, key int, deployedAt time.Time, shipped []*samplePr, prevSha string) string {
+	deploymentId := g.id("CICDDeployment", repo.name, key)
+	head := shipped[0].pr
+	for _, s := range shipped[1:] {
+		if s.pr.MergedDate.After(*head.MergedDate) {
+			head = s.pr
+		}
+	}
+	duration := float64(60 + g.rng.Intn(600))
+	finishedDate := deployedAt.Add(time.Duration(duration) * time.Second)
+	deploymentCommit := &devops.CicdDeploymentCommit{
+		DomainEntity:     g.entity(deploymentId),
+		CicdScopeId:      repo.scopeId,
+		CicdDeploymentId: deploymentId,
+		Name:             fmt.Sprintf("deploy #%d", key),
+		Result:           devops.RESULT_SUCCESS,
+		Status:           devops.STATUS_DONE,
+		OriginalResult:   devops.RESULT_SUCCESS,
+		OriginalStatus:   devops.STATUS_DONE,
+		Environment:      devops.PRODUCTION,
+		TaskDatesInfo: devops.TaskDatesInfo{
+			CreatedDate:  deployedAt,
+			StartedDate:  &deployedAt,
+			FinishedDate: &finishedDate,
+		},
+		DurationSec: &duration,
+		CommitSha:   head.MergeCommitSha,
+		CommitMsg:   fmt.Sprintf("Merge pull request #%d", head.PullRequestKey),
+		RefName:     "main",
+		RepoId:      repo.id,
+		RepoUrl:     repo.url,
+	}
+	deployment := deploymentCommit.ToDeployment()
+	deployment.RawDataOrigin = g.origin
+	g.out.rows = append(g.out.rows, deploymentCommit, deployment)
+	// refdiff can't run against the simulated repos, so the diffs between deployments are generated as well
+	index := 0
+	for _, s := range shipped {
+		for _, sha := range s.shas {
+			index++
+			g.out.commitsDiffs = append(g.out.commitsDiffs, &code.CommitsDiff{
+				NewCommitSha: head.MergeCommitSha,
+				OldCommitSha: prevSha,
+				CommitSha:    sha,
+				SortingIndex: index,
+			})
+		}
+	}
+
+	if g.rng.Float64() < repo.team.ChangeFailureRate {
+		g.generateIncident(repo, key, finishedDate)
+	}
+	return head.MergeCommitSha
+}
+
+func (g *generator) generateIncident(repo *sampleRepo, key int, deployedAt time.Time) {
+	createdDate := deployedAt.Add(time.Duration(5+g.rng.Intn(120)) * time.Minute)
+	if createdDate.After(g.end) {
+		return
+	}
+	issueId := g.id("Issue", repo.name, key)
+	issue := &ticket.Issue{
+		DomainEntity:   g.entity(issueId),
+		Url:            fmt.Sprintf("https://tickets.example.com/%s/%s/incidents/%d", g.project, repo.team.Name, key),
+		IssueKey:       fmt.Sprintf("INC-%s-%d", repo.name, key),
+		Title:          fmt.Sprintf("%s degraded after deploy #%d", repo.name, key),
+		Type:           ticket.INCIDENT,
+		OriginalType:   "incident",
+		Status:         ticket.IN_PROGRESS,
+		OriginalStatus: "open",
+		CreatedDate:    &createdDate,
+		UpdatedDate:    &createdDate,
+		Priority:       "P2",
+		Severity:       "major",
+		Component:      repo.name,
+	}
+	resolutionDate := createdDate.Add(g.exponential(repo.team.HoursToRestore, 5*time.Minute))
+	if !resolutionDate.After(g.end) {
+		issue.Status = ticket.DONE
+		issue.OriginalStatus = "resolved"
+		issue.ResolutionDate = &resolutionDate
+		issue.UpdatedDate = &resolutionDate
+		issue.LeadTimeMinutes = int64(resolutionDate.Sub(createdDate).Minutes())
+	}
+	g.out.rows = append(g.out.rows, issue, &ticket.BoardIssue{
+		BoardId:   repo.boardId,
+		IssueId:   issueId,
+		NoPKModel: common.NoPKModel{RawDataOrigin: g.origin},
+	})
+}
@@ -0,0 +1,95 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNormalize(t *testing.T) {
+	op := &SampleDataOptions{ProjectName: "demo"}
+	assert.Nil(t, op.Normalize())
+	assert.Equal(t, 90, op.Days)
+	assert.Len(t, op.Teams, 2)
+	assert.Equal(t, Presets[PRESET_ELITE].DeploymentsPerWeek, op.Teams[0].DeploymentsPerWeek)
+
+	op = &SampleDataOptions{ProjectName: "demo", Teams: []TeamProfile{{Name: "core", Repos: 3}}}
+	assert.Nil(t, op.Normalize())
+	assert.Equal(t, PRESET_HIGH, op.Teams[0].Preset)
+	assert.Equal(t, 3, op.Teams[0].Repos)
+	assert.Equal(t, Presets[PRESET_HIGH].HoursToMerge, op.Teams[0].HoursToMerge)
+
+	assert.NotNil(t, (&SampleDataOptions{}).Normalize())
+	assert.NotNil(t, (&SampleDataOptions{ProjectName: "demo", Teams: []TeamProfile{{Name: "a", Preset: "unknown"}}}).Normalize())
+	assert.NotNil(t, (&SampleDataOptions{ProjectName: "demo", Teams: []TeamProfile{{Name: "a"}, {Name: "a"}}}).Normalize())
+	assert.NotNil(t, (&SampleDataOptions{ProjectName: "demo", Teams: []TeamProfile{{Name: "a", ChangeFailureRate: 2}}}).Normalize())
+}
+
+func TestGenerate(t *testing.T) {
+	op := &SampleDataOptions{ProjectName: "demo", Seed: 42}
+	assert.Nil(t, op.Normalize())
+	end := time.Date(2023, 12, 1, 0, 0, 0, 0, time.UTC)
+	start := end.AddDate(0, 0, -op.Days)
+	ds := generate(op, `{"ProjectName":"demo"}`, end)
+
+	// the same seed must produce the same data
+	assert.Equal(t, ds, generate(op, `{"ProjectName":"demo"}`, end))
+
+	var prs []*code.PullRequest
+	var deployments []*devops.CICDDeployment
+	var deployedShas = make(map[string]bool)
+	var incidents []*ticket.Issue
+	for _, row := range ds.rows {
+		switch r := row.(type) {
+		case *code.PullRequest:
+			prs = append(prs, r)
+			assert.False(t, r.CreatedDate.Before(start))
+			assert.False(t, r.CreatedDate.After(end))
+			if r.Status == code.MERGED {
+				assert.NotEmpty(t, r.MergeCommitSha)
+				assert.True(t, r.MergedDate.After(r.CreatedDate))
+			}
+		case *devops.CICDDeployment:
+			deployments = append(deployments, r)
+			assert.Equal(t, RAW_SAMPLE_DATA_TABLE, r.RawDataTable)
+			assert.Equal(t, devops.PRODUCTION, r.Environment)
+		case *devops.CicdDeploymentCommit:
+			deployedShas[r.CommitSha] = true
+		case *ticket.Issue:
+			incidents = append(incidents, r)
+			assert.Equal(t, ticket.INCIDENT, r.Type)
+		}
+	}
+	assert.NotEmpty(t, prs)
+	assert.NotEmpty(t, deployments)
+	assert.NotEmpty(t, incidents)
+	// the elite team should ship a lot more often than the medium one
+	assert.Greater(t, len(deployments), op.Days/7*int(Presets[PRESET_ELITE].DeploymentsPerWeek)/2)
+	for _, diff := range ds.commitsDiffs {
+		assert.True(t, deployedShas[diff.NewCommitSha])
+	}
+
+	other := generate(&SampleDataOptions{ProjectName: "demo", Seed: 7, Days: op.Days, Teams: op.Teams}, "", end)
+	assert.NotEqual(t, ds.rows, other.rows)
+}
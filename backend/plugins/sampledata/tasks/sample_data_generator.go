@@ -0,0 +1,117 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"reflect"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+var GenerateSampleDataMeta = plugin.SubTaskMeta{
+	Name:             "generateSampleData",
+	EntryPoint:       GenerateSampleData,
+	EnabledByDefault: true,
+	Description:      "Generate synthetic repos, pull requests, pipelines, deployments and incidents for the project",
+	DomainTypes:      plugin.DOMAIN_TYPES,
+}
+
+// sampleDataTypes lists every type the generator produces, so records of the previous run get deleted
+// even if the current run doesn't produce any of them
+var sampleDataTypes = []interface{}{
+	&crossdomain.ProjectMapping{},
+	&crossdomain.Account{},
+	&code.Repo{},
+	&code.Commit{},
+	&code.RepoCommit{},
+	&code.PullRequest{},
+	&code.PullRequestCommit{},
+	&devops.CicdScope{},
+	&devops.CICDPipeline{},
+	&devops.CICDDeployment{},
+	&devops.CicdDeploymentCommit{},
+	&ticket.Board{},
+	&ticket.Issue{},
+	&ticket.BoardIssue{},
+}
+
+// GenerateSampleData replaces the records generated by the previous run of the same project with a fresh simulation
+func GenerateSampleData(taskCtx plugin.SubTaskContext) errors.Error {
+	db := taskCtx.GetDal()
+	data := taskCtx.GetData().(*SampleDataTaskData)
+	params, e := json.Marshal(struct{ ProjectName string }{data.Options.ProjectName})
+	if e != nil {
+		return errors.Default.Wrap(e, "failed to marshal params")
+	}
+
+	// commits_diffs carry no RawDataOrigin, find the outdated ones through the deployments they belong to
+	err := db.Exec(
+		`DELETE FROM commits_diffs WHERE new_commit_sha IN (
+			SELECT commit_sha FROM cicd_deployment_commits WHERE _raw_data_table = ? AND _raw_data_params = ?
+		)`,
+		RAW_SAMPLE_DATA_TABLE, string(params),
+	)
+	if err != nil {
+		return err
+	}
+
+	divider := api.NewBatchSaveDivider(taskCtx, 500, RAW_SAMPLE_DATA_TABLE, string(params))
+	for _, row := range sampleDataTypes {
+		if _, err = divider.ForType(reflect.TypeOf(row)); err != nil {
+			return err
+		}
+	}
+	ds := generate(data.Options, string(params), time.Now())
+	taskCtx.SetProgress(0, len(ds.rows))
+	for _, row := range ds.rows {
+		batch, err := divider.ForType(reflect.TypeOf(row))
+		if err != nil {
+			return err
+		}
+		if err = batch.Add(row); err != nil {
+			return err
+		}
+		taskCtx.IncProgress(1)
+	}
+	if err = divider.Close(); err != nil {
+		return err
+	}
+
+	diffs := make([]*code.CommitsDiff, 0, 500)
+	for _, diff := range ds.commitsDiffs {
+		diffs = append(diffs, diff)
+		if len(diffs) == cap(diffs) {
+			if err = db.CreateOrUpdate(diffs); err != nil {
+				return err
+			}
+			diffs = diffs[:0]
+		}
+	}
+	if len(diffs) > 0 {
+		return db.CreateOrUpdate(diffs)
+	}
+	return nil
+}
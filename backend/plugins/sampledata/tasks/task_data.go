@@ -0,0 +1,120 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// TeamProfile describes how a simulated team works, zero values are taken from the Preset
+type TeamProfile struct {
+	Name                string  `json:"name"`
+	Preset              string  `json:"preset"`
+	Repos               int     `json:"repos"`
+	Developers          int     `json:"developers"`
+	PullRequestsPerWeek float64 `json:"pullRequestsPerWeek"`
+	DeploymentsPerWeek  float64 `json:"deploymentsPerWeek"`
+	ChangeFailureRate   float64 `json:"changeFailureRate"`
+	HoursToMerge        float64 `json:"hoursToMerge"`
+	HoursToRestore      float64 `json:"hoursToRestore"`
+}
+
+const (
+	PRESET_ELITE  = "elite"
+	PRESET_HIGH   = "high"
+	PRESET_MEDIUM = "medium"
+	PRESET_LOW    = "low"
+)
+
+// Presets roughly follow the DORA performance levels
+var Presets = map[string]TeamProfile{
+	PRESET_ELITE:  {Repos: 2, Developers: 6, PullRequestsPerWeek: 25, DeploymentsPerWeek: 20, ChangeFailureRate: 0.03, HoursToMerge: 6, HoursToRestore: 0.5},
+	PRESET_HIGH:   {Repos: 2, Developers: 5, PullRequestsPerWeek: 15, DeploymentsPerWeek: 5, ChangeFailureRate: 0.1, HoursToMerge: 24, HoursToRestore: 8},
+	PRESET_MEDIUM: {Repos: 1, Developers: 4, PullRequestsPerWeek: 8, DeploymentsPerWeek: 1, ChangeFailureRate: 0.2, HoursToMerge: 72, HoursToRestore: 48},
+	PRESET_LOW:    {Repos: 1, Developers: 3, PullRequestsPerWeek: 4, DeploymentsPerWeek: 0.25, ChangeFailureRate: 0.4, HoursToMerge: 168, HoursToRestore: 240},
+}
+
+type SampleDataOptions struct {
+	ProjectName string        `json:"projectName" mapstructure:"projectName"`
+	Seed        int64         `json:"seed" mapstructure:"seed"`
+	Days        int           `json:"days" mapstructure:"days"`
+	Teams       []TeamProfile `json:"teams" mapstructure:"teams"`
+}
+
+type SampleDataTaskData struct {
+	Options *SampleDataOptions
+}
+
+// Normalize validates the options and fills in the defaults
+func (op *SampleDataOptions) Normalize() errors.Error {
+	if op.ProjectName == "" {
+		return errors.BadInput.New("projectName is required")
+	}
+	if op.Days <= 0 {
+		op.Days = 90
+	}
+	if len(op.Teams) == 0 {
+		op.Teams = []TeamProfile{
+			{Name: "platform", Preset: PRESET_ELITE},
+			{Name: "payments", Preset: PRESET_MEDIUM},
+		}
+	}
+	names := make(map[string]bool)
+	for i := range op.Teams {
+		team := &op.Teams[i]
+		if team.Name == "" {
+			return errors.BadInput.New("team name is required")
+		}
+		if names[team.Name] {
+			return errors.BadInput.New("duplicated team name " + team.Name)
+		}
+		names[team.Name] = true
+		if team.Preset == "" {
+			team.Preset = PRESET_HIGH
+		}
+		preset, ok := Presets[team.Preset]
+		if !ok {
+			return errors.BadInput.New("unknown preset " + team.Preset)
+		}
+		if team.ChangeFailureRate < 0 || team.ChangeFailureRate > 1 {
+			return errors.BadInput.New("changeFailureRate must be between 0 and 1")
+		}
+		if team.Repos == 0 {
+			team.Repos = preset.Repos
+		}
+		if team.Developers == 0 {
+			team.Developers = preset.Developers
+		}
+		if team.PullRequestsPerWeek == 0 {
+			team.PullRequestsPerWeek = preset.PullRequestsPerWeek
+		}
+		if team.DeploymentsPerWeek == 0 {
+			team.DeploymentsPerWeek = preset.DeploymentsPerWeek
+		}
+		if team.ChangeFailureRate == 0 {
+			team.ChangeFailureRate = preset.ChangeFailureRate
+		}
+		if team.HoursToMerge == 0 {
+			team.HoursToMerge = preset.HoursToMerge
+		}
+		if team.HoursToRestore == 0 {
+			team.HoursToRestore = preset.HoursToRestore
+		}
+	}
+	return nil
+}
@@ -85,6 +85,8 @@ func (p Sonarqube) GetTablesInfo() []dal.Tabler {
 		&models.SonarqubeFileMetrics{},
 		&models.SonarqubeAccount{},
 		&models.SonarqubeScopeConfig{},
+		&models.SonarqubeBranch{},
+		&models.SonarqubePullRequest{},
 	}
 }
 
@@ -100,6 +102,10 @@ func (p Sonarqube) SubTaskMetas() []plugin.SubTaskMeta {
 		tasks.ExtractHotspotsMeta,
 		tasks.CollectAccountsMeta,
 		tasks.ExtractAccountsMeta,
+		tasks.CollectBranchesMeta,
+		tasks.ExtractBranchesMeta,
+		tasks.CollectPullRequestsMeta,
+		tasks.ExtractPullRequestsMeta,
 		tasks.ConvertProjectsMeta,
 		tasks.ConvertIssuesMeta,
 		tasks.ConvertIssueCodeBlocksMeta,
@@ -195,7 +201,9 @@ func (p Sonarqube) ApiResources() map[string]map[string]plugin.ApiResourceHandle
 			"PUT": api.PutScope,
 		},
 		"connections/:connectionId/scopes/:scopeId/latest-sync-state": {
-			"GET": api.GetScopeLatestSyncState,
+			"GET":    api.GetScopeLatestSyncState,
+			"PATCH":  api.PatchScopeLatestSyncState,
+			"DELETE": api.DeleteScopeLatestSyncState,
 		},
 
 		"connections/:connectionId/proxy/rest/*path": {
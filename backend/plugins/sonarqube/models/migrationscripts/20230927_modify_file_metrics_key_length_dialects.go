@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+var _ plugin.MigrationScript = (*modifyFileMetricsKeyLengthDialects)(nil)
+
+// modifyFileMetricsKeyLengthDialects runs modifyFileMetricsKeyLength under the same name and version, the released
+// script is written for mysql and postgres only and the databases supported since then are handled here
+type modifyFileMetricsKeyLengthDialects struct {
+	modifyFileMetricsKeyLength
+}
+
+func (script *modifyFileMetricsKeyLengthDialects) Up(basicRes context.BasicRes) errors.Error {
+	db := basicRes.GetDal()
+	switch db.Dialect() {
+	case "sqlite":
+		// sqlite doesn't enforce the length of varchar
+		return nil
+	case "sqlserver":
+		return modifyFileMetricsKeyLengthSqlServer(db)
+	}
+	return script.modifyFileMetricsKeyLength.Up(basicRes)
+}
+
+// modifyFileMetricsKeyLengthSqlServer widens the column in place, sql server names the primary key after a
+// generated suffix, so the constraint to drop is looked up instead of assuming `<table>_pkey`
+func modifyFileMetricsKeyLengthSqlServer(db dal.Dal) errors.Error {
+	table := fileMetricsKey20230927{}.TableName()
+	var pkNames []string
+	err := db.Pluck("name", &pkNames,
+		dal.From("sys.key_constraints"),
+		dal.Where("type = 'PK' AND parent_object_id = OBJECT_ID(?)", table),
+	)
+	if err != nil {
+		return err
+	}
+	for _, pkName := range pkNames {
+		if err := db.Exec(fmt.Sprintf("ALTER TABLE %s DROP CONSTRAINT [%s]", table, pkName)); err != nil {
+			return err
+		}
+	}
+	if err := db.Exec(fmt.Sprintf("ALTER TABLE %s ALTER COLUMN file_metrics_key varchar(500) NOT NULL", table)); err != nil {
+		return err
+	}
+	return db.Exec(fmt.Sprintf("ALTER TABLE %s ADD PRIMARY KEY (connection_id, file_metrics_key)", table))
+}
@@ -0,0 +1,86 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package migrationscripts
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/migrationscripts/archived"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/helpers/migrationhelper"
+)
+
+var _ plugin.MigrationScript = (*addBranchesAndPullRequests)(nil)
+
+type sonarqubeBranch20231226 struct {
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	ProjectKey        string `gorm:"primaryKey;type:varchar(255)"`
+	Name              string `gorm:"primaryKey;type:varchar(255)"`
+	IsMain            bool
+	Type              string `gorm:"type:varchar(100)"`
+	QualityGateStatus string `gorm:"type:varchar(100)"`
+	Bugs              int
+	Vulnerabilities   int
+	CodeSmells        int
+	AnalysisDate      *time.Time
+	archived.NoPKModel
+}
+
+func (sonarqubeBranch20231226) TableName() string {
+	return "_tool_sonarqube_branches"
+}
+
+type sonarqubePullRequest20231226 struct {
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	ProjectKey        string `gorm:"primaryKey;type:varchar(255)"`
+	PullRequestKey    string `gorm:"primaryKey;type:varchar(255)"`
+	Title             string
+	Branch            string `gorm:"type:varchar(255)"`
+	Base              string `gorm:"type:varchar(255)"`
+	Url               string
+	QualityGateStatus string `gorm:"type:varchar(100)"`
+	Bugs              int
+	Vulnerabilities   int
+	CodeSmells        int
+	AnalysisDate      *time.Time
+	archived.NoPKModel
+}
+
+func (sonarqubePullRequest20231226) TableName() string {
+	return "_tool_sonarqube_pull_requests"
+}
+
+type addBranchesAndPullRequests struct{}
+
+func (*addBranchesAndPullRequests) Up(basicRes context.BasicRes) errors.Error {
+	return migrationhelper.AutoMigrateTables(
+		basicRes,
+		&sonarqubeBranch20231226{},
+		&sonarqubePullRequest20231226{},
+	)
+}
+
+func (*addBranchesAndPullRequests) Version() uint64 {
+	return 20231226000001
+}
+
+func (*addBranchesAndPullRequests) Name() string {
+	return "add _tool_sonarqube_branches and _tool_sonarqube_pull_requests"
+}
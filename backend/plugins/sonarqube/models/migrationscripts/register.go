@@ -27,8 +27,9 @@ func All() []plugin.MigrationScript {
 		new(expandProjectKey20230206),
 		new(addRawParamTableForScope),
 		new(addScopeConfigIdToProject),
-		new(modifyFileMetricsKeyLength),
+		new(modifyFileMetricsKeyLengthDialects),
 		new(modifyComponentLength),
 		new(addSonarQubeScopeConfig20231214),
+		new(addBranchesAndPullRequests),
 	}
 }
@@ -0,0 +1,41 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// SonarqubeBranch is the latest analysis of a branch of the project
+type SonarqubeBranch struct {
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	ProjectKey        string `gorm:"primaryKey;type:varchar(255)"`
+	Name              string `gorm:"primaryKey;type:varchar(255)"`
+	IsMain            bool
+	Type              string `gorm:"type:varchar(100)"`
+	QualityGateStatus string `gorm:"type:varchar(100)"`
+	Bugs              int
+	Vulnerabilities   int
+	CodeSmells        int
+	AnalysisDate      *common.Iso8601Time
+	common.NoPKModel
+}
+
+func (SonarqubeBranch) TableName() string {
+	return "_tool_sonarqube_branches"
+}
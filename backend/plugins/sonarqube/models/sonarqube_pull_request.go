@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package models
+
+import (
+	"github.com/apache/incubator-devlake/core/models/common"
+)
+
+// SonarqubePullRequest is the latest analysis of a pull request decorated by the project
+type SonarqubePullRequest struct {
+	ConnectionId      uint64 `gorm:"primaryKey"`
+	ProjectKey        string `gorm:"primaryKey;type:varchar(255)"`
+	PullRequestKey    string `gorm:"primaryKey;type:varchar(255)"`
+	Title             string
+	Branch            string `gorm:"type:varchar(255)"`
+	Base              string `gorm:"type:varchar(255)"`
+	Url               string
+	QualityGateStatus string `gorm:"type:varchar(100)"`
+	Bugs              int
+	Vulnerabilities   int
+	CodeSmells        int
+	AnalysisDate      *common.Iso8601Time
+	common.NoPKModel
+}
+
+func (SonarqubePullRequest) TableName() string {
+	return "_tool_sonarqube_pull_requests"
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBranchAndPullRequestResponse(t *testing.T) {
+	var branch branchResponse
+	err := json.Unmarshal([]byte(`{
+		"name": "feature/login",
+		"isMain": false,
+		"type": "BRANCH",
+		"status": {"qualityGateStatus": "ERROR", "bugs": 1, "vulnerabilities": 2, "codeSmells": 3},
+		"analysisDate": "2023-12-20T10:00:00+0000"
+	}`), &branch)
+	assert.Nil(t, err)
+	assert.Equal(t, "feature/login", branch.Name)
+	assert.Equal(t, "ERROR", branch.Status.QualityGateStatus)
+	assert.Equal(t, 3, branch.Status.CodeSmells)
+	assert.Equal(t, int64(1703066400), branch.AnalysisDate.ToTime().Unix())
+
+	var pr pullRequestResponse
+	err = json.Unmarshal([]byte(`{
+		"key": "42",
+		"title": "Add login",
+		"branch": "feature/login",
+		"base": "main",
+		"url": "https://github.com/apache/incubator-devlake/pull/42",
+		"status": {"qualityGateStatus": "OK", "bugs": 0, "vulnerabilities": 0, "codeSmells": 5},
+		"analysisDate": "2023-12-20T10:00:00+0000"
+	}`), &pr)
+	assert.Nil(t, err)
+	assert.Equal(t, "42", pr.Key)
+	assert.Equal(t, "main", pr.Base)
+	assert.Equal(t, "OK", pr.Status.QualityGateStatus)
+	assert.Equal(t, 5, pr.Status.CodeSmells)
+}
@@ -0,0 +1,83 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package tasks
+
+import (
+	"encoding/json"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/common"
+	"github.com/apache/incubator-devlake/core/plugin"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/apache/incubator-devlake/plugins/sonarqube/models"
+)
+
+var _ plugin.SubTaskEntryPoint = ExtractPullRequests
+
+type pullRequestResponse struct {
+	Key          string              `json:"key"`
+	Title        string              `json:"title"`
+	Branch       string              `json:"branch"`
+	Base         string              `json:"base"`
+	Url          string              `json:"url"`
+	Status       analysisStatus      `json:"status"`
+	AnalysisDate *common.Iso8601Time `json:"analysisDate"`
+}
+
+func ExtractPullRequests(taskCtx plugin.SubTaskContext) errors.Error {
+	rawDataSubTaskArgs, data := CreateRawDataSubTaskArgs(taskCtx, RAW_PULL_REQUESTS_TABLE)
+
+	extractor, err := helper.NewApiExtractor(helper.ApiExtractorArgs{
+		RawDataSubTaskArgs: *rawDataSubTaskArgs,
+		Extract: func(resData *helper.RawData) ([]interface{}, errors.Error) {
+			var res pullRequestResponse
+			err := errors.Convert(json.Unmarshal(resData.Data, &res))
+			if err != nil {
+				return nil, err
+			}
+			body := &models.SonarqubePullRequest{
+				ConnectionId:      data.Options.ConnectionId,
+				ProjectKey:        data.Options.ProjectKey,
+				PullRequestKey:    res.Key,
+				Title:             res.Title,
+				Branch:            res.Branch,
+				Base:              res.Base,
+				Url:               res.Url,
+				QualityGateStatus: res.Status.QualityGateStatus,
+				Bugs:              res.Status.Bugs,
+				Vulnerabilities:   res.Status.Vulnerabilities,
+				CodeSmells:        res.Status.CodeSmells,
+				AnalysisDate:      res.AnalysisDate,
+			}
+			return []interface{}{body}, nil
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	return extractor.Execute()
+}
+
+var ExtractPullRequestsMeta = plugin.SubTaskMeta{
+	Name:             "ExtractPullRequests",
+	EntryPoint:       ExtractPullRequests,
+	EnabledByDefault: true,
+	Description:      "Extract raw data into tool layer table sonarqube_pull_requests",
+	DomainTypes:      []string{plugin.DOMAIN_TYPE_CODE_QUALITY},
+}
@@ -19,12 +19,12 @@ package api
 
 import (
 	"crypto/md5"
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"strings"
 	"time"
 
-	"github.com/apache/incubator-devlake/helpers/dbhelper"
 	"github.com/go-playground/validator/v10"
 
 	"github.com/apache/incubator-devlake/core/errors"
@@ -55,6 +55,10 @@ type WebhookDeployTaskRequest struct {
 	CommitMsg    string     `mapstructure:"commit_msg"`
 	// DeploymentCommits is used for multiple commits in one deployment
 	DeploymentCommits []DeploymentCommit `mapstructure:"deploymentCommits" validate:"omitempty,dive"`
+	// IsRollback marks the deployment as a rollback, RollbackOf is the pipeline_id of the deployment it rolled back,
+	// the dora plugin links it to the previous deployment if only IsRollback is set
+	IsRollback bool   `mapstructure:"is_rollback"`
+	RollbackOf string `mapstructure:"rollback_of"`
 }
 
 type DeploymentCommit struct {
@@ -84,11 +88,27 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 	if err != nil {
 		return nil, err
 	}
+	// the deliveries dedupe the deployments posted twice and keep the failed ones to be replayed
+	_, err = webhookHelper.PostEvent(deploymentEventType, input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
+// handleDeploymentEvent converts the deployment posted to the connection into the deployment commits and the
+// deployments, which are saved by the webhook helper
+func handleDeploymentEvent(event *api.WebhookEvent) ([]interface{}, errors.Error) {
+	body := map[string]interface{}{}
+	err := errors.Convert(json.Unmarshal(event.Payload, &body))
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, `input json error`)
+	}
 	// get request
 	request := &WebhookDeployTaskRequest{}
-	err = api.DecodeMapStruct(input.Body, request, true)
+	err = api.DecodeMapStruct(body, request, true)
 	if err != nil {
-		return &plugin.ApiResourceOutput{Body: err.Error(), Status: http.StatusBadRequest}, nil
+		return nil, errors.BadInput.Wrap(err, `input json error`)
 	}
 	// validate
 	vld = validator.New()
@@ -96,12 +116,10 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 	if err != nil {
 		return nil, errors.BadInput.Wrap(vld.Struct(request), `input json error`)
 	}
-	txHelper := dbhelper.NewTxHelper(basicRes, &err)
-	defer txHelper.End()
-	tx := txHelper.Begin()
+	var results []interface{}
 
 	pipelineId := request.PipelineId
-	scopeId := fmt.Sprintf("%s:%d", "webhook", connection.ID)
+	scopeId := fmt.Sprintf("%s:%d", "webhook", event.ConnectionId)
 	if request.CreatedDate == nil {
 		request.CreatedDate = request.StartedDate
 	}
@@ -146,7 +164,7 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 			return nil, errors.Convert(fmt.Errorf("commit_sha or repo_url is required"))
 		}
 		urlHash16 := fmt.Sprintf("%x", md5.Sum([]byte(request.RepoUrl)))[:16]
-		deploymentCommitId := fmt.Sprintf("%s:%d:%s:%s", "webhook", connection.ID, urlHash16, request.CommitSha)
+		deploymentCommitId := fmt.Sprintf("%s:%d:%s:%s", "webhook", event.ConnectionId, urlHash16, request.CommitSha)
 		if pipelineId == "" {
 			pipelineId = deploymentCommitId
 		}
@@ -172,21 +190,12 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 			CommitSha:   request.CommitSha,
 			CommitMsg:   request.CommitMsg,
 		}
-		err = tx.CreateOrUpdate(deploymentCommit)
-		if err != nil {
-			logger.Error(err, "create deployment commit")
-			return nil, err
-		}
-
-		// create a deployment record
-		if err = tx.CreateOrUpdate(deploymentCommit.ToDeployment()); err != nil {
-			logger.Error(err, "create deployment")
-			return nil, err
-		}
+		// and a deployment record
+		results = append(results, deploymentCommit, toDeployment(deploymentCommit, request))
 	} else {
 		for _, commit := range request.DeploymentCommits {
 			urlHash16 := fmt.Sprintf("%x", md5.Sum([]byte(commit.RepoUrl)))[:16]
-			deploymentCommitId := fmt.Sprintf("%s:%d:%s:%s", "webhook", connection.ID, urlHash16, commit.CommitSha)
+			deploymentCommitId := fmt.Sprintf("%s:%d:%s:%s", "webhook", event.ConnectionId, urlHash16, commit.CommitSha)
 			if pipelineId == "" {
 				pipelineId = deploymentCommitId
 			}
@@ -212,20 +221,20 @@ func PostDeploymentCicdTask(input *plugin.ApiResourceInput) (*plugin.ApiResource
 				CommitSha:   commit.CommitSha,
 				CommitMsg:   commit.CommitMsg,
 			}
-			err = tx.CreateOrUpdate(deploymentCommit)
-			if err != nil {
-				logger.Error(err, "create deployment commit")
-				return nil, err
-			}
+			results = append(results, deploymentCommit)
 
-			// create a deployment record
-			deploymentCommit.Name = name
-			if err = tx.CreateOrUpdate(deploymentCommit.ToDeployment()); err != nil {
-				logger.Error(err, "create deployment")
-				return nil, err
-			}
+			// and a deployment record
+			deployment := *deploymentCommit
+			deployment.Name = name
+			results = append(results, toDeployment(&deployment, request))
 		}
 	}
+	return results, nil
+}
 
-	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+func toDeployment(deploymentCommit *devops.CicdDeploymentCommit, request *WebhookDeployTaskRequest) *devops.CICDDeployment {
+	deployment := deploymentCommit.ToDeployment()
+	deployment.IsRollback = request.IsRollback || request.RollbackOf != ""
+	deployment.RollbackOfDeploymentId = request.RollbackOf
+	return deployment
 }
@@ -0,0 +1,56 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHandleDeploymentEvent(t *testing.T) {
+	results, err := handleDeploymentEvent(&api.WebhookEvent{
+		ConnectionId: 1,
+		EventType:    deploymentEventType,
+		Payload: []byte(`{
+			"pipeline_id": "release-1",
+			"start_time": "2023-12-20T10:00:00+00:00",
+			"end_time": "2023-12-20T10:10:00+00:00",
+			"deploymentCommits": [
+				{"repo_url": "https://github.com/apache/incubator-devlake", "commit_sha": "abc"},
+				{"repo_url": "https://github.com/apache/incubator-devlake-website", "commit_sha": "def"}
+			]
+		}`),
+	})
+	assert.Nil(t, err)
+	assert.Len(t, results, 4)
+	commit := results[0].(*devops.CicdDeploymentCommit)
+	assert.Equal(t, "release-1", commit.CicdDeploymentId)
+	assert.Equal(t, "webhook:1", commit.CicdScopeId)
+	assert.Equal(t, "deployment for abc", commit.Name)
+	assert.Equal(t, devops.PRODUCTION, commit.Environment)
+	assert.Equal(t, float64(600), *commit.DurationSec)
+	deployment := results[1].(*devops.CICDDeployment)
+	assert.Equal(t, "release-1", deployment.Id)
+	assert.Equal(t, "deployment for abc,def", deployment.Name)
+
+	_, err = handleDeploymentEvent(&api.WebhookEvent{ConnectionId: 1, Payload: []byte(`{"commit_sha": "abc"}`)})
+	assert.NotNil(t, err)
+}
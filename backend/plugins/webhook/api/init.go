@@ -19,6 +19,7 @@ package api
 
 import (
 	"github.com/apache/incubator-devlake/core/context"
+	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/log"
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/helpers/apikeyhelper"
@@ -28,9 +29,13 @@ import (
 
 const pluginName = "webhook"
 
+// deploymentEventType is the event type of the deliveries posted to the deployments endpoint
+const deploymentEventType = "deployment"
+
 var vld *validator.Validate
 var connectionHelper *api.ConnectionApiHelper
 var apiKeyHelper *apikeyhelper.ApiKeyHelper
+var webhookHelper *api.WebhookHelper
 var basicRes context.BasicRes
 var logger log.Logger
 
@@ -40,4 +45,25 @@ func Init(br context.BasicRes, p plugin.PluginMeta) {
 	vld = validator.New()
 	connectionHelper = api.NewConnectionHelper(basicRes, vld, p.Name())
 	apiKeyHelper = apikeyhelper.NewApiKeyHelper(basicRes, logger)
+	var err errors.Error
+	// the payloads are authenticated by the api key of the connection, so they are not signed
+	webhookHelper, err = api.NewWebhookHelper(basicRes, &api.WebhookHelperArgs{
+		PluginName: pluginName,
+		Handlers: map[string]api.WebhookEventHandler{
+			deploymentEventType: handleDeploymentEvent,
+		},
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+// GetDeliveries lists the recent deliveries posted to the connection, the `status` query filters them, i.e. FAILED
+func GetDeliveries(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return webhookHelper.GetDeliveries(input)
+}
+
+// ReplayDeliveries processes the failed deliveries of the connection again
+func ReplayDeliveries(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	return webhookHelper.Replay(input)
 }
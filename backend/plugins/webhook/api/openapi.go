@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"net/http"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/apache/incubator-devlake/plugins/webhook/openapi"
+)
+
+// GetOpenApiSpec
+// @Summary get the OpenAPI spec of the ingestion endpoints
+// @Description get the OpenAPI spec of the payloads accepted by the deployments and issues endpoints,
+// @Description the latest version is returned unless the version is specified, e.g. ?version=v1
+// @Tags plugins/webhook
+// @Param version query string false "spec version"
+// @Success 200  {object} map[string]interface{}
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Router /plugins/webhook/openapi [GET]
+func GetOpenApiSpec(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	version := input.Query.Get("version")
+	if version == "" {
+		version = openapi.LatestVersion
+	}
+	spec, ok := openapi.Specs[version]
+	if !ok {
+		return nil, errors.BadInput.New("unknown spec version " + version)
+	}
+	return &plugin.ApiResourceOutput{Body: spec, ContentType: "application/json", Status: http.StatusOK}, nil
+}
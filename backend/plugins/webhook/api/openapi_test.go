@@ -0,0 +1,70 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"encoding/json"
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/apache/incubator-devlake/plugins/webhook/openapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type openApiSpec struct {
+	Components struct {
+		Schemas map[string]struct {
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"schemas"`
+	} `json:"components"`
+}
+
+func mapstructureKeys(t reflect.Type) []string {
+	keys := make([]string, 0, t.NumField())
+	for i := 0; i < t.NumField(); i++ {
+		key := t.Field(i).Tag.Get("mapstructure")
+		if key == "" {
+			// mapstructure matches the field name case-insensitively
+			key = strings.ToLower(t.Field(i).Name)
+		}
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func TestOpenApiSpecMatchesPayloads(t *testing.T) {
+	for version, content := range openapi.Specs {
+		spec := &openApiSpec{}
+		assert.Nil(t, json.Unmarshal(content, spec), version)
+		for schema, payload := range map[string]interface{}{
+			"Deployment":       WebhookDeployTaskRequest{},
+			"DeploymentCommit": DeploymentCommit{},
+			"Issue":            WebhookIssueRequest{},
+		} {
+			properties := make([]string, 0)
+			for property := range spec.Components.Schemas[schema].Properties {
+				properties = append(properties, property)
+			}
+			sort.Strings(properties)
+			assert.Equal(t, mapstructureKeys(reflect.TypeOf(payload)), properties, "%s %s", version, schema)
+		}
+	}
+}
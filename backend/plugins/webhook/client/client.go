@@ -0,0 +1,162 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package client is a small helper for CI scripts to push deployments and issues to the
+// webhook plugin, the payloads follow the OpenAPI spec in the openapi package.
+// It only depends on the standard library so it can be vendored into any Go tool.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Deployment is the payload of the deployments endpoint
+type Deployment struct {
+	PipelineId        string             `json:"pipeline_id,omitempty"`
+	RepoId            string             `json:"repo_id,omitempty"`
+	Result            string             `json:"result,omitempty"`
+	CreateTime        *time.Time         `json:"create_time,omitempty"`
+	StartTime         *time.Time         `json:"start_time"`
+	EndTime           *time.Time         `json:"end_time,omitempty"`
+	RepoUrl           string             `json:"repo_url,omitempty"`
+	Environment       string             `json:"environment,omitempty"`
+	Name              string             `json:"name,omitempty"`
+	RefName           string             `json:"ref_name,omitempty"`
+	CommitSha         string             `json:"commit_sha,omitempty"`
+	CommitMsg         string             `json:"commit_msg,omitempty"`
+	DeploymentCommits []DeploymentCommit `json:"deploymentCommits,omitempty"`
+	IsRollback        bool               `json:"is_rollback,omitempty"`
+	RollbackOf        string             `json:"rollback_of,omitempty"`
+}
+
+// DeploymentCommit is one of the commits deployed together
+type DeploymentCommit struct {
+	RepoUrl   string `json:"repo_url"`
+	Name      string `json:"name,omitempty"`
+	RefName   string `json:"ref_name,omitempty"`
+	CommitSha string `json:"commit_sha"`
+	CommitMsg string `json:"commit_msg,omitempty"`
+}
+
+// Issue is the payload of the issues endpoint
+type Issue struct {
+	Url                     string     `json:"url,omitempty"`
+	IssueKey                string     `json:"issue_key"`
+	Title                   string     `json:"title"`
+	Description             string     `json:"description,omitempty"`
+	EpicKey                 string     `json:"epic_key,omitempty"`
+	Type                    string     `json:"type,omitempty"`
+	Status                  string     `json:"status"`
+	OriginalStatus          string     `json:"original_status"`
+	StoryPoint              float64    `json:"story_point,omitempty"`
+	ResolutionDate          *time.Time `json:"resolution_date,omitempty"`
+	CreatedDate             *time.Time `json:"created_date"`
+	UpdatedDate             *time.Time `json:"updated_date,omitempty"`
+	LeadTimeMinutes         uint       `json:"lead_time_minutes,omitempty"`
+	ParentIssueKey          string     `json:"parent_issue_key,omitempty"`
+	Priority                string     `json:"priority,omitempty"`
+	OriginalEstimateMinutes int64      `json:"original_estimate_minutes,omitempty"`
+	TimeSpentMinutes        int64      `json:"time_spent_minutes,omitempty"`
+	TimeRemainingMinutes    int64      `json:"time_remaining_minutes,omitempty"`
+	CreatorId               string     `json:"creator_id,omitempty"`
+	CreatorName             string     `json:"creator_name,omitempty"`
+	AssigneeId              string     `json:"assignee_id,omitempty"`
+	AssigneeName            string     `json:"assignee_name,omitempty"`
+	Severity                string     `json:"severity,omitempty"`
+	Component               string     `json:"component,omitempty"`
+}
+
+// Client pushes records to a webhook connection
+type Client struct {
+	// BaseUrl is the root of the api key protected DevLake REST API, e.g. http://localhost:8080/rest or
+	// http://localhost:4000/api/rest through config-ui, the api key is only checked under /rest
+	BaseUrl      string
+	ConnectionId uint64
+	ApiKey       string
+	HttpClient   *http.Client
+}
+
+// NewClient creates a client for the webhook connection with its api key, /rest is appended to the baseUrl
+// when missing, e.g. http://localhost:8080 becomes http://localhost:8080/rest
+func NewClient(baseUrl string, connectionId uint64, apiKey string) *Client {
+	baseUrl = strings.TrimSuffix(baseUrl, "/")
+	if !strings.HasSuffix(baseUrl, "/rest") {
+		baseUrl += "/rest"
+	}
+	return &Client{
+		BaseUrl:      baseUrl,
+		ConnectionId: connectionId,
+		ApiKey:       apiKey,
+		HttpClient:   &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// PostDeployment creates or updates a deployment
+func (c *Client) PostDeployment(ctx context.Context, deployment *Deployment) error {
+	return c.post(ctx, fmt.Sprintf("connections/%d/deployments", c.ConnectionId), deployment)
+}
+
+// PostIssue creates or updates an issue
+func (c *Client) PostIssue(ctx context.Context, issue *Issue) error {
+	return c.post(ctx, fmt.Sprintf("connections/%d/issues", c.ConnectionId), issue)
+}
+
+// CloseIssue sets the status of the issue to DONE, the issue key can't contain / since the router doesn't match
+// an escaped slash
+func (c *Client) CloseIssue(ctx context.Context, issueKey string) error {
+	if strings.Contains(issueKey, "/") {
+		return fmt.Errorf("issue key %q can't be closed, it contains /", issueKey)
+	}
+	return c.post(ctx, fmt.Sprintf("connections/%d/issue/%s/close", c.ConnectionId, url.PathEscape(issueKey)), nil)
+}
+
+func (c *Client) post(ctx context.Context, path string, payload interface{}) error {
+	var body io.Reader
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, fmt.Sprintf("%s/plugins/webhook/%s", c.BaseUrl, path), body)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.ApiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.ApiKey)
+	}
+	res, err := c.HttpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode >= http.StatusBadRequest {
+		message, _ := io.ReadAll(res.Body)
+		return fmt.Errorf("webhook responded %d: %s", res.StatusCode, strings.TrimSpace(string(message)))
+	}
+	return nil
+}
@@ -0,0 +1,76 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClient(t *testing.T) {
+	var path, auth string
+	var payload map[string]interface{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		path, auth, payload = r.URL.EscapedPath(), r.Header.Get("Authorization"), nil
+		body, _ := io.ReadAll(r.Body)
+		if len(body) > 0 {
+			_ = json.Unmarshal(body, &payload)
+		}
+		if payload != nil && payload["title"] == "" {
+			w.WriteHeader(http.StatusBadRequest)
+			_, _ = w.Write([]byte("title is required"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(server.URL+"/", 3, "secret")
+	started := time.Date(2023, 12, 1, 10, 0, 0, 0, time.UTC)
+	assert.Nil(t, client.PostDeployment(context.Background(), &Deployment{
+		StartTime:         &started,
+		DeploymentCommits: []DeploymentCommit{{RepoUrl: "https://github.com/o/r", CommitSha: "015e3d3b"}},
+	}))
+	assert.Equal(t, "/rest/plugins/webhook/connections/3/deployments", path)
+	assert.Equal(t, "Bearer secret", auth)
+	assert.Equal(t, "2023-12-01T10:00:00Z", payload["start_time"])
+	assert.NotContains(t, payload, "commit_sha")
+	assert.Len(t, payload["deploymentCommits"], 1)
+
+	err := client.PostIssue(context.Background(), &Issue{IssueKey: "DLK-1"})
+	assert.EqualError(t, err, "webhook responded 400: title is required")
+
+	assert.Nil(t, client.CloseIssue(context.Background(), "DLK 1"))
+	assert.Equal(t, "/rest/plugins/webhook/connections/3/issue/DLK%201/close", path)
+	assert.Nil(t, payload)
+
+	path = ""
+	assert.EqualError(t, client.CloseIssue(context.Background(), "DLK/1"), `issue key "DLK/1" can't be closed, it contains /`)
+	assert.Empty(t, path)
+}
+
+func TestNewClient(t *testing.T) {
+	assert.Equal(t, "http://localhost:8080/rest", NewClient("http://localhost:8080", 1, "").BaseUrl)
+	assert.Equal(t, "http://localhost:8080/rest", NewClient("http://localhost:8080/rest/", 1, "").BaseUrl)
+	assert.Equal(t, "http://localhost:4000/api/rest", NewClient("http://localhost:4000/api/rest", 1, "").BaseUrl)
+}
@@ -0,0 +1,136 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+
+"""
+A small helper for CI scripts to push deployments and issues to the webhook plugin of DevLake.
+The payloads follow the OpenAPI spec in plugins/webhook/openapi, only the standard library is used
+so the file can be copied next to the scripts.
+
+    client = WebhookClient("http://localhost:8080/rest", connection_id=1, api_key="...")
+    client.post_deployment(Deployment(start_time=started, commit_sha=sha, repo_url=repo_url))
+"""
+
+import json
+from dataclasses import asdict, dataclass, field
+from datetime import datetime
+from typing import List, Optional
+from urllib.parse import quote
+from urllib.request import Request, urlopen
+
+
+@dataclass
+class DeploymentCommit:
+    repo_url: str
+    commit_sha: str
+    name: Optional[str] = None
+    ref_name: Optional[str] = None
+    commit_msg: Optional[str] = None
+
+
+@dataclass
+class Deployment:
+    start_time: datetime
+    pipeline_id: Optional[str] = None
+    repo_id: Optional[str] = None
+    result: Optional[str] = None
+    create_time: Optional[datetime] = None
+    end_time: Optional[datetime] = None
+    repo_url: Optional[str] = None
+    environment: Optional[str] = None
+    name: Optional[str] = None
+    ref_name: Optional[str] = None
+    commit_sha: Optional[str] = None
+    commit_msg: Optional[str] = None
+    deploymentCommits: List[DeploymentCommit] = field(default_factory=list)
+    is_rollback: bool = False
+    rollback_of: Optional[str] = None
+
+
+@dataclass
+class Issue:
+    issue_key: str
+    title: str
+    status: str
+    original_status: str
+    created_date: datetime
+    url: Optional[str] = None
+    description: Optional[str] = None
+    epic_key: Optional[str] = None
+    type: Optional[str] = None
+    story_point: Optional[float] = None
+    resolution_date: Optional[datetime] = None
+    updated_date: Optional[datetime] = None
+    lead_time_minutes: Optional[int] = None
+    parent_issue_key: Optional[str] = None
+    priority: Optional[str] = None
+    original_estimate_minutes: Optional[int] = None
+    time_spent_minutes: Optional[int] = None
+    time_remaining_minutes: Optional[int] = None
+    creator_id: Optional[str] = None
+    creator_name: Optional[str] = None
+    assignee_id: Optional[str] = None
+    assignee_name: Optional[str] = None
+    severity: Optional[str] = None
+    component: Optional[str] = None
+
+
+def to_payload(record) -> dict:
+    """
+    Convert a record into the json payload, unset fields are omitted and dates are sent in ISO 8601.
+    """
+    def convert(value):
+        if isinstance(value, datetime):
+            return value.isoformat()
+        if isinstance(value, list):
+            return [convert(v) for v in value]
+        if isinstance(value, dict):
+            return {k: convert(v) for k, v in value.items() if v is not None and v != [] and v is not False}
+        return value
+    return convert(asdict(record))
+
+
+class WebhookClient:
+    def __init__(self, base_url: str, connection_id: int, api_key: str, timeout: float = 30):
+        # base_url is the root of the api key protected DevLake REST API, e.g. http://localhost:8080/rest or
+        # http://localhost:4000/api/rest through config-ui, the api key is only checked under /rest
+        self.base_url = base_url.rstrip('/')
+        if not self.base_url.endswith('/rest'):
+            self.base_url += '/rest'
+        self.connection_id = connection_id
+        self.api_key = api_key
+        self.timeout = timeout
+
+    def post_deployment(self, deployment: Deployment):
+        self._post(f'connections/{self.connection_id}/deployments', to_payload(deployment))
+
+    def post_issue(self, issue: Issue):
+        self._post(f'connections/{self.connection_id}/issues', to_payload(issue))
+
+    def close_issue(self, issue_key: str):
+        # the router doesn't match an escaped slash
+        if '/' in issue_key:
+            raise ValueError(f'issue key {issue_key!r} can\'t be closed, it contains /')
+        self._post(f'connections/{self.connection_id}/issue/{quote(issue_key, safe="")}/close', None)
+
+    def _post(self, path: str, payload: Optional[dict]):
+        data = json.dumps(payload).encode() if payload is not None else None
+        request = Request(f'{self.base_url}/plugins/webhook/{path}', data=data, method='POST')
+        request.add_header('Content-Type', 'application/json')
+        if self.api_key:
+            request.add_header('Authorization', f'Bearer {self.api_key}')
+        # urlopen raises HTTPError for 4xx and 5xx responses
+        with urlopen(request, timeout=self.timeout):
+            pass
@@ -87,9 +87,18 @@ func (p Webhook) ApiResources() map[string]map[string]plugin.ApiResourceHandler
 			"PATCH":  api.PatchConnection,
 			"DELETE": api.DeleteConnection,
 		},
+		"openapi": {
+			"GET": api.GetOpenApiSpec,
+		},
 		"connections/:connectionId/deployments": {
 			"POST": api.PostDeploymentCicdTask,
 		},
+		"connections/:connectionId/deliveries": {
+			"GET": api.GetDeliveries,
+		},
+		"connections/:connectionId/deliveries/replay": {
+			"POST": api.ReplayDeliveries,
+		},
 		"connections/:connectionId/issues": {
 			"POST": api.PostIssue,
 		},
@@ -0,0 +1,37 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package openapi holds the versioned OpenAPI specs of the webhook ingestion endpoints,
+// a new version is added whenever a payload changes in a backward incompatible way
+package openapi
+
+import (
+	_ "embed"
+)
+
+// V1 is the OpenAPI spec of the ingestion payloads in version 1
+//
+//go:embed v1.json
+var V1 []byte
+
+// LatestVersion is the version served by default
+const LatestVersion = "v1"
+
+// Specs maps the versions to their specs
+var Specs = map[string][]byte{
+	"v1": V1,
+}
@@ -65,6 +65,10 @@ class AzureDevOpsAPI(API):
     def git_repos(self, org: str, project: str):
         return self.get(org, project, '_apis/git/repositories')
 
+    def org_git_repos(self, org: str):
+        # the repositories of all the projects of the organization in a single call
+        return self.get(org, '_apis/git/repositories')
+
     def git_repo_pull_requests(self, org: str, project: str, repo_id: str):
         return self.get(org, project, '_apis/git/repositories', repo_id, 'pullrequests?searchCriteria.status=all')
 
@@ -77,6 +81,9 @@ class AzureDevOpsAPI(API):
     def commits(self, org: str, project: str, repo_id: str):
         return self.get(org, project, '_apis/git/repositories', repo_id, 'commits')
 
+    def git_policy_configurations(self, org: str, project: str, repo_id: str):
+        return self.get(org, project, '_apis/git/policy/configurations', repositoryId=repo_id)
+
     def builds(self, org: str, project: str, repository_id: str, provider: str):
         return self.get(org, project, '_apis/build/builds', repositoryId=repository_id, repositoryType=provider, deletedFilter='excludeDeleted')
 
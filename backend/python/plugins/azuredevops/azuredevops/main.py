@@ -13,10 +13,13 @@
 # See the License for the specific language governing permissions and
 # limitations under the License.
 
+from concurrent.futures import ThreadPoolExecutor
+from typing import Iterable, Optional
 from urllib.parse import urlparse
 
 from azuredevops.api import AzureDevOpsAPI
 from azuredevops.models import AzureDevOpsConnection, GitRepository, GitRepositoryConfig
+from azuredevops.streams.branch_policies import GitBranchPolicies
 from azuredevops.streams.builds import Builds
 from azuredevops.streams.jobs import Jobs
 from azuredevops.streams.pull_request_commits import GitPullRequestCommits
@@ -31,6 +34,9 @@ from pydevlake.api import APIException
 
 _SUPPORTED_EXTERNAL_SOURCE_PROVIDERS = ['github', 'githubenterprise', 'bitbucket', 'git']
 
+# how many organizations are enumerated at the same time
+_MAX_CONCURRENT_ORGS = 8
+
 
 class AzureDevOpsPlugin(Plugin):
 
@@ -60,16 +66,9 @@ class AzureDevOpsPlugin(Plugin):
         )
 
     def remote_scope_groups(self, connection) -> list[RemoteScopeGroup]:
-        api = AzureDevOpsAPI(connection)
-        if connection.organization:
-            orgs = [connection.organization]
-        else:
-            member_id = api.my_profile().json['id']
-            accounts = api.accounts(member_id).json
-            orgs = [account['accountName'] for account in accounts['value']]
-
-        for org in orgs:
-            for proj in api.projects(org):
+        orgs = self._organizations(connection)
+        for org, projects in self._enumerate_concurrently(connection, orgs, lambda api, org: list(api.projects(org))):
+            for proj in projects:
                 proj_name = proj['name']
 
                 yield RemoteScopeGroup(
@@ -81,20 +80,34 @@ class AzureDevOpsPlugin(Plugin):
         org, proj = group_id.split('/')
         api = AzureDevOpsAPI(connection)
         for raw_repo in api.git_repos(org, proj):
-            raw_repo['name'] = f'{proj}/{raw_repo["name"]}'
-            raw_repo['project_id'] = proj
-            raw_repo['org_id'] = org
-            # remove username from url
-            url = urlparse(raw_repo['remoteUrl'])
-            url = url._replace(netloc=url.hostname)
-            raw_repo['url'] = url.geturl()
-            repo = GitRepository(**raw_repo)
-            if not repo.default_branch:
-                continue
-            if "parentRepository" in raw_repo:
-                repo.parent_repository_url = raw_repo["parentRepository"]["url"]
-            yield repo
-
+            repo = self._make_git_repository(raw_repo, org, proj)
+            if repo:
+                yield repo
+
+        yield from self._external_repositories(api, org, proj)
+
+    def search_remote_scopes(self, connection, search: str):
+        # azure devops has no repository search, so the repositories are listed one organization at a time and
+        # filtered here, the caller stops consuming once the requested page is filled
+        search = search.lower()
+        for org in self._organizations(connection):
+            api = AzureDevOpsAPI(connection)
+            # the repositories of all the projects of the organization in a single call
+            for raw_repo in api.org_git_repos(org):
+                proj = raw_repo['project']['name']
+                if search not in f'{proj}/{raw_repo["name"]}'.lower():
+                    continue
+                repo = self._make_git_repository(raw_repo, org, proj)
+                if repo:
+                    yield f'{org}/{proj}', repo
+            # the repositories of external providers are only listed per project
+            for raw_proj in api.projects(org):
+                proj = raw_proj['name']
+                for repo in self._external_repositories(api, org, proj):
+                    if search in repo.name.lower():
+                        yield f'{org}/{proj}', repo
+
+    def _external_repositories(self, api: AzureDevOpsAPI, org: str, proj: str) -> Iterable[GitRepository]:
         for endpoint in api.endpoints(org, proj):
             provider = endpoint['type'].lower()
             if provider not in _SUPPORTED_EXTERNAL_SOURCE_PROVIDERS:
@@ -113,6 +126,34 @@ class AzureDevOpsPlugin(Plugin):
                     defaultBranch=props.get('defaultBranch', 'main')
                 )
 
+    def _organizations(self, connection) -> list[str]:
+        if connection.organization:
+            return [connection.organization]
+        api = AzureDevOpsAPI(connection)
+        member_id = api.my_profile().json['id']
+        accounts = api.accounts(member_id).json
+        return [account['accountName'] for account in accounts['value']]
+
+    def _enumerate_concurrently(self, connection, orgs: list[str], fn):
+        # each thread gets its own API since the underlying http session is not thread-safe
+        with ThreadPoolExecutor(max_workers=_MAX_CONCURRENT_ORGS) as executor:
+            yield from zip(orgs, executor.map(lambda org: fn(AzureDevOpsAPI(connection), org), orgs))
+
+    def _make_git_repository(self, raw_repo: dict, org: str, proj: str) -> Optional[GitRepository]:
+        raw_repo['name'] = f'{proj}/{raw_repo["name"]}'
+        raw_repo['project_id'] = proj
+        raw_repo['org_id'] = org
+        # remove username from url
+        url = urlparse(raw_repo['remoteUrl'])
+        url = url._replace(netloc=url.hostname)
+        raw_repo['url'] = url.geturl()
+        repo = GitRepository(**raw_repo)
+        if not repo.default_branch:
+            return None
+        if "parentRepository" in raw_repo:
+            repo.parent_repository_url = raw_repo["parentRepository"]["url"]
+        return repo
+
     def test_connection(self, connection: AzureDevOpsConnection) -> TestConnectionResult:
         api = AzureDevOpsAPI(connection)
         message = None
@@ -147,6 +188,7 @@ class AzureDevOpsPlugin(Plugin):
         return [
             GitPullRequests,
             GitPullRequestCommits,
+            GitBranchPolicies,
             Builds,
             Jobs,
         ]
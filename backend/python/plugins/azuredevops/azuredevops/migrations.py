@@ -192,3 +192,20 @@ def add_queue_time_field_in_tool_azuredevops_builds(b: MigrationScriptBuilder):
     table = '_tool_azuredevops_builds'
     b.execute(f'ALTER TABLE {table} ADD COLUMN queue_time timestamptz', Dialect.POSTGRESQL)
     b.execute(f'ALTER TABLE {table} ADD COLUMN queue_time datetime', Dialect.MYSQL)
+
+
+@migration(20231222000001, name="add _tool_azuredevops_gitbranchpolicies table")
+def add_git_branch_policies_table(b: MigrationScriptBuilder):
+    class GitBranchPolicy(ToolModel):
+        id: int = Field(primary_key=True)
+        ref_name: str = Field(primary_key=True)
+        match_kind: Optional[str]
+        type_id: str
+        type_name: str
+        is_enabled: bool
+        is_blocking: bool
+        minimum_approver_count: Optional[int]
+        build_definition_id: Optional[int]
+        display_name: Optional[str]
+
+    b.create_tables(GitBranchPolicy)
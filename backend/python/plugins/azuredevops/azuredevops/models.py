@@ -143,3 +143,17 @@ class Job(ToolModel, table=True):
     finish_time: Optional[datetime.datetime]
     state: JobState
     result: Optional[JobResult]
+
+
+class GitBranchPolicy(ToolModel, table=True):
+    # A policy configuration may apply to several branches, the collector emits one record per branch scope
+    id: int = Field(primary_key=True)
+    ref_name: str = Field(primary_key=True)
+    match_kind: Optional[str]
+    type_id: str = Field(source='/type/id')
+    type_name: str = Field(source='/type/displayName')
+    is_enabled: bool
+    is_blocking: bool
+    minimum_approver_count: Optional[int] = Field(source='/settings/minimumApproverCount')
+    build_definition_id: Optional[int] = Field(source='/settings/buildDefinitionId')
+    display_name: Optional[str] = Field(source='/settings/displayName')
@@ -0,0 +1,84 @@
+# Licensed to the Apache Software Foundation (ASF) under one or more
+# contributor license agreements.  See the NOTICE file distributed with
+# this work for additional information regarding copyright ownership.
+# The ASF licenses this file to You under the Apache License, Version 2.0
+# (the "License"); you may not use this file except in compliance with
+# the License.  You may obtain a copy of the License at
+
+#     http://www.apache.org/licenses/LICENSE-2.0
+
+# Unless required by applicable law or agreed to in writing, software
+# distributed under the License is distributed on an "AS IS" BASIS,
+# WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+# See the License for the specific language governing permissions and
+# limitations under the License.
+
+from typing import Iterable
+
+from azuredevops.api import AzureDevOpsAPI
+from azuredevops.models import GitRepository, GitBranchPolicy
+from pydevlake import Context, Stream, DomainType
+import pydevlake.domain_layer.code as code
+
+# Well-known ids of the built-in policy types, display names are localized so they can't be relied on
+MINIMUM_REVIEWERS_POLICY_TYPE = 'fa4e907d-c16b-4a4c-9dfa-4906e5d171dd'
+REQUIRED_REVIEWERS_POLICY_TYPE = 'fd2167ab-b0be-447a-8ec8-39368250530e'
+BUILD_POLICY_TYPE = '0609b952-1397-4640-95ec-e00a01b2c241'
+
+
+class GitBranchPolicies(Stream):
+    tool_model = GitBranchPolicy
+    domain_types = [DomainType.CODE]
+
+    def should_run_on(self, scope: GitRepository) -> bool:
+        return not scope.is_external()
+
+    def collect(self, state, context) -> Iterable[tuple[object, dict]]:
+        api = AzureDevOpsAPI(context.connection)
+        repo: GitRepository = context.scope
+        response = api.git_policy_configurations(repo.org_id, repo.project_id, repo.id)
+        for raw_policy in response:
+            if raw_policy.get('isDeleted'):
+                continue
+            for policy_scope in raw_policy.get('settings', {}).get('scope', []):
+                # project-wide policies have no repositoryId
+                if policy_scope.get('repositoryId') not in (None, repo.id):
+                    continue
+                # a policy without refName applies to all branches
+                yield {
+                    **raw_policy,
+                    'ref_name': policy_scope.get('refName') or '',
+                    'match_kind': policy_scope.get('matchKind'),
+                }, state
+
+    def convert(self, policy: GitBranchPolicy, ctx: Context):
+        if not policy.is_enabled:
+            return
+
+        branch_pattern = policy.ref_name.removeprefix('refs/heads/')
+        if not policy.ref_name or (policy.match_kind or '').lower() == 'prefix':
+            branch_pattern += '*'
+
+        required_approvals = 0
+        if policy.is_blocking and policy.type_id == MINIMUM_REVIEWERS_POLICY_TYPE:
+            required_approvals = policy.minimum_approver_count or 0
+
+        required_status_checks = ''
+        if policy.is_blocking and policy.type_id == BUILD_POLICY_TYPE:
+            required_status_checks = policy.display_name or str(policy.build_definition_id)
+
+        yield code.BranchProtection(
+            repo_id=ctx.scope.domain_id(),
+            branch_pattern=branch_pattern,
+            is_default_branch=bool(policy.ref_name) and policy.ref_name == ctx.scope.default_branch,
+            # any branch policy on a branch in Azure DevOps means changes must go through pull requests
+            require_pull_request=True,
+            required_approvals=required_approvals,
+            require_code_owner_review=policy.is_blocking and policy.type_id == REQUIRED_REVIEWERS_POLICY_TYPE,
+            require_status_checks=bool(required_status_checks),
+            required_status_checks=required_status_checks,
+            require_signed_commits=False,
+            allow_force_pushes=False,
+            allow_deletions=False,
+            enforce_admins=False,
+        )
@@ -334,3 +334,55 @@ def test_pull_request_commits_stream():
     )
 
     assert_stream_convert(AzureDevOpsPlugin, 'gitpullrequestcommits', raw, expected)
+
+
+def test_git_branch_policies_stream(context):
+    raw = {
+        'createdBy': {
+            'displayName': 'John Doe',
+            'id': 'bc538feb-9fdd-6cf8-80e1-7c56950d0289',
+        },
+        'createdDate': '2023-02-07T04:41:26.6424314Z',
+        'isEnabled': True,
+        'isBlocking': True,
+        'isDeleted': False,
+        'settings': {
+            'minimumApproverCount': 2,
+            'creatorVoteCounts': False,
+            'resetOnSourcePush': True,
+            'scope': [
+                {
+                    'refName': 'refs/heads/main',
+                    'matchKind': 'Exact',
+                    'repositoryId': '0d50ba13-f9ad-49b0-9b21-d29eda50ca33'
+                }
+            ]
+        },
+        'id': 3,
+        'type': {
+            'id': 'fa4e907d-c16b-4a4c-9dfa-4906e5d171dd',
+            'url': 'https://dev.azure.com/johndoe/_apis/policy/types/fa4e907d-c16b-4a4c-9dfa-4906e5d171dd',
+            'displayName': 'Minimum number of reviewers'
+        },
+        'url': 'https://dev.azure.com/johndoe/_apis/policy/configurations/3',
+        # These are not part of the API response, but are added in collect method
+        'ref_name': 'refs/heads/main',
+        'match_kind': 'Exact',
+    }
+
+    expected = code.BranchProtection(
+        repo_id=context.scope.domain_id(),
+        branch_pattern='main',
+        is_default_branch=False,
+        require_pull_request=True,
+        required_approvals=2,
+        require_code_owner_review=False,
+        require_status_checks=False,
+        required_status_checks='',
+        require_signed_commits=False,
+        allow_force_pushes=False,
+        allow_deletions=False,
+        enforce_admins=False,
+    )
+
+    assert_stream_convert(AzureDevOpsPlugin, 'gitbranchpolicies', raw, expected, context)
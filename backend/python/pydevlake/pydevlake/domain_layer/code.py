@@ -163,3 +163,19 @@ class RepoCommit(NoPKModel, table=True):
     __tablename__ = "repo_commits"
     repo_id: str = Field(primary_key=True)
     commit_sha: str = Field(primary_key=True)
+
+
+class BranchProtection(DomainModel, table=True):
+    __tablename__ = "branch_protections"
+    repo_id: str
+    branch_pattern: str
+    is_default_branch: bool
+    require_pull_request: bool
+    required_approvals: int
+    require_code_owner_review: bool
+    require_status_checks: bool
+    required_status_checks: str
+    require_signed_commits: bool
+    allow_force_pushes: bool
+    allow_deletions: bool
+    enforce_admins: bool
@@ -108,6 +108,11 @@ class PluginCommands:
         c = self._plugin.connection_type(**connection)
         yield from self._plugin.make_remote_scopes(c, group_id)
 
+    @plugin_method
+    def search_remote_scopes(self, connection: dict, search: str, page: int, page_size: int):
+        c = self._plugin.connection_type(**connection)
+        yield from self._plugin.make_search_remote_scopes(c, search, page, page_size)
+
     def _mk_context(self, data: dict):
         db_url = data['db_url']
         scope_dict = data['scope']
@@ -16,6 +16,7 @@
 from typing import Type, Union, Iterable, Optional
 from abc import ABC, abstractmethod
 from pathlib import Path
+from itertools import islice
 import os
 import sys
 
@@ -92,6 +93,18 @@ class Plugin(ABC):
     def remote_scope_groups(self, connection: Connection) -> list[msg.RemoteScopeGroup]:
         pass
 
+    def search_remote_scopes(self, connection: Connection, search: str) -> Iterable[tuple[str, ToolScope]]:
+        """
+        Yields the (group id, tool scope) pairs of the remote scopes whose name contains `search`.
+        The default implementation walks every group, plugins should override it when
+        the remote API can enumerate the scopes in fewer calls.
+        """
+        search = search.lower()
+        for group in self.remote_scope_groups(connection):
+            for tool_scope in self.remote_scopes(connection, group.id):
+                if search in tool_scope.name.lower():
+                    yield group.id, tool_scope
+
     @property
     def streams(self) -> list[Union[Stream, Type[Stream]]]:
         pass
@@ -115,18 +128,26 @@ class Plugin(ABC):
     def make_remote_scopes(self, connection: Connection, group_id: Optional[str] = None) -> msg.RemoteScopes:
         if group_id:
             for tool_scope in self.remote_scopes(connection, group_id):
-                tool_scope.connection_id = connection.id
-                tool_scope.raw_data_params = raw_data_params(connection.id, tool_scope.id)
-                tool_scope.raw_data_table = self._raw_scope_table_name()
-                yield msg.RemoteScope(
-                        id=tool_scope.id,
-                        parent_id=group_id,
-                        name=tool_scope.name,
-                        data=tool_scope
-                    )
+                yield self._make_remote_scope(connection, group_id, tool_scope)
         else:
             yield from self.remote_scope_groups(connection)
 
+    def make_search_remote_scopes(self, connection: Connection, search: str, page: int, page_size: int) -> msg.RemoteScopes:
+        start = (page - 1) * page_size
+        for group_id, tool_scope in islice(self.search_remote_scopes(connection, search), start, start + page_size):
+            yield self._make_remote_scope(connection, group_id, tool_scope)
+
+    def _make_remote_scope(self, connection: Connection, group_id: str, tool_scope: ToolScope) -> msg.RemoteScope:
+        tool_scope.connection_id = connection.id
+        tool_scope.raw_data_params = raw_data_params(connection.id, tool_scope.id)
+        tool_scope.raw_data_table = self._raw_scope_table_name()
+        return msg.RemoteScope(
+            id=tool_scope.id,
+            parent_id=group_id,
+            name=tool_scope.name,
+            data=tool_scope
+        )
+
     def make_pipeline(self, scope_config_pairs: list[ScopeConfigPair],
                       connection: Connection) -> msg.PipelineData:
         """
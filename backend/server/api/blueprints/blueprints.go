@@ -249,3 +249,84 @@ func Delete(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, nil, http.StatusOK)
 }
+
+// @Summary re-transform the scopes of a scope config
+// @Description replay the extractors and convertors from the raw data already collected for the scopes using the scope config,
+// @Description a pipeline without collectors is created for each blueprint containing them
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param body body services.RetransformInput true "json"
+// @Success 200 {object} []models.Pipeline
+// @Failure 400 {object} shared.ApiBody "Bad Request"
+// @Failure 500 {object} shared.ApiBody "Internal Error"
+// @Router /scope-configs/retransform [Post]
+func PostRetransform(c *gin.Context) {
+	input := &services.RetransformInput{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	pipelines, err := services.RetransformScopeConfig(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error re-transforming the scope config"))
+		return
+	}
+	shared.ApiOutputSuccess(c, pipelines, http.StatusOK)
+}
+
+// @Summary preview the impact of a blueprint change
+// @Description takes the same body as the patch api and tells which scopes and tasks the next pipeline would add, change or drop,
+// @Description along with its estimated duration based on the recent pipelines of the blueprint, nothing is saved
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param blueprintId path string true "blueprintId"
+// @Success 200 {object} services.BlueprintChangeImpact
+// @Failure 400 {object} shared.ApiBody "Bad Request"
+// @Failure 500 {object} shared.ApiBody "Internal Error"
+// @Router /blueprints/{blueprintId}/preview [Post]
+func PostPreview(c *gin.Context) {
+	blueprintId := c.Param("blueprintId")
+	id, err := strconv.ParseUint(blueprintId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad blueprintID format supplied"))
+		return
+	}
+	var body map[string]interface{}
+	err = c.ShouldBind(&body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	impact, err := services.PreviewBlueprintChange(id, body)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error previewing the blueprint change"))
+		return
+	}
+	shared.ApiOutputSuccess(c, impact, http.StatusOK)
+}
+
+// @Summary preview the re-transformation of a scope config
+// @Description tells which blueprints, scopes and tasks would be re-run by the re-transform api and their estimated duration,
+// @Description with the proposed scopeConfig if any, the tasks are compared to the ones of the saved scope config, nothing is triggered
+// @Tags framework/blueprints
+// @Accept application/json
+// @Param body body services.RetransformPreviewInput true "json"
+// @Success 200 {object} []services.BlueprintChangeImpact
+// @Failure 400 {object} shared.ApiBody "Bad Request"
+// @Failure 500 {object} shared.ApiBody "Internal Error"
+// @Router /scope-configs/retransform/preview [Post]
+func PostRetransformPreview(c *gin.Context) {
+	input := &services.RetransformPreviewInput{}
+	err := c.ShouldBindJSON(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	impacts, err := services.PreviewRetransform(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error previewing the re-transformation"))
+		return
+	}
+	shared.ApiOutputSuccess(c, impacts, http.StatusOK)
+}
@@ -0,0 +1,111 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package canaries
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedCanaries struct {
+	Canaries []*models.Canary `json:"canaries"`
+	Count    int64            `json:"count"`
+}
+
+// @Summary Get list of canaries
+// @Description GET /canaries?plugin=github&connectionId=1&page=1&pageSize=10
+// @Tags framework/canaries
+// @Param plugin query string false "plugin name"
+// @Param connectionId query int false "connection id"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedCanaries
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /canaries [get]
+func GetCanaries(c *gin.Context) {
+	var query services.CanaryQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	canaries, count, err := services.GetCanaries(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting canaries"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedCanaries{
+		Canaries: canaries,
+		Count:    count,
+	}, http.StatusOK)
+}
+
+// @Summary Run a canary
+// @Description Collect a few pages of one scope of the connection to project the duration and the api requests of
+// @Description the full collection, scopeId defaults to the first scope of the connection
+// @Tags framework/canaries
+// @Accept application/json
+// @Param canary body models.ApiInputCanary true "json"
+// @Success 201  {object} models.Canary
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /canaries [post]
+func PostCanary(c *gin.Context) {
+	input := &models.ApiInputCanary{}
+	err := c.ShouldBind(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	canary, err := services.RunCanary(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error running canary"))
+		return
+	}
+	shared.ApiOutputSuccess(c, canary, http.StatusCreated)
+}
+
+// @Summary Get the report of a canary
+// @Description The requests and the duration of the canary, with the projection of the full collection once it is finished
+// @Tags framework/canaries
+// @Param canaryId path int true "canary id"
+// @Success 200  {object} services.CanaryReport
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Not Found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /canaries/{canaryId} [get]
+func GetCanary(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("canaryId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad canaryId format supplied"))
+		return
+	}
+	report, err := services.GetCanaryReport(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting canary"))
+		return
+	}
+	shared.ApiOutputSuccess(c, report, http.StatusOK)
+}
@@ -0,0 +1,99 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package maintenance
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+// @Summary Get list of maintenance windows
+// @Description GET /maintenance-windows
+// @Tags framework/maintenance
+// @Success 200  {object} []models.MaintenanceWindow
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /maintenance-windows [get]
+func GetMaintenanceWindows(c *gin.Context) {
+	windows, err := services.GetMaintenanceWindows()
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting maintenance windows"))
+		return
+	}
+	shared.ApiOutputSuccess(c, windows, http.StatusOK)
+}
+
+// @Summary Create a maintenance window
+// @Description Scheduled blueprints are deferred to the end of the window and queued pipelines are held during it,
+// @Description the window either recurs with cronConfig (UTC) and duration, or happens once between startAt and endAt
+// @Tags framework/maintenance
+// @Accept application/json
+// @Param window body models.ApiInputMaintenanceWindow true "json"
+// @Success 201  {object} models.MaintenanceWindow
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /maintenance-windows [post]
+func PostMaintenanceWindow(c *gin.Context) {
+	input := &models.ApiInputMaintenanceWindow{}
+	err := c.ShouldBind(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	window, err := services.CreateMaintenanceWindow(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating maintenance window"))
+		return
+	}
+	shared.ApiOutputSuccess(c, window, http.StatusCreated)
+}
+
+// @Summary Delete a maintenance window
+// @Description Delete a maintenance window
+// @Tags framework/maintenance
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /maintenance-windows/:maintenanceWindowId [delete]
+func DeleteMaintenanceWindow(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("maintenanceWindowId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad maintenanceWindowId format supplied"))
+		return
+	}
+	err = services.DeleteMaintenanceWindow(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting maintenance window"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Get the maintenance status
+// @Description Whether a maintenance window is active, until when, and the blueprints deferred to its end
+// @Tags framework/maintenance
+// @Success 200  {object} services.MaintenanceStatus
+// @Router /maintenance-status [get]
+func GetMaintenanceStatus(c *gin.Context) {
+	shared.ApiOutputSuccess(c, services.GetMaintenanceStatus(), http.StatusOK)
+}
@@ -200,3 +200,56 @@ func PostRerun(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, rerunTasks, http.StatusOK)
 }
+
+// @Summary get artifacts of a pipeline
+// @Description GET /pipelines/:pipelineId/artifacts
+// @Tags framework/pipelines
+// @Param pipelineId path int true "pipelineId"
+// @Success 200  {object} []models.PipelineArtifact
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/artifacts [get]
+func GetArtifacts(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipeline ID format supplied"))
+		return
+	}
+	artifacts, err := services.GetPipelineArtifacts(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting artifacts of pipeline"))
+		return
+	}
+	shared.ApiOutputSuccess(c, artifacts, http.StatusOK)
+}
+
+// @Summary download artifacts of a pipeline
+// @Description GET /pipelines/:pipelineId/artifacts.tar.gz
+// @Tags framework/pipelines
+// @Param pipelineId path int true "query"
+// @Success 200  "The archive file"
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 404  {string} errcode.Error "Pipeline or artifacts not found"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /pipelines/{pipelineId}/artifacts.tar.gz [get]
+func DownloadArtifacts(c *gin.Context) {
+	pipelineId := c.Param("pipelineId")
+	id, err := strconv.ParseUint(pipelineId, 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad pipeline ID format supplied"))
+		return
+	}
+	pipeline, err := services.GetPipeline(id, true)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting pipeline"))
+		return
+	}
+	archive, err := services.GetPipelineArtifactsArchivePath(pipeline)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting artifacts for pipeline"))
+		return
+	}
+	defer os.Remove(archive)
+	c.FileAttachment(archive, filepath.Base(archive))
+}
@@ -22,6 +22,7 @@ import (
 
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/services"
 
@@ -152,3 +153,94 @@ func DeleteProject(c *gin.Context) {
 	}
 	shared.ApiOutputSuccess(c, nil, http.StatusOK)
 }
+
+type PaginatedProjectHealthScores struct {
+	HealthScores []*crossdomain.ProjectHealthScore `json:"healthScores"`
+	Count        int64                             `json:"count"`
+}
+
+// @Summary Get project health scores
+// @Description GET /project-health-scores?projectName=xxx&page=1&pageSize=10
+// @Description returns the latest health score of every project, or the history of the project if projectName is specified
+// @Tags framework/projects
+// @Param projectName query string false "project name"
+// @Param asOf query string false "date or RFC3339 time to look back at"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedProjectHealthScores
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /project-health-scores [get]
+func GetProjectHealthScores(c *gin.Context) {
+	var query services.ProjectHealthScoreQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	scores, count, err := services.GetProjectHealthScores(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting project health scores"))
+		return
+	}
+
+	shared.ApiOutputSuccess(c, PaginatedProjectHealthScores{
+		HealthScores: scores,
+		Count:        count,
+	}, http.StatusOK)
+}
+
+// @Summary Get the service summary of a project
+// @Description DORA category, health score, last production deployment and open incident count of the project,
+// @Description designed for embedding in developer portals like Backstage. With asOf, the health score and DORA category
+// @Description are the snapshot reported back then, the last deployment and open incident count are derived from the current rows by their dates
+// @Tags framework/projects
+// @Param projectName path string true "project name"
+// @Param asOf query string false "date or RFC3339 time to look back at"
+// @Success 200  {object} services.ServiceSummary
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /service-summaries/projects/:projectName [get]
+func GetProjectServiceSummary(c *gin.Context) {
+	projectName := c.Param("projectName")[1:]
+	var query services.ServiceSummaryQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+
+	summary, err := services.GetProjectServiceSummary(projectName, &query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting project service summary"))
+		return
+	}
+	shared.ApiOutputSuccess(c, summary, http.StatusOK)
+}
+
+// @Summary Get the service summaries of a repo
+// @Description GET /service-summaries/repos?url=https://github.com/apache/incubator-devlake or ?name=apache/incubator-devlake
+// @Description returns one summary per project the repo belongs to, the last deployment is the last one containing the repo,
+// @Description asOf works the same as for the project service summary
+// @Tags framework/projects
+// @Param url query string false "repo url"
+// @Param name query string false "repo name"
+// @Param asOf query string false "date or RFC3339 time to look back at"
+// @Success 200  {object} []services.ServiceSummary
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /service-summaries/repos [get]
+func GetRepoServiceSummaries(c *gin.Context) {
+	var query services.ServiceSummaryRepoQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	summaries, err := services.GetRepoServiceSummaries(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error getting repo service summaries"))
+		return
+	}
+	shared.ApiOutputSuccess(c, summaries, http.StatusOK)
+}
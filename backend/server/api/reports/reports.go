@@ -0,0 +1,128 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package reports
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/server/api/shared"
+	"github.com/apache/incubator-devlake/server/services"
+	"github.com/gin-gonic/gin"
+)
+
+type PaginatedReportSchedules struct {
+	ReportSchedules []*models.ReportSchedule `json:"reportSchedules"`
+	Count           int64                    `json:"count"`
+}
+
+// @Summary Get list of report schedules
+// @Description GET /report-schedules?projectName=xxx&page=1&pageSize=10
+// @Tags framework/reports
+// @Param projectName query string false "project name"
+// @Param page query int false "query"
+// @Param pageSize query int false "query"
+// @Success 200  {object} PaginatedReportSchedules
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /report-schedules [get]
+func GetReportSchedules(c *gin.Context) {
+	var query services.ReportScheduleQuery
+	err := c.ShouldBindQuery(&query)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	schedules, count, err := services.GetReportSchedules(&query)
+	if err != nil {
+		shared.ApiOutputAbort(c, errors.Default.Wrap(err, "error getting report schedules"))
+		return
+	}
+	shared.ApiOutputSuccess(c, PaginatedReportSchedules{
+		ReportSchedules: schedules,
+		Count:           count,
+	}, http.StatusOK)
+}
+
+// @Summary Create a report schedule
+// @Description Email the weekly digest of a project to the recipients, cronConfig defaults to every Monday 08:00 UTC
+// @Tags framework/reports
+// @Accept application/json
+// @Param schedule body models.ApiInputReportSchedule true "json"
+// @Success 201  {object} models.ReportSchedule
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /report-schedules [post]
+func PostReportSchedule(c *gin.Context) {
+	input := &models.ApiInputReportSchedule{}
+	err := c.ShouldBind(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, shared.BadRequestBody))
+		return
+	}
+	schedule, err := services.CreateReportSchedule(input)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error creating report schedule"))
+		return
+	}
+	shared.ApiOutputSuccess(c, schedule, http.StatusCreated)
+}
+
+// @Summary Delete a report schedule
+// @Description Delete a report schedule
+// @Tags framework/reports
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /report-schedules/:reportScheduleId [delete]
+func DeleteReportSchedule(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("reportScheduleId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad reportScheduleId format supplied"))
+		return
+	}
+	err = services.DeleteReportSchedule(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error deleting report schedule"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
+
+// @Summary Send a report now
+// @Description Render and email the report of the schedule right away
+// @Tags framework/reports
+// @Success 200
+// @Failure 400  {string} errcode.Error "Bad Request"
+// @Failure 500  {string} errcode.Error "Internal Error"
+// @Router /report-schedules/:reportScheduleId/send [post]
+func PostSendReport(c *gin.Context) {
+	id, err := strconv.ParseUint(c.Param("reportScheduleId"), 10, 64)
+	if err != nil {
+		shared.ApiOutputError(c, errors.BadInput.Wrap(err, "bad reportScheduleId format supplied"))
+		return
+	}
+	err = services.SendReport(id)
+	if err != nil {
+		shared.ApiOutputError(c, errors.Default.Wrap(err, "error sending report"))
+		return
+	}
+	shared.ApiOutputSuccess(c, nil, http.StatusOK)
+}
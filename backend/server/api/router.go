@@ -18,8 +18,11 @@ limitations under the License.
 package api
 
 import (
+	"bytes"
 	"fmt"
+	"io"
 	"net/http"
+	"strconv"
 	"strings"
 
 	"github.com/apache/incubator-devlake/core/context"
@@ -29,11 +32,14 @@ import (
 
 	"github.com/apache/incubator-devlake/core/plugin"
 	"github.com/apache/incubator-devlake/server/api/blueprints"
+	"github.com/apache/incubator-devlake/server/api/canaries"
 	"github.com/apache/incubator-devlake/server/api/domainlayer"
+	"github.com/apache/incubator-devlake/server/api/maintenance"
 	"github.com/apache/incubator-devlake/server/api/pipelines"
 	"github.com/apache/incubator-devlake/server/api/plugininfo"
 	"github.com/apache/incubator-devlake/server/api/project"
 	"github.com/apache/incubator-devlake/server/api/push"
+	"github.com/apache/incubator-devlake/server/api/reports"
 	"github.com/apache/incubator-devlake/server/api/shared"
 	"github.com/apache/incubator-devlake/server/api/task"
 	"github.com/apache/incubator-devlake/server/services"
@@ -49,6 +55,8 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.GET("/pipelines/:pipelineId/tasks", task.GetTaskByPipeline)
 	r.POST("/pipelines/:pipelineId/rerun", pipelines.PostRerun)
 	r.GET("/pipelines/:pipelineId/logging.tar.gz", pipelines.DownloadLogs)
+	r.GET("/pipelines/:pipelineId/artifacts", pipelines.GetArtifacts)
+	r.GET("/pipelines/:pipelineId/artifacts.tar.gz", pipelines.DownloadArtifacts)
 
 	r.GET("/blueprints", blueprints.Index)
 	r.POST("/blueprints", blueprints.Post)
@@ -56,7 +64,10 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.DELETE("/blueprints/:blueprintId", blueprints.Delete)
 	r.GET("/blueprints/:blueprintId", blueprints.Get)
 	r.POST("/blueprints/:blueprintId/trigger", blueprints.Trigger)
+	r.POST("/blueprints/:blueprintId/preview", blueprints.PostPreview)
 	r.GET("/blueprints/:blueprintId/pipelines", blueprints.GetBlueprintPipelines)
+	r.POST("/scope-configs/retransform", blueprints.PostRetransform)
+	r.POST("/scope-configs/retransform/preview", blueprints.PostRetransformPreview)
 
 	r.POST("/tasks/:taskId/rerun", task.PostRerun)
 
@@ -73,6 +84,26 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 	r.DELETE("/projects/*projectName", project.DeleteProject)
 	r.POST("/projects", project.PostProject)
 	r.GET("/projects", project.GetProjects)
+	r.GET("/project-health-scores", project.GetProjectHealthScores)
+	r.GET("/service-summaries/projects/*projectName", project.GetProjectServiceSummary)
+	r.GET("/service-summaries/repos", project.GetRepoServiceSummaries)
+
+	// report api
+	r.GET("/report-schedules", reports.GetReportSchedules)
+	r.POST("/report-schedules", reports.PostReportSchedule)
+	r.DELETE("/report-schedules/:reportScheduleId", reports.DeleteReportSchedule)
+	r.POST("/report-schedules/:reportScheduleId/send", reports.PostSendReport)
+
+	// maintenance api
+	r.GET("/maintenance-windows", maintenance.GetMaintenanceWindows)
+	r.POST("/maintenance-windows", maintenance.PostMaintenanceWindow)
+	r.DELETE("/maintenance-windows/:maintenanceWindowId", maintenance.DeleteMaintenanceWindow)
+	r.GET("/maintenance-status", maintenance.GetMaintenanceStatus)
+
+	// canary api
+	r.GET("/canaries", canaries.GetCanaries)
+	r.POST("/canaries", canaries.PostCanary)
+	r.GET("/canaries/:canaryId", canaries.GetCanary)
 
 	// api keys api
 	r.GET("/api-keys", apikeys.GetApiKeys)
@@ -94,6 +125,9 @@ func RegisterRouter(r *gin.Engine, basicRes context.BasicRes) {
 func registerPluginEndpoints(r *gin.Engine, basicRes context.BasicRes, pluginName string, apiResources map[string]map[string]plugin.ApiResourceHandler) {
 	for resourcePath, resourceHandlers := range apiResources {
 		for method, h := range resourceHandlers {
+			if method == http.MethodPut && resourcePath == "connections/:connectionId/scopes" {
+				h = runCanaryAfterScopesAdded(pluginName, h)
+			}
 			r.Handle(
 				method,
 				fmt.Sprintf("/plugins/%s/%s", pluginName, resourcePath),
@@ -103,6 +137,20 @@ func registerPluginEndpoints(r *gin.Engine, basicRes context.BasicRes, pluginNam
 	}
 }
 
+// runCanaryAfterScopesAdded runs a canary for the connection the first time scopes are added to it
+func runCanaryAfterScopesAdded(pluginName string, handler plugin.ApiResourceHandler) plugin.ApiResourceHandler {
+	return func(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+		output, err := handler(input)
+		if err != nil {
+			return output, err
+		}
+		if connectionId, e := strconv.ParseUint(input.Params["connectionId"], 10, 64); e == nil {
+			go services.RunCanaryForNewConnection(pluginName, connectionId)
+		}
+		return output, nil
+	}
+}
+
 func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plugin.ApiResourceHandler) func(c *gin.Context) {
 	return func(c *gin.Context) {
 		var err errors.Error
@@ -125,6 +173,14 @@ func handlePluginCall(basicRes context.BasicRes, pluginName string, handler plug
 			if strings.HasPrefix(c.Request.Header.Get("Content-Type"), "multipart/form-data;") {
 				input.Request = c.Request
 			} else {
+				rawBody, readErr := io.ReadAll(c.Request.Body)
+				if readErr != nil {
+					shared.ApiOutputError(c, errors.BadInput.Wrap(errors.Convert(readErr), shared.BadRequestBody))
+					return
+				}
+				input.RawBody = rawBody
+				input.Request = c.Request
+				c.Request.Body = io.NopCloser(bytes.NewReader(rawBody))
 				shouldBindJSONErr := c.ShouldBindJSON(&input.Body)
 				if shouldBindJSONErr != nil && shouldBindJSONErr.Error() != "EOF" {
 					shared.ApiOutputError(c, shouldBindJSONErr)
@@ -0,0 +1,48 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/errors"
+)
+
+// parseAsOf parses the `asOf` query param of the metric apis, it accepts a date (i.e. 2023-09-30, the whole
+// day is included) or a RFC3339 time, the returned time is exclusive, nil means now
+func parseAsOf(asOf string) (*time.Time, errors.Error) {
+	if asOf == "" {
+		return nil, nil
+	}
+	if date, err := time.Parse(time.DateOnly, asOf); err == nil {
+		date = date.AddDate(0, 0, 1)
+		return &date, nil
+	}
+	t, err := time.Parse(time.RFC3339, asOf)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "asOf must be a date like 2006-01-02 or a RFC3339 time")
+	}
+	return &t, nil
+}
+
+func asOfOrNow(asOf *time.Time) time.Time {
+	if asOf == nil {
+		return time.Now()
+	}
+	return *asOf
+}
@@ -0,0 +1,43 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAsOf(t *testing.T) {
+	asOf, err := parseAsOf("")
+	assert.Nil(t, err)
+	assert.Nil(t, asOf)
+
+	// the whole day is included
+	asOf, err = parseAsOf("2023-09-30")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 10, 1, 0, 0, 0, 0, time.UTC), *asOf)
+
+	asOf, err = parseAsOf("2023-09-30T12:00:00+08:00")
+	assert.Nil(t, err)
+	assert.Equal(t, time.Date(2023, 9, 30, 4, 0, 0, 0, time.UTC), asOf.UTC())
+
+	_, err = parseAsOf("last quarter")
+	assert.NotNil(t, err)
+}
@@ -28,6 +28,7 @@ import (
 	"github.com/apache/incubator-devlake/core/dal"
 	"github.com/apache/incubator-devlake/core/errors"
 	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/runner"
 	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
 	"github.com/apache/incubator-devlake/impls/logruslog"
 	"github.com/robfig/cron/v3"
@@ -53,7 +54,13 @@ type BlueprintJob struct {
 }
 
 func (bj BlueprintJob) Run() {
-	blueprint := bj.Blueprint
+	if deferBlueprintIfUnderMaintenance(bj.Blueprint.ID) {
+		return
+	}
+	runBlueprintJob(bj.Blueprint)
+}
+
+func runBlueprintJob(blueprint *models.Blueprint) {
 	pipeline, err := createPipelineByBlueprint(blueprint, &blueprint.SyncPolicy)
 	if err == ErrEmptyPlan {
 		blueprintLog.Info("Empty plan, blueprint id:[%d] blueprint name:[%s]", blueprint.ID, blueprint.Name)
@@ -169,6 +176,10 @@ func validateBlueprintAndMakePlan(blueprint *models.Blueprint) errors.Error {
 		}
 	}
 
+	if _, _, err := runner.ParseTimeouts(&blueprint.SyncPolicy); err != nil {
+		return err
+	}
+
 	if strings.ToLower(blueprint.CronConfig) == "manual" {
 		blueprint.IsManual = true
 	}
@@ -310,13 +321,18 @@ func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.S
 	} else {
 		plan = blueprint.Plan
 	}
+	return createPipelineByPlan(blueprint, plan, blueprint.SyncPolicy)
+}
 
+// createPipelineByPlan creates a pipeline of the blueprint running the plan, ErrEmptyPlan is returned when
+// the plan has nothing but the tasks of the org, refdiff and dora plugins
+func createPipelineByPlan(blueprint *models.Blueprint, plan models.PipelinePlan, syncPolicy models.SyncPolicy) (*models.Pipeline, errors.Error) {
 	newPipeline := models.NewPipeline{}
 	newPipeline.Plan = plan
 	newPipeline.Name = blueprint.Name
 	newPipeline.BlueprintId = blueprint.ID
 	newPipeline.Labels = blueprint.Labels
-	newPipeline.SyncPolicy = blueprint.SyncPolicy
+	newPipeline.SyncPolicy = syncPolicy
 
 	// if the plan is empty, we should not create the pipeline
 	var shouldCreatePipeline bool
@@ -346,29 +362,38 @@ func createPipelineByBlueprint(blueprint *models.Blueprint, syncPolicy *models.S
 // MakePlanForBlueprint generates pipeline plan by version
 func MakePlanForBlueprint(blueprint *models.Blueprint, syncPolicy *models.SyncPolicy) (models.PipelinePlan, errors.Error) {
 	var plan models.PipelinePlan
-	// load project metric plugins and convert it to a map
-	metrics := make(map[string]json.RawMessage)
-	projectMetrics := make([]models.ProjectMetricSetting, 0)
-	if blueprint.ProjectName != "" {
-		err := db.All(&projectMetrics, dal.Where("project_name = ? AND enable = ?", blueprint.ProjectName, true))
-		if err != nil {
-			return nil, err
-		}
-		for _, projectMetric := range projectMetrics {
-			metrics[projectMetric.PluginName] = json.RawMessage(projectMetric.PluginOption)
-		}
+	metrics, err := loadProjectMetrics(blueprint.ProjectName)
+	if err != nil {
+		return nil, err
 	}
 	skipCollectors := false
 	if syncPolicy != nil && syncPolicy.SkipCollectors {
 		skipCollectors = true
 	}
-	plan, err := GeneratePlanJsonV200(blueprint.ProjectName, blueprint.Connections, metrics, skipCollectors)
+	plan, err = GeneratePlanJsonV200(blueprint.ProjectName, blueprint.Connections, metrics, skipCollectors)
 	if err != nil {
 		return nil, err
 	}
 	return SequencializePipelinePlans(blueprint.BeforePlan, plan, blueprint.AfterPlan), nil
 }
 
+// loadProjectMetrics loads the options of the metric plugins enabled for the project, keyed by the plugin name
+func loadProjectMetrics(projectName string) (map[string]json.RawMessage, errors.Error) {
+	metrics := make(map[string]json.RawMessage)
+	if projectName == "" {
+		return metrics, nil
+	}
+	projectMetrics := make([]models.ProjectMetricSetting, 0)
+	err := db.All(&projectMetrics, dal.Where("project_name = ? AND enable = ?", projectName, true))
+	if err != nil {
+		return nil, err
+	}
+	for _, projectMetric := range projectMetrics {
+		metrics[projectMetric.PluginName] = json.RawMessage(projectMetric.PluginOption)
+	}
+	return metrics, nil
+}
+
 // ParallelizePipelinePlans merges multiple pipelines into one unified plan
 // by assuming they can be executed in parallel
 func ParallelizePipelinePlans(plans ...models.PipelinePlan) models.PipelinePlan {
@@ -411,6 +436,7 @@ func TriggerBlueprint(id uint64, syncPolicy *models.SyncPolicy, shouldSanitize b
 	}
 	blueprint.SkipCollectors = syncPolicy.SkipCollectors
 	blueprint.FullSync = syncPolicy.FullSync
+	blueprint.ProduceArtifacts = blueprint.ProduceArtifacts || syncPolicy.ProduceArtifacts
 	pipeline, err := createPipelineByBlueprint(blueprint, syncPolicy)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,390 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"encoding/json"
+	"sort"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	helper "github.com/apache/incubator-devlake/helpers/pluginhelper/api"
+)
+
+// how a task of the next pipeline compares to the current plan of the blueprint
+const (
+	TASK_IMPACT_ADDED     = "ADDED"
+	TASK_IMPACT_CHANGED   = "CHANGED"
+	TASK_IMPACT_UNCHANGED = "UNCHANGED"
+	TASK_IMPACT_REMOVED   = "REMOVED"
+	TASK_IMPACT_RERUN     = "RERUN"
+)
+
+// the number of recent pipelines of a blueprint used to estimate the duration of its tasks
+const impactEstimationPipelines = 5
+
+type ScopeRef struct {
+	PluginName   string `json:"pluginName"`
+	ConnectionId uint64 `json:"connectionId"`
+	ScopeId      string `json:"scopeId"`
+}
+
+type PlanTaskImpact struct {
+	Plugin          string                 `json:"plugin"`
+	Options         map[string]interface{} `json:"options"`
+	Subtasks        []string               `json:"subtasks"`
+	Impact          string                 `json:"impact"`
+	AddedSubtasks   []string               `json:"addedSubtasks,omitempty"`
+	RemovedSubtasks []string               `json:"removedSubtasks,omitempty"`
+	// EstimatedSeconds is nil when there is no history of the plugin to estimate it
+	EstimatedSeconds *int64 `json:"estimatedSeconds"`
+}
+
+// BlueprintChangeImpact tells what the next pipeline of the blueprint would run once the change is saved, the
+// estimated duration is based on the tasks of its recent pipelines, a task never run before is estimated by
+// the average duration of the tasks of the same plugin
+type BlueprintChangeImpact struct {
+	BlueprintId      uint64            `json:"blueprintId"`
+	BlueprintName    string            `json:"blueprintName"`
+	AddedScopes      []*ScopeRef       `json:"addedScopes"`
+	RemovedScopes    []*ScopeRef       `json:"removedScopes"`
+	RerunScopes      []*ScopeRef       `json:"rerunScopes,omitempty"`
+	Tasks            []*PlanTaskImpact `json:"tasks"`
+	RemovedTasks     []*PlanTaskImpact `json:"removedTasks"`
+	EstimatedSeconds int64             `json:"estimatedSeconds"`
+	UnestimatedTasks int               `json:"unestimatedTasks"`
+}
+
+// PreviewBlueprintChange applies the patch, in the same format as PatchBlueprint, to a copy of the blueprint and
+// compares the plans before and after without saving anything
+func PreviewBlueprintChange(id uint64, body map[string]interface{}) (*BlueprintChangeImpact, errors.Error) {
+	current, err := GetBlueprint(id, false)
+	if err != nil {
+		return nil, err
+	}
+	proposed, err := GetBlueprint(id, false)
+	if err != nil {
+		return nil, err
+	}
+	err = helper.DecodeMapStruct(body, proposed, true)
+	if err != nil {
+		return nil, err
+	}
+	if current.Mode != proposed.Mode {
+		return nil, errors.BadInput.New("mode is not updatable")
+	}
+	err = helper.DecodeMapStruct(body, &proposed.SyncPolicy, true)
+	if err != nil {
+		return nil, err
+	}
+	currentPlan, err := planOfBlueprint(current)
+	if err != nil {
+		return nil, err
+	}
+	proposedPlan, err := planOfBlueprint(proposed)
+	if err != nil {
+		return nil, err
+	}
+	estimator, err := loadTaskDurationEstimator(id)
+	if err != nil {
+		return nil, err
+	}
+	impact := &BlueprintChangeImpact{
+		BlueprintId:   proposed.ID,
+		BlueprintName: proposed.Name,
+	}
+	impact.AddedScopes, impact.RemovedScopes = diffBlueprintScopes(current.Connections, proposed.Connections)
+	impact.Tasks, impact.RemovedTasks = diffPlans(currentPlan, proposedPlan)
+	estimator.estimatePlan(impact, proposedPlan)
+	return impact, nil
+}
+
+// RetransformPreviewInput is the RetransformInput along with the proposed scope config, in the same format as the
+// patch of the scope config, the saved scope config is previewed when it is omitted
+type RetransformPreviewInput struct {
+	RetransformInput
+	ScopeConfig map[string]interface{} `json:"scopeConfig"`
+}
+
+// PreviewRetransform tells which blueprints, scopes and tasks RetransformScopeConfig would run, the estimation is
+// an upper bound since the durations of the past tasks include the collection. With a proposed scope config, the
+// plans are made again with it and compared to the ones of the saved scope config like PreviewBlueprintChange,
+// the tasks it doesn't change are re-run all the same
+func PreviewRetransform(input *RetransformPreviewInput) ([]*BlueprintChangeImpact, errors.Error) {
+	impacts := make([]*BlueprintChangeImpact, 0)
+	err := forEachRetransformBlueprint(&input.RetransformInput, func(blueprint *models.Blueprint, connections []*models.BlueprintConnection, plan models.PipelinePlan) errors.Error {
+		estimator, err := loadTaskDurationEstimator(blueprint.ID)
+		if err != nil {
+			return err
+		}
+		impact := &BlueprintChangeImpact{
+			BlueprintId:   blueprint.ID,
+			BlueprintName: blueprint.Name,
+			RerunScopes:   blueprintScopeRefs(connections),
+		}
+		if input.ScopeConfig == nil {
+			impact.Tasks = planTaskImpacts(plan, TASK_IMPACT_RERUN)
+			estimator.estimatePlan(impact, plan)
+			impacts = append(impacts, impact)
+			return nil
+		}
+		proposedPlan, err := makeRetransformPlan(blueprint.ProjectName, overrideScopeConfig(connections, input.ScopeConfig))
+		if err != nil {
+			return err
+		}
+		impact.Tasks, impact.RemovedTasks = diffPlans(plan, proposedPlan)
+		for _, task := range impact.Tasks {
+			if task.Impact == TASK_IMPACT_UNCHANGED {
+				task.Impact = TASK_IMPACT_RERUN
+			}
+		}
+		estimator.estimatePlan(impact, proposedPlan)
+		impacts = append(impacts, impact)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return impacts, nil
+}
+
+// overrideScopeConfig copies the connections with the proposed scope config set on every scope, the plugins patch
+// it onto the saved scope config while making the plan
+func overrideScopeConfig(connections []*models.BlueprintConnection, scopeConfig map[string]interface{}) []*models.BlueprintConnection {
+	result := make([]*models.BlueprintConnection, 0, len(connections))
+	for _, connection := range connections {
+		overridden := *connection
+		overridden.Scopes = make([]*models.BlueprintScope, 0, len(connection.Scopes))
+		for _, scope := range connection.Scopes {
+			scope := *scope
+			scope.ScopeConfigOverride = scopeConfig
+			overridden.Scopes = append(overridden.Scopes, &scope)
+		}
+		result = append(result, &overridden)
+	}
+	return result
+}
+
+func planOfBlueprint(blueprint *models.Blueprint) (models.PipelinePlan, errors.Error) {
+	if blueprint.Mode == models.BLUEPRINT_MODE_NORMAL {
+		return MakePlanForBlueprint(blueprint, &blueprint.SyncPolicy)
+	}
+	return blueprint.Plan, nil
+}
+
+func blueprintScopeRefs(connections []*models.BlueprintConnection) []*ScopeRef {
+	refs := make([]*ScopeRef, 0)
+	for _, connection := range connections {
+		for _, scope := range connection.Scopes {
+			refs = append(refs, &ScopeRef{
+				PluginName:   connection.PluginName,
+				ConnectionId: connection.ConnectionId,
+				ScopeId:      scope.ScopeId,
+			})
+		}
+	}
+	return refs
+}
+
+func diffBlueprintScopes(current, proposed []*models.BlueprintConnection) (added []*ScopeRef, removed []*ScopeRef) {
+	currentRefs := blueprintScopeRefs(current)
+	proposedRefs := blueprintScopeRefs(proposed)
+	inCurrent := make(map[ScopeRef]bool, len(currentRefs))
+	for _, ref := range currentRefs {
+		inCurrent[*ref] = true
+	}
+	inProposed := make(map[ScopeRef]bool, len(proposedRefs))
+	for _, ref := range proposedRefs {
+		inProposed[*ref] = true
+	}
+	added, removed = make([]*ScopeRef, 0), make([]*ScopeRef, 0)
+	for _, ref := range proposedRefs {
+		if !inCurrent[*ref] {
+			added = append(added, ref)
+		}
+	}
+	for _, ref := range currentRefs {
+		if !inProposed[*ref] {
+			removed = append(removed, ref)
+		}
+	}
+	return added, removed
+}
+
+// taskKey identifies a task by its plugin and options, the options are compared by their JSON
+// so the numbers decoded from the database match the ones of the plan
+func taskKey(plugin string, options map[string]interface{}) string {
+	blob, err := json.Marshal(options)
+	if err != nil {
+		return plugin
+	}
+	var normalized interface{}
+	if json.Unmarshal(blob, &normalized) == nil {
+		blob, _ = json.Marshal(normalized)
+	}
+	return plugin + ":" + string(blob)
+}
+
+func planTaskImpacts(plan models.PipelinePlan, impact string) []*PlanTaskImpact {
+	tasks := make([]*PlanTaskImpact, 0)
+	for _, stage := range plan {
+		for _, task := range stage {
+			tasks = append(tasks, &PlanTaskImpact{
+				Plugin:   task.Plugin,
+				Options:  task.Options,
+				Subtasks: task.Subtasks,
+				Impact:   impact,
+			})
+		}
+	}
+	return tasks
+}
+
+// diffPlans compares the tasks of the proposed plan with the current one, a task of the same plugin and options
+// with different subtasks is CHANGED
+func diffPlans(current, proposed models.PipelinePlan) (tasks []*PlanTaskImpact, removed []*PlanTaskImpact) {
+	currentTasks := make(map[string]*models.PipelineTask)
+	for _, stage := range current {
+		for _, task := range stage {
+			currentTasks[taskKey(task.Plugin, task.Options)] = task
+		}
+	}
+	seen := make(map[string]bool)
+	tasks = planTaskImpacts(proposed, TASK_IMPACT_ADDED)
+	for _, task := range tasks {
+		key := taskKey(task.Plugin, task.Options)
+		seen[key] = true
+		currentTask, ok := currentTasks[key]
+		if !ok {
+			continue
+		}
+		task.AddedSubtasks = stringsMinus(task.Subtasks, currentTask.Subtasks)
+		task.RemovedSubtasks = stringsMinus(currentTask.Subtasks, task.Subtasks)
+		if len(task.AddedSubtasks) > 0 || len(task.RemovedSubtasks) > 0 {
+			task.Impact = TASK_IMPACT_CHANGED
+		} else {
+			task.Impact = TASK_IMPACT_UNCHANGED
+		}
+	}
+	removed = make([]*PlanTaskImpact, 0)
+	for _, task := range planTaskImpacts(current, TASK_IMPACT_REMOVED) {
+		if !seen[taskKey(task.Plugin, task.Options)] {
+			removed = append(removed, task)
+		}
+	}
+	return tasks, removed
+}
+
+func stringsMinus(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, s := range b {
+		inB[s] = true
+	}
+	var result []string
+	for _, s := range a {
+		if !inB[s] {
+			result = append(result, s)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+type taskDurationEstimator struct {
+	byKey    map[string]int64
+	byPlugin map[string]int64
+}
+
+func loadTaskDurationEstimator(blueprintId uint64) (*taskDurationEstimator, errors.Error) {
+	var pipelineIds []uint64
+	err := db.Pluck("id", &pipelineIds,
+		dal.From(&models.Pipeline{}),
+		dal.Where("blueprint_id = ? AND status IN ?", blueprintId, []string{models.TASK_COMPLETED, models.TASK_PARTIAL}),
+		dal.Orderby("id DESC"),
+		dal.Limit(impactEstimationPipelines),
+	)
+	if err != nil {
+		return nil, err
+	}
+	var tasks []*models.Task
+	if len(pipelineIds) > 0 {
+		err = db.All(&tasks,
+			dal.Where("pipeline_id IN ? AND status = ?", pipelineIds, models.TASK_COMPLETED),
+			dal.Orderby("id DESC"),
+		)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return newTaskDurationEstimator(tasks), nil
+}
+
+// newTaskDurationEstimator expects the tasks ordered from the latest, the latest duration of a task is used
+func newTaskDurationEstimator(tasks []*models.Task) *taskDurationEstimator {
+	estimator := &taskDurationEstimator{
+		byKey:    make(map[string]int64),
+		byPlugin: make(map[string]int64),
+	}
+	sums := make(map[string]int64)
+	counts := make(map[string]int64)
+	for _, task := range tasks {
+		key := taskKey(task.Plugin, task.Options)
+		if _, ok := estimator.byKey[key]; !ok {
+			estimator.byKey[key] = int64(task.SpentSeconds)
+		}
+		sums[task.Plugin] += int64(task.SpentSeconds)
+		counts[task.Plugin]++
+	}
+	for plugin, sum := range sums {
+		estimator.byPlugin[plugin] = sum / counts[plugin]
+	}
+	return estimator
+}
+
+func (e *taskDurationEstimator) estimate(plugin string, options map[string]interface{}) *int64 {
+	if seconds, ok := e.byKey[taskKey(plugin, options)]; ok {
+		return &seconds
+	}
+	if seconds, ok := e.byPlugin[plugin]; ok {
+		return &seconds
+	}
+	return nil
+}
+
+// estimatePlan fills the estimations of the impact, the tasks of a stage run in parallel so a stage lasts as
+// long as its slowest task
+func (e *taskDurationEstimator) estimatePlan(impact *BlueprintChangeImpact, plan models.PipelinePlan) {
+	i := 0
+	for _, stage := range plan {
+		var stageSeconds int64
+		for range stage {
+			task := impact.Tasks[i]
+			i++
+			task.EstimatedSeconds = e.estimate(task.Plugin, task.Options)
+			if task.EstimatedSeconds == nil {
+				impact.UnestimatedTasks++
+				continue
+			}
+			if *task.EstimatedSeconds > stageSeconds {
+				stageSeconds = *task.EstimatedSeconds
+			}
+		}
+		impact.EstimatedSeconds += stageSeconds
+	}
+}
@@ -0,0 +1,121 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffPlans(t *testing.T) {
+	current := models.PipelinePlan{
+		{
+			{Plugin: "github", Subtasks: []string{"collectApiIssues", "extractApiIssues"}, Options: map[string]interface{}{"connectionId": uint64(1), "githubId": 1}},
+			{Plugin: "github", Subtasks: []string{"collectApiIssues"}, Options: map[string]interface{}{"connectionId": uint64(1), "githubId": 2}},
+			{Plugin: "jira", Subtasks: []string{"collectIssues"}, Options: map[string]interface{}{"connectionId": uint64(1), "boardId": 1}},
+		},
+	}
+	proposed := models.PipelinePlan{
+		{
+			// options decoded from the database are float64
+			{Plugin: "github", Subtasks: []string{"collectApiIssues", "extractApiIssues"}, Options: map[string]interface{}{"connectionId": float64(1), "githubId": float64(1)}},
+			{Plugin: "github", Subtasks: []string{"collectApiPullRequests"}, Options: map[string]interface{}{"connectionId": uint64(1), "githubId": 2}},
+			{Plugin: "github", Subtasks: nil, Options: map[string]interface{}{"connectionId": uint64(1), "githubId": 3}},
+		},
+	}
+	tasks, removed := diffPlans(current, proposed)
+	assert.Len(t, tasks, 3)
+	assert.Equal(t, TASK_IMPACT_UNCHANGED, tasks[0].Impact)
+	assert.Equal(t, TASK_IMPACT_CHANGED, tasks[1].Impact)
+	assert.Equal(t, []string{"collectApiPullRequests"}, tasks[1].AddedSubtasks)
+	assert.Equal(t, []string{"collectApiIssues"}, tasks[1].RemovedSubtasks)
+	assert.Equal(t, TASK_IMPACT_ADDED, tasks[2].Impact)
+	assert.Len(t, removed, 1)
+	assert.Equal(t, "jira", removed[0].Plugin)
+	assert.Equal(t, TASK_IMPACT_REMOVED, removed[0].Impact)
+}
+
+func TestDiffBlueprintScopes(t *testing.T) {
+	current := []*models.BlueprintConnection{
+		{PluginName: "github", ConnectionId: 1, Scopes: []*models.BlueprintScope{{ScopeId: "1"}, {ScopeId: "2"}}},
+	}
+	proposed := []*models.BlueprintConnection{
+		{PluginName: "github", ConnectionId: 1, Scopes: []*models.BlueprintScope{{ScopeId: "2"}}},
+		{PluginName: "jira", ConnectionId: 1, Scopes: []*models.BlueprintScope{{ScopeId: "1"}}},
+	}
+	added, removed := diffBlueprintScopes(current, proposed)
+	assert.Equal(t, []*ScopeRef{{PluginName: "jira", ConnectionId: 1, ScopeId: "1"}}, added)
+	assert.Equal(t, []*ScopeRef{{PluginName: "github", ConnectionId: 1, ScopeId: "1"}}, removed)
+}
+
+func TestEstimatePlan(t *testing.T) {
+	// ordered from the latest
+	estimator := newTaskDurationEstimator([]*models.Task{
+		{Plugin: "github", Options: map[string]interface{}{"githubId": float64(1)}, SpentSeconds: 100},
+		{Plugin: "github", Options: map[string]interface{}{"githubId": float64(2)}, SpentSeconds: 50},
+		{Plugin: "github", Options: map[string]interface{}{"githubId": float64(1)}, SpentSeconds: 300},
+		{Plugin: "dora", Options: map[string]interface{}{"projectName": "p"}, SpentSeconds: 20},
+	})
+	plan := models.PipelinePlan{
+		{
+			{Plugin: "github", Options: map[string]interface{}{"githubId": 1}},
+			{Plugin: "github", Options: map[string]interface{}{"githubId": 3}},
+			{Plugin: "jira", Options: map[string]interface{}{"boardId": 1}},
+		},
+		{
+			{Plugin: "dora", Options: map[string]interface{}{"projectName": "p"}},
+		},
+	}
+	impact := &BlueprintChangeImpact{Tasks: planTaskImpacts(plan, TASK_IMPACT_RERUN)}
+	estimator.estimatePlan(impact, plan)
+	// the latest duration of the same task
+	assert.Equal(t, int64(100), *impact.Tasks[0].EstimatedSeconds)
+	// the average of the plugin
+	assert.Equal(t, int64(150), *impact.Tasks[1].EstimatedSeconds)
+	assert.Nil(t, impact.Tasks[2].EstimatedSeconds)
+	assert.Equal(t, int64(20), *impact.Tasks[3].EstimatedSeconds)
+	// the slowest task of each stage
+	assert.Equal(t, int64(170), impact.EstimatedSeconds)
+	assert.Equal(t, 1, impact.UnestimatedTasks)
+}
+
+func TestOverrideScopeConfig(t *testing.T) {
+	scopeConfig := map[string]interface{}{"deploymentPattern": "(?i)deploy"}
+	connections := []*models.BlueprintConnection{
+		{
+			PluginName:   "github",
+			ConnectionId: 1,
+			Scopes:       []*models.BlueprintScope{{ScopeId: "1"}, {ScopeId: "2"}},
+		},
+	}
+	overridden := overrideScopeConfig(connections, scopeConfig)
+	assert.Equal(t, []*models.BlueprintConnection{
+		{
+			PluginName:   "github",
+			ConnectionId: 1,
+			Scopes: []*models.BlueprintScope{
+				{ScopeId: "1", ScopeConfigOverride: scopeConfig},
+				{ScopeId: "2", ScopeConfigOverride: scopeConfig},
+			},
+		},
+	}, overridden)
+	// the blueprint connection is left untouched
+	assert.Nil(t, connections[0].Scopes[0].ScopeConfigOverride)
+}
@@ -34,7 +34,40 @@ func GeneratePlanJsonV200(
 	metrics map[string]json.RawMessage,
 	skipCollectors bool,
 ) (coreModels.PipelinePlan, errors.Error) {
-	var err errors.Error
+	sourcePlans, scopes, err := makeDataSourcePlansV200(connections, skipCollectors)
+	if err != nil {
+		return nil, err
+	}
+	metricPlans, err := makeMetricPlansV200(projectName, metrics)
+	if err != nil {
+		return nil, err
+	}
+	var planForProjectMapping coreModels.PipelinePlan
+	if projectName != "" {
+		p, err := plugin.GetPlugin("org")
+		if err != nil {
+			return nil, err
+		}
+		if pluginBp, ok := p.(plugin.ProjectMapper); ok {
+			planForProjectMapping, err = pluginBp.MapProject(projectName, scopes)
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+	plan := SequencializePipelinePlans(
+		planForProjectMapping,
+		ParallelizePipelinePlans(sourcePlans...),
+		ParallelizePipelinePlans(metricPlans...),
+	)
+	return plan, err
+}
+
+// makeDataSourcePlansV200 generates the plan of each connection and collects the scopes produced by the data-source plugins
+func makeDataSourcePlansV200(
+	connections []*coreModels.BlueprintConnection,
+	skipCollectors bool,
+) ([]coreModels.PipelinePlan, []plugin.Scope, errors.Error) {
 	// make plan for data-source coreModels fist. generate plan for each
 	// connection, then merge them into one legitimate plan and collect the
 	// scopes produced by the data-source plugins
@@ -44,12 +77,12 @@ func GeneratePlanJsonV200(
 		if len(connection.Scopes) == 0 && connection.PluginName != `webhook` && connection.PluginName != `jenkins` {
 			// webhook needn't scopes
 			// jenkins may upgrade from v100 and its scope is empty
-			return nil, errors.Default.New(fmt.Sprintf("connections[%d].scopes is empty", i))
+			return nil, nil, errors.Default.New(fmt.Sprintf("connections[%d].scopes is empty", i))
 		}
 
 		p, err := plugin.GetPlugin(connection.PluginName)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		if pluginBp, ok := p.(plugin.DataSourcePluginBlueprintV200); ok {
 			var pluginScopes []plugin.Scope
@@ -58,13 +91,13 @@ func GeneratePlanJsonV200(
 				connection.Scopes,
 			)
 			if err != nil {
-				return nil, err
+				return nil, nil, err
 			}
 			// collect scopes for the project. a github repository may produce
 			// 2 scopes, 1 repo and 1 board
 			scopes = append(scopes, pluginScopes...)
 		} else {
-			return nil, errors.Default.New(
+			return nil, nil, errors.Default.New(
 				fmt.Sprintf("plugin %s does not support DataSourcePluginBlueprintV200", connection.PluginName),
 			)
 		}
@@ -94,8 +127,11 @@ func GeneratePlanJsonV200(
 			}
 		}
 	}
+	return sourcePlans, scopes, nil
+}
 
-	// make plans for metric plugins
+// makeMetricPlansV200 generates the plan of each metric plugin enabled for the project
+func makeMetricPlansV200(projectName string, metrics map[string]json.RawMessage) ([]coreModels.PipelinePlan, errors.Error) {
 	metricPlans := make([]coreModels.PipelinePlan, len(metrics))
 	i := 0
 	for metricPluginName, metricPluginOptJson := range metrics {
@@ -119,25 +155,7 @@ func GeneratePlanJsonV200(
 			)
 		}
 	}
-	var planForProjectMapping coreModels.PipelinePlan
-	if projectName != "" {
-		p, err := plugin.GetPlugin("org")
-		if err != nil {
-			return nil, err
-		}
-		if pluginBp, ok := p.(plugin.ProjectMapper); ok {
-			planForProjectMapping, err = pluginBp.MapProject(projectName, scopes)
-			if err != nil {
-				return nil, err
-			}
-		}
-	}
-	plan := SequencializePipelinePlans(
-		planForProjectMapping,
-		ParallelizePipelinePlans(sourcePlans...),
-		ParallelizePipelinePlans(metricPlans...),
-	)
-	return plan, err
+	return metricPlans, nil
 }
 
 func removeCollectorTasks(plan coreModels.PipelinePlan) coreModels.PipelinePlan {
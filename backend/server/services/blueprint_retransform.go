@@ -0,0 +1,201 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// RetransformInput designates the scope config whose transformation rules (i.e. deploymentPattern, prType) changed
+type RetransformInput struct {
+	PluginName    string `json:"pluginName" validate:"required"`
+	ConnectionId  uint64 `json:"connectionId" validate:"required"`
+	ScopeConfigId uint64 `json:"scopeConfigId" validate:"required"`
+}
+
+// RetransformScopeConfig creates a pipeline for every enabled blueprint containing scopes of the scope config,
+// the pipelines skip the collectors and only run the data-source tasks of those scopes followed by the metric
+// plugins of the project, so the extractors and convertors replay the raw data already collected with the
+// updated scope config instead of collecting everything again
+func RetransformScopeConfig(input *RetransformInput) ([]*models.Pipeline, errors.Error) {
+	pipelines := make([]*models.Pipeline, 0)
+	err := forEachRetransformBlueprint(input, func(blueprint *models.Blueprint, _ []*models.BlueprintConnection, plan models.PipelinePlan) errors.Error {
+		syncPolicy := blueprint.SyncPolicy
+		syncPolicy.SkipCollectors = true
+		syncPolicy.FullSync = false
+		pipeline, err := createPipelineByPlan(blueprint, plan, syncPolicy)
+		if err == ErrEmptyPlan {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if err := SanitizePipeline(pipeline); err != nil {
+			return errors.Convert(err)
+		}
+		pipelines = append(pipelines, pipeline)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return pipelines, nil
+}
+
+// forEachRetransformBlueprint calls fn with the affected connections and the retransform plan of every enabled
+// blueprint containing scopes of the scope config
+func forEachRetransformBlueprint(
+	input *RetransformInput,
+	fn func(blueprint *models.Blueprint, connections []*models.BlueprintConnection, plan models.PipelinePlan) errors.Error,
+) errors.Error {
+	if input.PluginName == "" || input.ConnectionId == 0 || input.ScopeConfigId == 0 {
+		return errors.BadInput.New("pluginName, connectionId and scopeConfigId are required")
+	}
+	scopeIds, err := findScopeIdsByScopeConfig(input.PluginName, input.ConnectionId, input.ScopeConfigId)
+	if err != nil {
+		return err
+	}
+	if len(scopeIds) == 0 {
+		return nil
+	}
+	var blueprintIds []uint64
+	err = db.Pluck("DISTINCT blueprint_id", &blueprintIds,
+		dal.From(&models.BlueprintScope{}),
+		dal.Where("plugin_name = ? AND connection_id = ? AND scope_id IN ?", input.PluginName, input.ConnectionId, scopeIds),
+	)
+	if err != nil {
+		return err
+	}
+	affected := make(map[string]bool, len(scopeIds))
+	for _, scopeId := range scopeIds {
+		affected[scopeId] = true
+	}
+	for _, blueprintId := range blueprintIds {
+		blueprint, err := GetBlueprint(blueprintId, false)
+		if err != nil {
+			return err
+		}
+		if !blueprint.Enable || blueprint.Mode != models.BLUEPRINT_MODE_NORMAL {
+			continue
+		}
+		connections := filterRetransformConnections(blueprint.Connections, input.PluginName, input.ConnectionId, affected)
+		plan, err := makeRetransformPlan(blueprint.ProjectName, connections)
+		if err != nil {
+			return err
+		}
+		if err := fn(blueprint, connections, plan); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// findScopeIdsByScopeConfig returns the ids of the scopes of the connection using the scope config
+func findScopeIdsByScopeConfig(pluginName string, connectionId uint64, scopeConfigId uint64) ([]string, errors.Error) {
+	p, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, fmt.Sprintf("plugin %s not found", pluginName))
+	}
+	source, ok := p.(plugin.PluginSource)
+	if !ok {
+		return nil, errors.BadInput.New(fmt.Sprintf("plugin %s has no scope config", pluginName))
+	}
+	scopeModel := source.Scope()
+	idColumn, err := findScopeIdColumn(scopeModel)
+	if err != nil {
+		return nil, err
+	}
+	var scopeIds []string
+	err = db.Pluck(idColumn, &scopeIds,
+		dal.From(scopeModel.TableName()),
+		dal.Where("connection_id = ? AND scope_config_id = ?", connectionId, scopeConfigId),
+	)
+	return scopeIds, err
+}
+
+// findScopeIdColumn returns the id column of the scope table, which is the primary key besides connection_id
+func findScopeIdColumn(scopeModel dal.Tabler) (string, errors.Error) {
+	pkColumns, err := dal.GetPrimarykeyColumns(db, scopeModel)
+	if err != nil {
+		return "", err
+	}
+	idColumn := ""
+	for _, pkColumn := range pkColumns {
+		if pkColumn.Name() != "connection_id" {
+			idColumn = pkColumn.Name()
+		}
+	}
+	if len(pkColumns) != 2 || idColumn == "" {
+		return "", errors.Default.New(fmt.Sprintf("unable to find the id column of %s", scopeModel.TableName()))
+	}
+	return idColumn, nil
+}
+
+// filterRetransformConnections keeps the affected scopes of the connection and drops everything else
+func filterRetransformConnections(
+	connections []*models.BlueprintConnection,
+	pluginName string,
+	connectionId uint64,
+	affected map[string]bool,
+) []*models.BlueprintConnection {
+	var result []*models.BlueprintConnection
+	for _, connection := range connections {
+		if connection.PluginName != pluginName || connection.ConnectionId != connectionId {
+			continue
+		}
+		var scopes []*models.BlueprintScope
+		for _, scope := range connection.Scopes {
+			if affected[scope.ScopeId] {
+				scopes = append(scopes, scope)
+			}
+		}
+		if len(scopes) == 0 {
+			continue
+		}
+		filtered := *connection
+		filtered.Scopes = scopes
+		result = append(result, &filtered)
+	}
+	return result
+}
+
+// makeRetransformPlan generates the plan without collectors for the connections, the project mapping is left out
+// since the scopes of the project didn't change
+func makeRetransformPlan(projectName string, connections []*models.BlueprintConnection) (models.PipelinePlan, errors.Error) {
+	sourcePlans, _, err := makeDataSourcePlansV200(connections, true)
+	if err != nil {
+		return nil, err
+	}
+	metrics, err := loadProjectMetrics(projectName)
+	if err != nil {
+		return nil, err
+	}
+	metricPlans, err := makeMetricPlansV200(projectName, metrics)
+	if err != nil {
+		return nil, err
+	}
+	return SequencializePipelinePlans(
+		ParallelizePipelinePlans(sourcePlans...),
+		ParallelizePipelinePlans(metricPlans...),
+	), nil
+}
@@ -0,0 +1,57 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterRetransformConnections(t *testing.T) {
+	connections := []*models.BlueprintConnection{
+		{
+			PluginName:   "github",
+			ConnectionId: 1,
+			Scopes:       []*models.BlueprintScope{{ScopeId: "1"}, {ScopeId: "2"}, {ScopeId: "3"}},
+		},
+		{
+			PluginName:   "github",
+			ConnectionId: 2,
+			Scopes:       []*models.BlueprintScope{{ScopeId: "1"}},
+		},
+		{
+			PluginName:   "jira",
+			ConnectionId: 1,
+			Scopes:       []*models.BlueprintScope{{ScopeId: "1"}},
+		},
+	}
+	filtered := filterRetransformConnections(connections, "github", 1, map[string]bool{"1": true, "3": true})
+	assert.Equal(t, []*models.BlueprintConnection{
+		{
+			PluginName:   "github",
+			ConnectionId: 1,
+			Scopes:       []*models.BlueprintScope{{ScopeId: "1"}, {ScopeId: "3"}},
+		},
+	}, filtered)
+	// the blueprint connection is left untouched
+	assert.Len(t, connections[0].Scopes, 3)
+
+	assert.Empty(t, filterRetransformConnections(connections, "github", 1, map[string]bool{"4": true}))
+}
@@ -0,0 +1,366 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"fmt"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// defaultCanaryMaxPages is how many pages the collectors of a canary fetch for each input
+const defaultCanaryMaxPages = 2
+
+// CanaryQuery is a query for GetCanaries
+type CanaryQuery struct {
+	Pagination
+	Plugin       string `form:"plugin"`
+	ConnectionId uint64 `form:"connectionId"`
+}
+
+// CanaryReport is the outcome of a canary and the projection of the full collection of its scope and its connection
+type CanaryReport struct {
+	*models.Canary
+	Status       string `json:"status"`
+	SpentSeconds int    `json:"spentSeconds"`
+	ApiRequests  int64  `json:"apiRequests"`
+	// the projection is only available once the canary is finished, the Projected* numbers are of the canary scope
+	ProjectedApiRequests int64 `json:"projectedApiRequests"`
+	ProjectedSeconds     int64 `json:"projectedSeconds"`
+	RateLimitPerHour     int   `json:"rateLimitPerHour"`
+	// ProjectedQuotaHours is how many hours of the api rate limit the full collection would take, 0 if unknown
+	ProjectedQuotaHours float64 `json:"projectedQuotaHours"`
+	// the ConnectionProjected* numbers scale the ones of the canary scope by the number of scopes of the connection,
+	// assuming the scopes are of similar size
+	ScopeCount                     int64   `json:"scopeCount"`
+	ConnectionProjectedApiRequests int64   `json:"connectionProjectedApiRequests"`
+	ConnectionProjectedSeconds     int64   `json:"connectionProjectedSeconds"`
+	ConnectionProjectedQuotaHours  float64 `json:"connectionProjectedQuotaHours"`
+}
+
+// RunCanary creates a pipeline collecting a few pages of one scope of the connection, a connection has one canary
+// so the previous canary of the connection, if any, is replaced
+func RunCanary(input *models.ApiInputCanary) (*models.Canary, errors.Error) {
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	scopeId, pipeline, err := createCanaryPipeline(input)
+	if err != nil {
+		return nil, err
+	}
+	canary := &models.Canary{}
+	err = db.First(canary, dal.Where("plugin = ? AND connection_id = ?", input.Plugin, input.ConnectionId))
+	if err != nil && !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "error getting DB canary")
+	}
+	canary.Plugin = input.Plugin
+	canary.ConnectionId = input.ConnectionId
+	canary.ScopeId = scopeId
+	canary.PipelineId = pipeline.ID
+	if canary.ID != 0 {
+		err = db.Update(canary)
+	} else {
+		err = db.Create(canary)
+	}
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error saving DB canary")
+	}
+	return canary, nil
+}
+
+// RunCanaryForNewConnection runs a canary for the connection unless it has one already, it is called once scopes
+// are added to a connection. The canary is inserted before its pipeline is created, so that the unique key on
+// (plugin, connection_id) lets only one of the concurrent calls run it
+func RunCanaryForNewConnection(pluginName string, connectionId uint64) {
+	if !cfg.GetBool("CANARY_ON_NEW_CONNECTIONS") {
+		return
+	}
+	canary := &models.Canary{Plugin: pluginName, ConnectionId: connectionId}
+	err := db.Create(canary)
+	if err != nil {
+		if !db.IsDuplicationError(err) {
+			logger.Error(err, "create canary of %s connection #%d", pluginName, connectionId)
+		}
+		return
+	}
+	scopeId, pipeline, err := createCanaryPipeline(&models.ApiInputCanary{Plugin: pluginName, ConnectionId: connectionId})
+	if err != nil {
+		if err != ErrEmptyPlan {
+			logger.Error(err, "run canary of %s connection #%d", pluginName, connectionId)
+		}
+		// release the connection so that the canary is tried again once more scopes are added
+		if err := db.Delete(canary); err != nil {
+			logger.Error(err, "delete canary of %s connection #%d", pluginName, connectionId)
+		}
+		return
+	}
+	canary.ScopeId = scopeId
+	canary.PipelineId = pipeline.ID
+	if err := db.Update(canary); err != nil {
+		logger.Error(err, "update canary of %s connection #%d", pluginName, connectionId)
+	}
+}
+
+// createCanaryPipeline creates the pipeline collecting a few pages of the scope of the input, or of the first scope
+// of the connection if the input has none
+func createCanaryPipeline(input *models.ApiInputCanary) (string, *models.Pipeline, errors.Error) {
+	p, err := plugin.GetPlugin(input.Plugin)
+	if err != nil {
+		return "", nil, errors.BadInput.Wrap(err, fmt.Sprintf("plugin %s not found", input.Plugin))
+	}
+	pluginBp, ok := p.(plugin.DataSourcePluginBlueprintV200)
+	if !ok {
+		return "", nil, errors.BadInput.New(fmt.Sprintf("plugin %s does not support DataSourcePluginBlueprintV200", input.Plugin))
+	}
+	scopeId := input.ScopeId
+	if scopeId == "" {
+		scopeId, err = findFirstScopeId(p, input.ConnectionId)
+		if err != nil {
+			return "", nil, err
+		}
+	}
+	plan, _, err := pluginBp.MakeDataSourcePipelinePlanV200(input.ConnectionId, []*models.BlueprintScope{
+		{PluginName: input.Plugin, ConnectionId: input.ConnectionId, ScopeId: scopeId},
+	})
+	if err != nil {
+		return "", nil, err
+	}
+	plan = filterCollectionPlan(plan)
+	if plan.IsEmpty() {
+		return "", nil, ErrEmptyPlan
+	}
+	maxPages := defaultCanaryMaxPages
+	if cfg.IsSet("CANARY_MAX_PAGES") && cfg.GetInt("CANARY_MAX_PAGES") > 0 {
+		maxPages = cfg.GetInt("CANARY_MAX_PAGES")
+	}
+	pipeline, err := CreatePipeline(&models.NewPipeline{
+		Name:       fmt.Sprintf("canary of %s connection #%d", input.Plugin, input.ConnectionId),
+		Plan:       plan,
+		SyncPolicy: models.SyncPolicy{MaxPages: maxPages},
+	}, false)
+	if err != nil {
+		return "", nil, err
+	}
+	return scopeId, pipeline, nil
+}
+
+// GetCanaries returns a paginated list of canaries based on `query`
+func GetCanaries(query *CanaryQuery) ([]*models.Canary, int64, errors.Error) {
+	clauses := []dal.Clause{
+		dal.From(&models.Canary{}),
+	}
+	if query.Plugin != "" {
+		clauses = append(clauses, dal.Where("plugin = ?", query.Plugin))
+	}
+	if query.ConnectionId != 0 {
+		clauses = append(clauses, dal.Where("connection_id = ?", query.ConnectionId))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of canaries")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	canaries := make([]*models.Canary, 0)
+	err = db.All(&canaries, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB canaries")
+	}
+	return canaries, count, nil
+}
+
+// GetCanaryReport returns the canary with the projection of the full collection
+func GetCanaryReport(id uint64) (*CanaryReport, errors.Error) {
+	canary := &models.Canary{}
+	err := db.First(canary, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return nil, errors.NotFound.Wrap(err, fmt.Sprintf("canary not found: %d", id))
+		}
+		return nil, errors.Default.Wrap(err, "error getting DB canary")
+	}
+	pipeline, err := GetDbPipeline(canary.PipelineId)
+	if err != nil {
+		return nil, err
+	}
+	var taskIds []uint64
+	err = db.Pluck("id", &taskIds, dal.From(&models.Task{}), dal.Where("pipeline_id = ?", pipeline.ID))
+	if err != nil {
+		return nil, err
+	}
+	subtasks := make([]*models.Subtask, 0)
+	if len(taskIds) > 0 {
+		err = db.All(&subtasks, dal.Where("task_id IN ?", taskIds))
+		if err != nil {
+			return nil, err
+		}
+	}
+	report := &CanaryReport{
+		Canary:       canary,
+		Status:       pipeline.Status,
+		SpentSeconds: pipeline.SpentSeconds,
+	}
+	for _, subtask := range subtasks {
+		report.ApiRequests += subtask.ApiRequests
+	}
+	if pipeline.FinishedAt == nil {
+		return report, nil
+	}
+	report.ProjectedApiRequests = projectApiRequests(subtasks)
+	report.ProjectedSeconds = int64(pipeline.SpentSeconds)
+	if report.ApiRequests > 0 {
+		report.ProjectedSeconds = int64(pipeline.SpentSeconds) * report.ProjectedApiRequests / report.ApiRequests
+	}
+	report.RateLimitPerHour = getConnectionRateLimitPerHour(canary.Plugin, canary.ConnectionId)
+	if report.RateLimitPerHour > 0 {
+		report.ProjectedQuotaHours = float64(report.ProjectedApiRequests) / float64(report.RateLimitPerHour)
+	}
+	scopeCount, err := countScopes(canary.Plugin, canary.ConnectionId)
+	if err != nil {
+		return nil, err
+	}
+	projectConnection(report, scopeCount)
+	return report, nil
+}
+
+// projectConnection scales the projection of the canary scope by the number of scopes of the connection
+func projectConnection(report *CanaryReport, scopeCount int64) {
+	if scopeCount < 1 {
+		// the canary scope may have been removed since
+		scopeCount = 1
+	}
+	report.ScopeCount = scopeCount
+	report.ConnectionProjectedApiRequests = report.ProjectedApiRequests * scopeCount
+	report.ConnectionProjectedSeconds = report.ProjectedSeconds * scopeCount
+	report.ConnectionProjectedQuotaHours = report.ProjectedQuotaHours * float64(scopeCount)
+}
+
+// projectApiRequests estimates the api requests of the full collection from the canary. The collectors which know
+// their total pages project their own requests, the others, e.g. the ones taking the collected records as input,
+// are assumed to grow at the same ratio
+func projectApiRequests(subtasks []*models.Subtask) int64 {
+	var knownRequests, knownProjected, otherRequests int64
+	for _, subtask := range subtasks {
+		if subtask.ProjectedApiRequests > subtask.ApiRequests {
+			knownRequests += subtask.ApiRequests
+			knownProjected += subtask.ProjectedApiRequests
+		} else {
+			otherRequests += subtask.ApiRequests
+		}
+	}
+	if knownRequests == 0 {
+		return otherRequests
+	}
+	return knownProjected + otherRequests*knownProjected/knownRequests
+}
+
+// findFirstScopeId returns the id of the first scope of the connection
+func findFirstScopeId(p plugin.PluginMeta, connectionId uint64) (string, errors.Error) {
+	source, ok := p.(plugin.PluginSource)
+	if !ok {
+		return "", errors.BadInput.New(fmt.Sprintf("plugin %s has no scope", p.Name()))
+	}
+	scopeModel := source.Scope()
+	idColumn, err := findScopeIdColumn(scopeModel)
+	if err != nil {
+		return "", err
+	}
+	var scopeIds []string
+	err = db.Pluck(idColumn, &scopeIds,
+		dal.From(scopeModel.TableName()),
+		dal.Where("connection_id = ?", connectionId),
+		dal.Orderby(idColumn),
+		dal.Limit(1),
+	)
+	if err != nil {
+		return "", err
+	}
+	if len(scopeIds) == 0 {
+		return "", errors.BadInput.New(fmt.Sprintf("%s connection #%d has no scope", p.Name(), connectionId))
+	}
+	return scopeIds[0], nil
+}
+
+// countScopes returns the number of scopes of the connection
+func countScopes(pluginName string, connectionId uint64) (int64, errors.Error) {
+	p, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return 0, errors.BadInput.Wrap(err, fmt.Sprintf("plugin %s not found", pluginName))
+	}
+	source, ok := p.(plugin.PluginSource)
+	if !ok {
+		return 0, nil
+	}
+	return db.Count(
+		dal.From(source.Scope().TableName()),
+		dal.Where("connection_id = ?", connectionId),
+	)
+}
+
+// filterCollectionPlan keeps the tasks collecting from the connection, which are the ones with the connectionId
+// option, e.g. github and github_graphql are kept while gitextractor and refdiff are left out
+func filterCollectionPlan(plan models.PipelinePlan) models.PipelinePlan {
+	var filtered models.PipelinePlan
+	for _, stage := range plan {
+		var tasks models.PipelineStage
+		for _, task := range stage {
+			if _, ok := task.Options["connectionId"]; ok {
+				tasks = append(tasks, task)
+			}
+		}
+		if len(tasks) > 0 {
+			filtered = append(filtered, tasks)
+		}
+	}
+	return filtered
+}
+
+// getConnectionRateLimitPerHour returns the rate limit of the connection, or API_REQUESTS_PER_HOUR if the
+// connection has none
+func getConnectionRateLimitPerHour(pluginName string, connectionId uint64) int {
+	rateLimit := cfg.GetInt("API_REQUESTS_PER_HOUR")
+	p, err := plugin.GetPlugin(pluginName)
+	if err != nil {
+		return rateLimit
+	}
+	source, ok := p.(plugin.PluginSource)
+	if !ok {
+		return rateLimit
+	}
+	columns, err := dal.GetColumnNames(db, source.Connection(), func(cm dal.ColumnMeta) bool {
+		return cm.Name() == "rate_limit_per_hour"
+	})
+	if err != nil || len(columns) == 0 {
+		return rateLimit
+	}
+	var rateLimits []int
+	err = db.Pluck("rate_limit_per_hour", &rateLimits,
+		dal.From(source.Connection().TableName()),
+		dal.Where("id = ?", connectionId),
+	)
+	if err == nil && len(rateLimits) == 1 && rateLimits[0] > 0 {
+		return rateLimits[0]
+	}
+	return rateLimit
+}
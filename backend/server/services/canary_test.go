@@ -0,0 +1,80 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestProjectApiRequests(t *testing.T) {
+	// nothing was cut by the limit of pages
+	assert.Equal(t, int64(3), projectApiRequests([]*models.Subtask{
+		{Name: "collectIssues", ApiRequests: 2, ProjectedApiRequests: 2},
+		{Name: "collectComments", ApiRequests: 1, ProjectedApiRequests: 1},
+		{Name: "extractIssues"},
+	}))
+	// issues have 20 pages in total, the comments of the issues are expected to grow at the same ratio
+	assert.Equal(t, int64(20+30*10), projectApiRequests([]*models.Subtask{
+		{Name: "collectIssues", ApiRequests: 2, ProjectedApiRequests: 20},
+		{Name: "collectComments", ApiRequests: 30, ProjectedApiRequests: 30},
+		{Name: "extractIssues"},
+	}))
+	assert.Equal(t, int64(0), projectApiRequests(nil))
+}
+
+func TestProjectConnection(t *testing.T) {
+	report := &CanaryReport{ProjectedApiRequests: 200, ProjectedSeconds: 60, ProjectedQuotaHours: 0.5}
+	projectConnection(report, 4)
+	assert.Equal(t, int64(4), report.ScopeCount)
+	assert.Equal(t, int64(800), report.ConnectionProjectedApiRequests)
+	assert.Equal(t, int64(240), report.ConnectionProjectedSeconds)
+	assert.Equal(t, 2.0, report.ConnectionProjectedQuotaHours)
+
+	// the canary scope is counted even if it was removed since
+	report = &CanaryReport{ProjectedApiRequests: 200}
+	projectConnection(report, 0)
+	assert.Equal(t, int64(1), report.ScopeCount)
+	assert.Equal(t, int64(200), report.ConnectionProjectedApiRequests)
+}
+
+func TestFilterCollectionPlan(t *testing.T) {
+	plan := models.PipelinePlan{
+		{
+			{Plugin: "github", Subtasks: []string{"collectApiIssues"}, Options: map[string]interface{}{"connectionId": 1, "githubId": 1}},
+			{Plugin: "gitextractor", Options: map[string]interface{}{"repoId": "github:GithubRepo:1:1"}},
+		},
+		{
+			{Plugin: "refdiff", Options: map[string]interface{}{"repoId": "github:GithubRepo:1:1"}},
+		},
+		{
+			{Plugin: "github_graphql", Options: map[string]interface{}{"connectionId": 1, "githubId": 1}},
+		},
+	}
+	assert.Equal(t, models.PipelinePlan{
+		{
+			{Plugin: "github", Subtasks: []string{"collectApiIssues"}, Options: map[string]interface{}{"connectionId": 1, "githubId": 1}},
+		},
+		{
+			{Plugin: "github_graphql", Options: map[string]interface{}{"connectionId": 1, "githubId": 1}},
+		},
+	}, filterCollectionPlan(plan))
+	assert.True(t, filterCollectionPlan(models.PipelinePlan{{{Plugin: "dora"}}}).IsEmpty())
+}
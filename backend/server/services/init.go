@@ -119,6 +119,7 @@ func ExecuteMigration() errors.Error {
 	// cronjob for blueprint triggering
 	location := cron.WithLocation(time.UTC)
 	cronManager = cron.New(location)
+	reportCronManager = cron.New(location)
 	if err != nil {
 		panic(err)
 	}
@@ -43,6 +43,13 @@ func lockDatabase() {
 		Version:  version.Version,
 	}
 	errors.Must(db.Create(lockingHistory))
+	// a never released transaction would hold the lock of the whole sqlite database and block all writes,
+	// sqlite is meant for a single instance anyway
+	if db.Dialect() == "sqlite" {
+		lockingHistory.Succeeded = true
+		errors.Must(db.Update(lockingHistory))
+		return
+	}
 	// 2. obtain the lock: using a never released transaction
 	// This prevents multiple devlake instances from sharing the same database by locking the migration history table
 	// However, it would not work if any older devlake instances were already using the database.
@@ -0,0 +1,237 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/robfig/cron/v3"
+)
+
+// maintenanceCheckInterval is how often the deferred blueprints are checked for the end of the maintenance
+const maintenanceCheckInterval = time.Minute
+
+// the windows are cached in memory so they still take effect while the database is down for maintenance
+var maintenanceWindows []*models.MaintenanceWindow
+var deferredBlueprintIds = make(map[uint64]bool)
+var maintenanceLock sync.Mutex
+
+// MaintenanceStatus tells whether the instance is under maintenance and which blueprints are waiting for it to end
+type MaintenanceStatus struct {
+	UnderMaintenance     bool       `json:"underMaintenance"`
+	Until                *time.Time `json:"until"`
+	DeferredBlueprintIds []uint64   `json:"deferredBlueprintIds"`
+}
+
+// GetMaintenanceWindows returns all maintenance windows
+func GetMaintenanceWindows() ([]*models.MaintenanceWindow, errors.Error) {
+	windows := make([]*models.MaintenanceWindow, 0)
+	err := db.All(&windows, dal.Orderby("id"))
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB maintenance windows")
+	}
+	return windows, nil
+}
+
+// CreateMaintenanceWindow validates and saves the maintenance window, it takes effect right away
+func CreateMaintenanceWindow(input *models.ApiInputMaintenanceWindow) (*models.MaintenanceWindow, errors.Error) {
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	window := &models.MaintenanceWindow{
+		Name:       input.Name,
+		CronConfig: input.CronConfig,
+		Duration:   input.Duration,
+		StartAt:    input.StartAt,
+		EndAt:      input.EndAt,
+	}
+	if err := validateMaintenanceWindow(window); err != nil {
+		return nil, err
+	}
+	err := db.Create(window)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating DB maintenance window")
+	}
+	return window, ReloadMaintenanceWindows()
+}
+
+// DeleteMaintenanceWindow deletes the maintenance window, the blueprints it deferred run once no window is active
+func DeleteMaintenanceWindow(id uint64) errors.Error {
+	if id == 0 {
+		return errors.BadInput.New("maintenance window's id is missing")
+	}
+	err := db.Delete(&models.MaintenanceWindow{}, dal.Where("id = ?", id))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting DB maintenance window")
+	}
+	return ReloadMaintenanceWindows()
+}
+
+// ReloadMaintenanceWindows refreshes the maintenance windows cached in memory
+func ReloadMaintenanceWindows() errors.Error {
+	windows, err := GetMaintenanceWindows()
+	if err != nil {
+		return err
+	}
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+	maintenanceWindows = windows
+	return nil
+}
+
+// GetMaintenanceStatus returns the maintenance status of the instance at the moment
+func GetMaintenanceStatus() *MaintenanceStatus {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+	status := &MaintenanceStatus{
+		Until:                getMaintenanceEnd(maintenanceWindows, time.Now()),
+		DeferredBlueprintIds: make([]uint64, 0, len(deferredBlueprintIds)),
+	}
+	status.UnderMaintenance = status.Until != nil
+	for id := range deferredBlueprintIds {
+		status.DeferredBlueprintIds = append(status.DeferredBlueprintIds, id)
+	}
+	sort.Slice(status.DeferredBlueprintIds, func(i, j int) bool {
+		return status.DeferredBlueprintIds[i] < status.DeferredBlueprintIds[j]
+	})
+	return status
+}
+
+func validateMaintenanceWindow(window *models.MaintenanceWindow) errors.Error {
+	if window.CronConfig != "" || window.Duration != "" {
+		if window.StartAt != nil || window.EndAt != nil {
+			return errors.BadInput.New("a maintenance window either recurs with cronConfig and duration or happens once between startAt and endAt")
+		}
+		if _, err := cron.ParseStandard(window.CronConfig); err != nil {
+			return errors.BadInput.Wrap(err, "invalid cronConfig")
+		}
+		duration, err := time.ParseDuration(window.Duration)
+		if err != nil {
+			return errors.BadInput.Wrap(err, "invalid duration")
+		}
+		if duration <= 0 {
+			return errors.BadInput.New("duration must be positive")
+		}
+		return nil
+	}
+	if window.StartAt == nil || window.EndAt == nil {
+		return errors.BadInput.New("either cronConfig and duration, or startAt and endAt are required")
+	}
+	if !window.EndAt.After(*window.StartAt) {
+		return errors.BadInput.New("endAt must be after startAt")
+	}
+	return nil
+}
+
+// getMaintenanceWindowEnd returns the end of the window if it is active at the given time, nil otherwise
+func getMaintenanceWindowEnd(window *models.MaintenanceWindow, now time.Time) *time.Time {
+	if window.CronConfig == "" {
+		if window.StartAt == nil || window.EndAt == nil || now.Before(*window.StartAt) || !now.Before(*window.EndAt) {
+			return nil
+		}
+		return window.EndAt
+	}
+	schedule, err := cron.ParseStandard(window.CronConfig)
+	if err != nil {
+		return nil
+	}
+	duration, err := time.ParseDuration(window.Duration)
+	if err != nil {
+		return nil
+	}
+	// blueprints are scheduled in UTC, so are the windows. The window is active if it started within the duration
+	start := schedule.Next(now.UTC().Add(-duration))
+	if start.After(now) {
+		return nil
+	}
+	end := start.Add(duration)
+	return &end
+}
+
+// getMaintenanceEnd returns the latest end of the windows active at the given time, nil if none is active
+func getMaintenanceEnd(windows []*models.MaintenanceWindow, now time.Time) *time.Time {
+	var until *time.Time
+	for _, window := range windows {
+		end := getMaintenanceWindowEnd(window, now)
+		if end != nil && (until == nil || end.After(*until)) {
+			until = end
+		}
+	}
+	return until
+}
+
+// isUnderMaintenance tells whether any maintenance window is active at the moment
+func isUnderMaintenance() bool {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+	return getMaintenanceEnd(maintenanceWindows, time.Now()) != nil
+}
+
+// deferBlueprintIfUnderMaintenance defers the blueprint to the end of the maintenance, a blueprint triggered
+// several times during the maintenance runs once
+func deferBlueprintIfUnderMaintenance(blueprintId uint64) bool {
+	maintenanceLock.Lock()
+	defer maintenanceLock.Unlock()
+	until := getMaintenanceEnd(maintenanceWindows, time.Now())
+	if until == nil {
+		return false
+	}
+	deferredBlueprintIds[blueprintId] = true
+	blueprintLog.Info("blueprint id:[%d] is deferred by the maintenance until %s", blueprintId, until.Format(time.RFC3339))
+	return true
+}
+
+// runDeferredBlueprints runs the blueprints deferred by the maintenance once it ends, a blueprint which can't be
+// loaded, e.g. the database is not back yet, stays deferred for the next check
+func runDeferredBlueprints() {
+	if isUnderMaintenance() {
+		return
+	}
+	maintenanceLock.Lock()
+	ids := deferredBlueprintIds
+	deferredBlueprintIds = make(map[uint64]bool)
+	maintenanceLock.Unlock()
+	for id := range ids {
+		blueprint, err := GetBlueprint(id, false)
+		if err != nil {
+			if err.GetType() == errors.NotFound {
+				continue
+			}
+			blueprintLog.Error(err, "load deferred blueprint id:[%d]", id)
+			maintenanceLock.Lock()
+			deferredBlueprintIds[id] = true
+			maintenanceLock.Unlock()
+			continue
+		}
+		if blueprint.Enable {
+			runBlueprintJob(blueprint)
+		}
+	}
+}
+
+// watchMaintenanceWindows checks periodically for the end of the maintenance
+func watchMaintenanceWindows() {
+	for range time.Tick(maintenanceCheckInterval) {
+		runDeferredBlueprints()
+	}
+}
@@ -0,0 +1,63 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetMaintenanceEnd(t *testing.T) {
+	at := func(s string) time.Time {
+		v, err := time.Parse(time.RFC3339, s)
+		if err != nil {
+			panic(err)
+		}
+		return v
+	}
+	startAt, endAt := at("2023-12-20T10:00:00Z"), at("2023-12-20T12:00:00Z")
+	nightly := &models.MaintenanceWindow{CronConfig: "0 2 * * *", Duration: "1h"}
+	once := &models.MaintenanceWindow{StartAt: &startAt, EndAt: &endAt}
+
+	assert.Nil(t, getMaintenanceEnd(nil, at("2023-12-20T02:30:00Z")))
+	assert.Equal(t, at("2023-12-20T03:00:00Z"), *getMaintenanceEnd([]*models.MaintenanceWindow{nightly}, at("2023-12-20T02:00:00Z")))
+	assert.Equal(t, at("2023-12-20T03:00:00Z"), *getMaintenanceEnd([]*models.MaintenanceWindow{nightly}, at("2023-12-20T02:30:00Z")))
+	assert.Nil(t, getMaintenanceEnd([]*models.MaintenanceWindow{nightly}, at("2023-12-20T03:00:00Z")))
+	assert.Nil(t, getMaintenanceEnd([]*models.MaintenanceWindow{nightly}, at("2023-12-20T01:59:00Z")))
+	assert.Equal(t, endAt, *getMaintenanceEnd([]*models.MaintenanceWindow{nightly, once}, at("2023-12-20T11:00:00Z")))
+	assert.Nil(t, getMaintenanceEnd([]*models.MaintenanceWindow{nightly, once}, endAt))
+
+	// overlapping windows last until the latest end
+	longer := &models.MaintenanceWindow{CronConfig: "30 2 * * *", Duration: "2h"}
+	assert.Equal(t, at("2023-12-20T04:30:00Z"), *getMaintenanceEnd([]*models.MaintenanceWindow{nightly, longer}, at("2023-12-20T02:45:00Z")))
+}
+
+func TestValidateMaintenanceWindow(t *testing.T) {
+	startAt := time.Now()
+	endAt := startAt.Add(time.Hour)
+	assert.Nil(t, validateMaintenanceWindow(&models.MaintenanceWindow{CronConfig: "0 2 * * *", Duration: "1h"}))
+	assert.Nil(t, validateMaintenanceWindow(&models.MaintenanceWindow{StartAt: &startAt, EndAt: &endAt}))
+	assert.NotNil(t, validateMaintenanceWindow(&models.MaintenanceWindow{CronConfig: "0 2 * * *"}))
+	assert.NotNil(t, validateMaintenanceWindow(&models.MaintenanceWindow{CronConfig: "bad", Duration: "1h"}))
+	assert.NotNil(t, validateMaintenanceWindow(&models.MaintenanceWindow{CronConfig: "0 2 * * *", Duration: "1h", StartAt: &startAt}))
+	assert.NotNil(t, validateMaintenanceWindow(&models.MaintenanceWindow{StartAt: &endAt, EndAt: &startAt}))
+	assert.NotNil(t, validateMaintenanceWindow(&models.MaintenanceWindow{}))
+}
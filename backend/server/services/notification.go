@@ -61,6 +61,11 @@ func (n *NotificationService) PipelineStatusChanged(params PipelineNotification)
 	return n.sendNotification(models.NotificationPipelineStatusChanged, params)
 }
 
+// TokenExpiring notifies that the token of a connection is about to expire
+func (n *NotificationService) TokenExpiring(params TokenExpiryNotification) errors.Error {
+	return n.sendNotification(models.NotificationTokenExpiring, params)
+}
+
 func (n *NotificationService) sendNotification(notificationType models.NotificationType, data interface{}) errors.Error {
 	var dataJson, err = json.Marshal(data)
 	if err != nil {
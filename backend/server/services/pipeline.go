@@ -107,6 +107,18 @@ func pipelineServiceInit() {
 		panic(err)
 	}
 
+	err = ReloadReportSchedules()
+	if err != nil {
+		panic(err)
+	}
+
+	err = ReloadMaintenanceWindows()
+	if err != nil {
+		panic(err)
+	}
+	go watchMaintenanceWindows()
+	go watchTokenExpiries()
+
 	var pipelineMaxParallel = cfg.GetInt64("PIPELINE_MAX_PARALLEL")
 	if pipelineMaxParallel < 0 {
 		panic(errors.BadInput.New(`PIPELINE_MAX_PARALLEL should be a positive integer`))
@@ -288,6 +300,11 @@ func RunPipelineInQueue(pipelineMaxParallel int64) {
 		globalPipelineLog.Info("get lock and wait next pipeline")
 		var dbPipeline *models.Pipeline
 		for {
+			// hold the queued pipelines during the maintenance, the running ones are left as is
+			if isUnderMaintenance() {
+				time.Sleep(time.Second)
+				continue
+			}
 			dbPipeline, err = dequeuePipeline(runningParallelLabels)
 			if err == nil && dbPipeline != nil {
 				break
@@ -0,0 +1,212 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"crypto/sha1"
+	"database/sql"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/utils"
+	"github.com/apache/incubator-devlake/helpers/pluginhelper"
+	"github.com/google/uuid"
+)
+
+const defaultPipelineArtifactsDir = "./artifacts"
+
+// artifactTable is a raw or tool layer table of a plugin, with the columns telling which scope a row belongs to
+// and when it was written
+type artifactTable struct {
+	Name         string
+	ParamsColumn string
+	TimeColumn   string
+}
+
+// getArtifactTables picks the raw and tool layer tables of the given plugins out of all tables
+func getArtifactTables(allTables []string, plugins []string) []artifactTable {
+	var tables []artifactTable
+	for _, table := range allTables {
+		for _, plugin := range plugins {
+			if strings.HasPrefix(table, fmt.Sprintf("_raw_%s_", plugin)) {
+				tables = append(tables, artifactTable{Name: table, ParamsColumn: "params", TimeColumn: "created_at"})
+				break
+			}
+			if strings.HasPrefix(table, fmt.Sprintf("_tool_%s_", plugin)) {
+				tables = append(tables, artifactTable{Name: table, ParamsColumn: "_raw_data_params", TimeColumn: "updated_at"})
+				break
+			}
+		}
+	}
+	sort.Slice(tables, func(i, j int) bool {
+		return tables[i].Name < tables[j].Name
+	})
+	return tables
+}
+
+func getPipelineArtifactsPath(pipeline *models.Pipeline) string {
+	dir := cfg.GetString("PIPELINE_ARTIFACTS_DIR")
+	if dir == "" {
+		dir = defaultPipelineArtifactsDir
+	}
+	return filepath.Join(dir, fmt.Sprintf("pipeline-%d", pipeline.ID))
+}
+
+// producePipelineArtifacts dumps the rows written by the pipeline into csv files, one file per table and scope,
+// rows are matched by the time they were written, so pipelines of the same plugins running in parallel may
+// find their rows in the artifacts of each other
+func producePipelineArtifacts(pipeline *models.Pipeline) errors.Error {
+	if !pipeline.ProduceArtifacts || pipeline.BeganAt == nil || pipeline.FinishedAt == nil {
+		return nil
+	}
+	tasks, err := GetLatestTasksOfPipeline(pipeline)
+	if err != nil {
+		return err
+	}
+	var plugins []string
+	for _, task := range tasks {
+		plugins = append(plugins, task.Plugin)
+	}
+	allTables, err := db.AllTables()
+	if err != nil {
+		return err
+	}
+	// a rerun pipeline produces its artifacts again
+	dir := getPipelineArtifactsPath(pipeline)
+	if e := os.RemoveAll(dir); e != nil {
+		return errors.Default.Wrap(e, fmt.Sprintf("error removing artifacts of pipeline #%d", pipeline.ID))
+	}
+	err = db.Delete(&models.PipelineArtifact{}, dal.Where("pipeline_id = ?", pipeline.ID))
+	if err != nil {
+		return err
+	}
+	for _, table := range getArtifactTables(allTables, utils.StringsUniq(plugins)) {
+		columns, err := dal.GetColumnNames(db, dal.DefaultTabler{Name: table.Name}, func(cm dal.ColumnMeta) bool {
+			return cm.Name() == table.ParamsColumn || cm.Name() == table.TimeColumn
+		})
+		if err != nil {
+			return err
+		}
+		if len(columns) < 2 {
+			continue
+		}
+		var scopes []string
+		err = db.Pluck(
+			fmt.Sprintf("DISTINCT %s", table.ParamsColumn),
+			&scopes,
+			dal.From(table.Name),
+			dal.Where(fmt.Sprintf("%s BETWEEN ? AND ?", table.TimeColumn), pipeline.BeganAt, pipeline.FinishedAt),
+		)
+		if err != nil {
+			return err
+		}
+		for _, params := range scopes {
+			artifact, err := dumpPipelineArtifact(pipeline, dir, table, params)
+			if err != nil {
+				return err
+			}
+			if err = db.Create(artifact); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func dumpPipelineArtifact(pipeline *models.Pipeline, dir string, table artifactTable, params string) (*models.PipelineArtifact, errors.Error) {
+	cursor, err := db.Cursor(
+		dal.From(table.Name),
+		dal.Where(
+			fmt.Sprintf("%s = ? AND %s BETWEEN ? AND ?", table.ParamsColumn, table.TimeColumn),
+			params, pipeline.BeganAt, pipeline.FinishedAt,
+		),
+	)
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close()
+	columns, err := errors.Convert01(cursor.Columns())
+	if err != nil {
+		return nil, err
+	}
+	// scopes are named after the hash of their params since the params are not fit for a file name
+	path := filepath.Join(table.Name, fmt.Sprintf("%x.csv", sha1.Sum([]byte(params))))
+	if e := os.MkdirAll(filepath.Join(dir, table.Name), os.ModePerm); e != nil {
+		return nil, errors.Default.Wrap(e, fmt.Sprintf("error creating artifacts directory of pipeline #%d", pipeline.ID))
+	}
+	csvWriter, err := pluginhelper.NewCsvFileWriter(filepath.Join(dir, path), columns)
+	if err != nil {
+		return nil, err
+	}
+	defer csvWriter.Close()
+	scanValues := make([]interface{}, len(columns))
+	for i := range scanValues {
+		scanValues[i] = new(sql.NullString)
+	}
+	rows := 0
+	for cursor.Next() {
+		if err = errors.Convert(cursor.Scan(scanValues...)); err != nil {
+			return nil, errors.Default.Wrap(err, fmt.Sprintf("unable to scan row on table %s", table.Name))
+		}
+		values := make([]string, len(columns))
+		for i, v := range scanValues {
+			values[i] = v.(*sql.NullString).String
+		}
+		csvWriter.Write(values)
+		rows++
+	}
+	return &models.PipelineArtifact{
+		PipelineId:    pipeline.ID,
+		DataTable:     table.Name,
+		RawDataParams: params,
+		Rows:          rows,
+		Path:          path,
+	}, nil
+}
+
+// GetPipelineArtifacts returns the artifacts produced by the pipeline
+func GetPipelineArtifacts(pipelineId uint64) ([]*models.PipelineArtifact, errors.Error) {
+	var artifacts []*models.PipelineArtifact
+	err := db.All(&artifacts, dal.Where("pipeline_id = ?", pipelineId), dal.Orderby("id"))
+	if err != nil {
+		return nil, err
+	}
+	return artifacts, nil
+}
+
+// GetPipelineArtifactsArchivePath creates an archive for the artifacts of this pipeline and returns its file path
+func GetPipelineArtifactsArchivePath(pipeline *models.Pipeline) (string, errors.Error) {
+	dir := getPipelineArtifactsPath(pipeline)
+	if _, e := os.Stat(dir); e != nil {
+		if os.IsNotExist(e) {
+			return "", errors.NotFound.Wrap(e, fmt.Sprintf("artifacts for pipeline #%d not found", pipeline.ID))
+		}
+		return "", errors.Default.Wrap(e, fmt.Sprintf("error validating artifacts path for pipeline #%d", pipeline.ID))
+	}
+	archive := fmt.Sprintf("%s/%s/artifacts.tar.gz", os.TempDir(), uuid.New())
+	if err := utils.CreateGZipArchive(archive, fmt.Sprintf("%s/*", dir)); err != nil {
+		return "", err
+	}
+	return archive, nil
+}
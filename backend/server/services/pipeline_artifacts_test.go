@@ -0,0 +1,45 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetArtifactTables(t *testing.T) {
+	tables := getArtifactTables(
+		[]string{
+			"_tool_github_repos",
+			"_raw_github_api_issues",
+			"_raw_github_graphql_prs",
+			"_raw_gitlab_api_issues",
+			"_tool_gitlab_projects",
+			"_devlake_pipelines",
+			"issues",
+		},
+		[]string{"github"},
+	)
+	assert.Equal(t, []artifactTable{
+		{Name: "_raw_github_api_issues", ParamsColumn: "params", TimeColumn: "created_at"},
+		{Name: "_raw_github_graphql_prs", ParamsColumn: "params", TimeColumn: "created_at"},
+		{Name: "_tool_github_repos", ParamsColumn: "_raw_data_params", TimeColumn: "updated_at"},
+	}, tables)
+	assert.Empty(t, getArtifactTables([]string{"_raw_github_api_issues"}, nil))
+}
@@ -102,6 +102,10 @@ func runPipeline(pipelineId uint64) errors.Error {
 		globalPipelineLog.Error(err, "update pipeline state failed")
 		return err
 	}
+	// artifacts are optional, failing to produce them doesn't fail the pipeline
+	if e := producePipelineArtifacts(dbPipeline); e != nil {
+		globalPipelineLog.Error(e, "produce artifacts of pipeline %d failed", pipelineId)
+	}
 	// notify external webhook
 	return NotifyExternal(pipelineId)
 }
@@ -0,0 +1,84 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+)
+
+// ProjectHealthScoreQuery used to query the health scores calculated by the dora plugin
+type ProjectHealthScoreQuery struct {
+	Pagination
+	// ProjectName returns the history of the project instead of the latest score of all projects
+	ProjectName string `form:"projectName"`
+	// AsOf returns the scores as they were reported at the time, see parseAsOf
+	AsOf string `form:"asOf"`
+}
+
+// GetProjectHealthScores returns the latest health score of every project, or the history of one project
+// if `query.ProjectName` is specified, newest first. The snapshots are never updated once the day is over,
+// so `query.AsOf` answers what the scores looked like back then
+func GetProjectHealthScores(query *ProjectHealthScoreQuery) ([]*crossdomain.ProjectHealthScore, int64, errors.Error) {
+	if err := VerifyStruct(query); err != nil {
+		return nil, 0, err
+	}
+	asOf, err := parseAsOf(query.AsOf)
+	if err != nil {
+		return nil, 0, err
+	}
+	clauses := []dal.Clause{
+		dal.From("project_health_scores phs"),
+	}
+	if asOf != nil {
+		clauses = append(clauses, dal.Where("phs.snapshot_date < ?", *asOf))
+	}
+	if query.ProjectName != "" {
+		clauses = append(clauses, dal.Where("phs.project_name = ?", query.ProjectName))
+	} else if asOf != nil {
+		clauses = append(clauses, dal.Where(
+			`phs.snapshot_date = (
+				SELECT MAX(s.snapshot_date) FROM project_health_scores s WHERE s.project_name = phs.project_name AND s.snapshot_date < ?
+			)`,
+			*asOf,
+		))
+	} else {
+		clauses = append(clauses, dal.Where(
+			`phs.snapshot_date = (SELECT MAX(s.snapshot_date) FROM project_health_scores s WHERE s.project_name = phs.project_name)`,
+		))
+	}
+
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of project health scores")
+	}
+
+	clauses = append(clauses,
+		dal.Select("phs.*"),
+		dal.Orderby("phs.snapshot_date DESC, phs.project_name"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	scores := make([]*crossdomain.ProjectHealthScore, 0)
+	err = db.All(&scores, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB project health scores")
+	}
+	return scores, count, nil
+}
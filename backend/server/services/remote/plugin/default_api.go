@@ -74,9 +74,10 @@ func GetDefaultAPI(
 			// GetScopeLatestSyncState "connections/:connectionId/scopes/:scopeId/latest-sync-state"
 			// GetScope "connections/:connectionId/scopes/:scopeId"
 			// Because there may be slash in scopeId, so we handle it manually.
+			// PATCH and DELETE of "connections/:connectionId/scopes/:scopeId/latest-sync-state" are dispatched the same way.
 			"GET":    papi.GetScopeDispatcher,
-			"PATCH":  papi.UpdateScope,
-			"DELETE": papi.DeleteScope,
+			"PATCH":  papi.PatchScopeDispatcher,
+			"DELETE": papi.DeleteScopeDispatcher,
 		},
 		"connections/:connectionId/scope-configs": {
 			"POST": papi.PostScopeConfigs,
@@ -78,5 +78,47 @@ func (pa *pluginAPI) getRemoteScopesIncrementally(connection models.DynamicTable
 }
 
 func (pa *pluginAPI) SearchRemoteScopes(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
-	return &plugin.ApiResourceOutput{Status: http.StatusNotImplemented}, nil
+	connectionId, _ := strconv.ParseUint(input.Params["connectionId"], 10, 64)
+	if connectionId == 0 {
+		return nil, errors.BadInput.New("invalid connectionId")
+	}
+	connection := pa.connType.New()
+	err := pa.connhelper.First(connection, input.Params)
+	if err != nil {
+		return nil, err
+	}
+	search := input.Query.Get("search")
+	if search == "" {
+		return nil, errors.BadInput.New("search is required")
+	}
+	page, pageSize := 1, 50
+	if p, e := strconv.Atoi(input.Query.Get("page")); e == nil && p > 0 {
+		page = p
+	}
+	if ps, e := strconv.Atoi(input.Query.Get("pageSize")); e == nil && ps > 0 {
+		pageSize = ps
+	}
+	stream := pa.invoker.Stream("search-remote-scopes", bridge.DefaultContext, connection.Unwrap(), search, page, pageSize)
+	children := make([]RemoteScopesTreeNode, 0)
+	for recv := range stream.Receive() {
+		if recv.Err != nil {
+			return nil, recv.Err
+		}
+		scope := RemoteScopesTreeNode{}
+		err = recv.Get(&scope)
+		if err != nil {
+			return nil, err
+		}
+		// the config-ui is expecting the parent id to be null
+		scope.ParentId = nil
+		children = append(children, scope)
+	}
+	return &plugin.ApiResourceOutput{
+		Body: map[string]interface{}{
+			"children": children,
+			"page":     page,
+			"pageSize": pageSize,
+		},
+		Status: http.StatusOK,
+	}, nil
 }
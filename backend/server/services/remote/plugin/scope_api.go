@@ -114,6 +114,40 @@ func (pa *pluginAPI) GetScopeLatestSyncState(input *plugin.ApiResourceInput) (*p
 	return &plugin.ApiResourceOutput{Body: scopeSyncStates, Status: http.StatusOK}, nil
 }
 
+func (pa *pluginAPI) PatchScopeDispatcher(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	scopeIdWithSuffix := strings.TrimLeft(input.Params["scopeId"], "/")
+	if strings.HasSuffix(scopeIdWithSuffix, "/latest-sync-state") {
+		input.Params["scopeId"] = strings.TrimSuffix(scopeIdWithSuffix, "/latest-sync-state")
+		return pa.PatchScopeLatestSyncState(input)
+	}
+	return pa.UpdateScope(input)
+}
+
+func (pa *pluginAPI) DeleteScopeDispatcher(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	scopeIdWithSuffix := strings.TrimLeft(input.Params["scopeId"], "/")
+	if strings.HasSuffix(scopeIdWithSuffix, "/latest-sync-state") {
+		input.Params["scopeId"] = strings.TrimSuffix(scopeIdWithSuffix, "/latest-sync-state")
+		return pa.DeleteScopeLatestSyncState(input)
+	}
+	return pa.DeleteScope(input)
+}
+
+func (pa *pluginAPI) PatchScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	scopeSyncStates, err := pa.scopeHelper.SetScopeLatestSyncState(input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: scopeSyncStates, Status: http.StatusOK}, nil
+}
+
+func (pa *pluginAPI) DeleteScopeLatestSyncState(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
+	err := pa.scopeHelper.DeleteScopeLatestSyncState(input)
+	if err != nil {
+		return nil, err
+	}
+	return &plugin.ApiResourceOutput{Body: nil, Status: http.StatusOK}, nil
+}
+
 func (pa *pluginAPI) GetScope(input *plugin.ApiResourceInput) (*plugin.ApiResourceOutput, errors.Error) {
 	scope, err := pa.scopeHelper.GetScope(input)
 	if err != nil {
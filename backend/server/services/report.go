@@ -0,0 +1,319 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"bytes"
+	"fmt"
+	"html/template"
+	"mime"
+	"net/mail"
+	"net/smtp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/robfig/cron/v3"
+)
+
+// defaultReportCronConfig sends the digest every Monday morning
+const defaultReportCronConfig = "0 8 * * 1"
+
+// reportHistoryWeeks is how many weeks of snapshots a digest contains
+const reportHistoryWeeks = 4
+
+var reportCronManager *cron.Cron
+var reportReloadLock sync.Mutex
+
+// ReportScheduleQuery used to query report schedules as the api input
+type ReportScheduleQuery struct {
+	Pagination
+	ProjectName string `form:"projectName"`
+}
+
+// GetReportSchedules returns a paginated list of report schedules based on `query`
+func GetReportSchedules(query *ReportScheduleQuery) ([]*models.ReportSchedule, int64, errors.Error) {
+	if err := VerifyStruct(query); err != nil {
+		return nil, 0, err
+	}
+	clauses := []dal.Clause{
+		dal.From(&models.ReportSchedule{}),
+	}
+	if query.ProjectName != "" {
+		clauses = append(clauses, dal.Where("project_name = ?", query.ProjectName))
+	}
+	count, err := db.Count(clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error getting DB count of report schedules")
+	}
+	clauses = append(clauses,
+		dal.Orderby("id DESC"),
+		dal.Offset(query.GetSkip()),
+		dal.Limit(query.GetPageSize()),
+	)
+	schedules := make([]*models.ReportSchedule, 0)
+	err = db.All(&schedules, clauses...)
+	if err != nil {
+		return nil, 0, errors.Default.Wrap(err, "error finding DB report schedules")
+	}
+	return schedules, count, nil
+}
+
+// CreateReportSchedule validates and saves the report schedule, then schedules it if enabled
+func CreateReportSchedule(input *models.ApiInputReportSchedule) (*models.ReportSchedule, errors.Error) {
+	if err := VerifyStruct(input); err != nil {
+		return nil, err
+	}
+	if _, err := getProjectByName(db, input.ProjectName); err != nil {
+		return nil, err
+	}
+	schedule := &models.ReportSchedule{
+		ProjectName: input.ProjectName,
+		CronConfig:  input.CronConfig,
+		Recipients:  input.Recipients,
+		Enable:      input.Enable == nil || *input.Enable,
+	}
+	if schedule.CronConfig == "" {
+		schedule.CronConfig = defaultReportCronConfig
+	}
+	if _, err := cron.ParseStandard(schedule.CronConfig); err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid cronConfig")
+	}
+	if _, err := parseRecipients(schedule.Recipients); err != nil {
+		return nil, err
+	}
+	err := db.Create(schedule)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error creating DB report schedule")
+	}
+	return schedule, ReloadReportSchedules()
+}
+
+// DeleteReportSchedule deletes the report schedule and unschedules it
+func DeleteReportSchedule(id uint64) errors.Error {
+	if id == 0 {
+		return errors.BadInput.New("report schedule's id is missing")
+	}
+	err := db.Delete(&models.ReportSchedule{}, dal.Where("id = ?", id))
+	if err != nil {
+		return errors.Default.Wrap(err, "error deleting DB report schedule")
+	}
+	return ReloadReportSchedules()
+}
+
+// SendReport renders and sends the report of the schedule right away
+func SendReport(id uint64) errors.Error {
+	schedule := &models.ReportSchedule{}
+	err := db.First(schedule, dal.Where("id = ?", id))
+	if err != nil {
+		if db.IsErrorNotFound(err) {
+			return errors.NotFound.Wrap(err, fmt.Sprintf("report schedule not found: %d", id))
+		}
+		return errors.Default.Wrap(err, "error getting DB report schedule")
+	}
+	return sendReport(schedule)
+}
+
+// ReloadReportSchedules reschedules all enabled report schedules, the ones with an invalid cronConfig are skipped
+// so they don't unschedule the others
+func ReloadReportSchedules() errors.Error {
+	reportReloadLock.Lock()
+	defer reportReloadLock.Unlock()
+
+	schedules := make([]*models.ReportSchedule, 0)
+	err := db.All(&schedules, dal.Where("enable = ?", true))
+	if err != nil {
+		return errors.Default.Wrap(err, "error finding DB report schedules")
+	}
+	reportCronManager.Stop()
+	// always restart the cron, even if nothing could be scheduled
+	defer reportCronManager.Start()
+	for _, e := range reportCronManager.Entries() {
+		reportCronManager.Remove(e.ID)
+	}
+	scheduled := 0
+	for _, schedule := range schedules {
+		schedule := schedule
+		_, err := reportCronManager.AddFunc(schedule.CronConfig, func() {
+			if err := sendReport(schedule); err != nil {
+				logger.Error(err, "send report [%d] of project [%s]", schedule.ID, schedule.ProjectName)
+			}
+		})
+		if err != nil {
+			logger.Error(err, "%s for report [%d] of project [%s]", failToCreateCronJob, schedule.ID, schedule.ProjectName)
+			continue
+		}
+		scheduled++
+	}
+	logger.Info("total %d reports were scheduled", scheduled)
+	return nil
+}
+
+type reportData struct {
+	ProjectName  string
+	GeneratedAt  time.Time
+	HealthScores []*crossdomain.ProjectHealthScore
+	Traceability []*code.IssueTraceabilityMetric
+}
+
+func sendReport(schedule *models.ReportSchedule) errors.Error {
+	recipients, err := parseRecipients(schedule.Recipients)
+	if err != nil {
+		return err
+	}
+	data, err := loadReportData(schedule.ProjectName)
+	if err != nil {
+		return err
+	}
+	html, err := renderReport(data)
+	if err != nil {
+		return err
+	}
+	subject := fmt.Sprintf("[DevLake] Weekly digest of %s", schedule.ProjectName)
+	err = sendEmail(recipients, subject, html)
+	if err != nil {
+		return err
+	}
+	now := time.Now()
+	schedule.LastSentAt = &now
+	return db.Update(schedule)
+}
+
+func loadReportData(projectName string) (*reportData, errors.Error) {
+	now := time.Now()
+	since := now.AddDate(0, 0, -7*reportHistoryWeeks)
+	data := &reportData{
+		ProjectName:  projectName,
+		GeneratedAt:  now,
+		HealthScores: make([]*crossdomain.ProjectHealthScore, 0),
+		Traceability: make([]*code.IssueTraceabilityMetric, 0),
+	}
+	err := db.All(
+		&data.HealthScores,
+		dal.Where("project_name = ? AND snapshot_date >= ?", projectName, since),
+		dal.Orderby("snapshot_date DESC"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB project health scores")
+	}
+	err = db.All(
+		&data.Traceability,
+		dal.Select("itm.*"),
+		dal.From("issue_traceability_metrics itm"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'repos' AND pm.row_id = itm.repo_id)"),
+		dal.Where("pm.project_name = ? AND itm.week_start >= ?", projectName, since),
+		dal.Orderby("itm.week_start DESC, itm.repo_id"),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB issue traceability metrics")
+	}
+	return data, nil
+}
+
+var reportTemplate = template.Must(template.New("report").Funcs(template.FuncMap{
+	"score": func(score *float64) string {
+		if score == nil {
+			return "n/a"
+		}
+		return fmt.Sprintf("%.1f", *score)
+	},
+	"percent": func(value float64) string {
+		return fmt.Sprintf("%.1f%%", value)
+	},
+	"date": func(t time.Time) string {
+		return t.Format(time.DateOnly)
+	},
+}).Parse(`<html><body>
+<h2>{{.ProjectName}}</h2>
+<p>Generated at {{.GeneratedAt.UTC.Format "2006-01-02 15:04 MST"}}</p>
+<h3>Health score</h3>
+{{if .HealthScores}}<table border="1" cellpadding="4">
+<tr><th>Date</th><th>Score</th><th>DORA</th><th>Review coverage</th><th>Test stability</th><th>Incident load</th></tr>
+{{range .HealthScores}}<tr><td>{{date .SnapshotDate}}</td><td>{{printf "%.1f" .Score}}</td><td>{{score .DoraScore}}</td><td>{{score .ReviewCoverageScore}}</td><td>{{score .TestStabilityScore}}</td><td>{{score .IncidentLoadScore}}</td></tr>
+{{end}}</table>{{else}}<p>No data</p>{{end}}
+<h3>Issue traceability</h3>
+{{if .Traceability}}<table border="1" cellpadding="4">
+<tr><th>Week</th><th>Repo</th><th>Commits linked</th><th>Pull requests linked</th></tr>
+{{range .Traceability}}<tr><td>{{date .WeekStart}}</td><td>{{.RepoId}}</td><td>{{.LinkedCommitsCount}}/{{.CommitsCount}} ({{percent .CommitLinkRate}})</td><td>{{.LinkedPullRequestsCount}}/{{.PullRequestsCount}} ({{percent .PullRequestLinkRate}})</td></tr>
+{{end}}</table>{{else}}<p>No data</p>{{end}}
+</body></html>`))
+
+func renderReport(data *reportData) (string, errors.Error) {
+	var buf bytes.Buffer
+	err := reportTemplate.Execute(&buf, data)
+	if err != nil {
+		return "", errors.Default.Wrap(err, "error rendering report")
+	}
+	return buf.String(), nil
+}
+
+func parseRecipients(recipients string) ([]string, errors.Error) {
+	addresses, err := mail.ParseAddressList(recipients)
+	if err != nil {
+		return nil, errors.BadInput.Wrap(err, "invalid recipients")
+	}
+	result := make([]string, len(addresses))
+	for i, address := range addresses {
+		result[i] = address.Address
+	}
+	return result, nil
+}
+
+// sendEmail sends the html via the smtp server configured by SMTP_HOST, SMTP_PORT, SMTP_USERNAME, SMTP_PASSWORD
+// and SMTP_FROM
+func sendEmail(to []string, subject string, html string) errors.Error {
+	host := cfg.GetString("SMTP_HOST")
+	if host == "" {
+		return errors.BadInput.New("SMTP_HOST is not configured")
+	}
+	port := cfg.GetString("SMTP_PORT")
+	if port == "" {
+		port = "587"
+	}
+	username := cfg.GetString("SMTP_USERNAME")
+	from := cfg.GetString("SMTP_FROM")
+	if from == "" {
+		from = username
+	}
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, cfg.GetString("SMTP_PASSWORD"), host)
+	}
+	err := smtp.SendMail(fmt.Sprintf("%s:%s", host, port), auth, from, to, buildEmailMessage(from, to, subject, html))
+	if err != nil {
+		return errors.Default.Wrap(err, "error sending email")
+	}
+	return nil
+}
+
+func buildEmailMessage(from string, to []string, subject string, html string) []byte {
+	var buf bytes.Buffer
+	buf.WriteString(fmt.Sprintf("From: %s\r\n", from))
+	buf.WriteString(fmt.Sprintf("To: %s\r\n", strings.Join(to, ", ")))
+	buf.WriteString(fmt.Sprintf("Subject: %s\r\n", mime.QEncoding.Encode("utf-8", subject)))
+	buf.WriteString("MIME-Version: 1.0\r\n")
+	buf.WriteString("Content-Type: text/html; charset=\"UTF-8\"\r\n")
+	buf.WriteString("\r\n")
+	buf.WriteString(html)
+	return buf.Bytes()
+}
@@ -0,0 +1,88 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/models"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/helpers/unithelper"
+	mockdal "github.com/apache/incubator-devlake/mocks/core/dal"
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRenderReport(t *testing.T) {
+	dora := float64(75)
+	html, err := renderReport(&reportData{
+		ProjectName: "<project>",
+		GeneratedAt: time.Date(2023, 12, 18, 8, 0, 0, 0, time.UTC),
+		HealthScores: []*crossdomain.ProjectHealthScore{
+			{SnapshotDate: time.Date(2023, 12, 18, 0, 0, 0, 0, time.UTC), DoraScore: &dora, Score: 82.5},
+		},
+		Traceability: []*code.IssueTraceabilityMetric{
+			{RepoId: "github:GithubRepo:1:1", WeekStart: time.Date(2023, 12, 11, 0, 0, 0, 0, time.UTC), CommitsCount: 4, LinkedCommitsCount: 1, CommitLinkRate: 25},
+		},
+	})
+	assert.Nil(t, err)
+	assert.Contains(t, html, "&lt;project&gt;")
+	assert.Contains(t, html, "<td>2023-12-18</td><td>82.5</td><td>75.0</td><td>n/a</td>")
+	assert.Contains(t, html, "<td>1/4 (25.0%)</td>")
+
+	html, err = renderReport(&reportData{ProjectName: "empty"})
+	assert.Nil(t, err)
+	assert.Equal(t, 2, strings.Count(html, "No data"))
+}
+
+func TestParseRecipients(t *testing.T) {
+	recipients, err := parseRecipients("a@example.com, Bob <b@example.com>")
+	assert.Nil(t, err)
+	assert.Equal(t, []string{"a@example.com", "b@example.com"}, recipients)
+
+	_, err = parseRecipients("not an email")
+	assert.NotNil(t, err)
+}
+
+func TestBuildEmailMessage(t *testing.T) {
+	message := string(buildEmailMessage("lake@example.com", []string{"a@example.com", "b@example.com"}, "digest", "<p>hi</p>"))
+	assert.Contains(t, message, "To: a@example.com, b@example.com\r\n")
+	assert.Contains(t, message, "Content-Type: text/html")
+	assert.True(t, strings.HasSuffix(message, "\r\n\r\n<p>hi</p>"))
+}
+
+func TestReloadReportSchedulesSkipsInvalidCron(t *testing.T) {
+	mockDal := new(mockdal.Dal)
+	mockDal.On("All", mock.Anything, mock.Anything).Run(func(args mock.Arguments) {
+		schedules := args.Get(0).(*[]*models.ReportSchedule)
+		*schedules = []*models.ReportSchedule{
+			{ProjectName: "broken", CronConfig: "not a cron"},
+			{ProjectName: "weekly", CronConfig: defaultReportCronConfig},
+		}
+	}).Return(nil)
+	db, logger, reportCronManager = mockDal, unithelper.DummyLogger(), cron.New()
+	defer func() { db, logger, reportCronManager = nil, nil, nil }()
+
+	assert.Nil(t, ReloadReportSchedules())
+	assert.Len(t, reportCronManager.Entries(), 1)
+	reportCronManager.Stop()
+}
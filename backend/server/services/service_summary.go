@@ -0,0 +1,224 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"time"
+
+	"github.com/apache/incubator-devlake/core/dal"
+	"github.com/apache/incubator-devlake/core/errors"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/code"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/crossdomain"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/devops"
+	"github.com/apache/incubator-devlake/core/models/domainlayer/ticket"
+)
+
+// DORA performance levels of ServiceSummary.DoraCategory
+const (
+	DORA_ELITE  = "ELITE"
+	DORA_HIGH   = "HIGH"
+	DORA_MEDIUM = "MEDIUM"
+	DORA_LOW    = "LOW"
+)
+
+// ServiceSummary is a compact view of a project (or a repo of a project) for embedding in
+// developer portals like Backstage scorecards
+type ServiceSummary struct {
+	ProjectName       string                    `json:"projectName"`
+	RepoId            string                    `json:"repoId,omitempty"`
+	RepoName          string                    `json:"repoName,omitempty"`
+	DoraCategory      string                    `json:"doraCategory"`
+	HealthScore       *float64                  `json:"healthScore"`
+	LastDeployment    *ServiceDeploymentSummary `json:"lastDeployment"`
+	OpenIncidentCount int64                     `json:"openIncidentCount"`
+}
+
+type ServiceDeploymentSummary struct {
+	Id           string     `json:"id"`
+	Name         string     `json:"name"`
+	Result       string     `json:"result"`
+	Environment  string     `json:"environment"`
+	FinishedDate *time.Time `json:"finishedDate"`
+}
+
+// ServiceSummaryQuery AsOf returns the summary as of the time, see parseAsOf. The health score and the dora category
+// come from the snapshot reported back then, while the last deployment and the open incident count are derived from
+// the current rows by their dates, a deployment or an incident edited or deleted since changes them
+type ServiceSummaryQuery struct {
+	AsOf string `form:"asOf"`
+}
+
+// ServiceSummaryRepoQuery identifies a repo by its url or its name (i.e. `owner/repo`)
+type ServiceSummaryRepoQuery struct {
+	ServiceSummaryQuery
+	Url  string `form:"url"`
+	Name string `form:"name"`
+}
+
+// GetProjectServiceSummary returns the summary of the project
+func GetProjectServiceSummary(projectName string, query *ServiceSummaryQuery) (*ServiceSummary, errors.Error) {
+	if _, err := getProjectByName(db, projectName); err != nil {
+		return nil, err
+	}
+	asOf, err := parseAsOf(query.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	summary, err := makeServiceSummary(projectName, asOf)
+	if err != nil {
+		return nil, err
+	}
+	deployment := &devops.CICDDeployment{}
+	err = db.First(
+		deployment,
+		dal.Select("d.*"),
+		dal.From("cicd_deployments d"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'cicd_scopes' AND pm.row_id = d.cicd_scope_id)"),
+		dal.Where("pm.project_name = ? AND d.environment = ? AND d.finished_date < ?", projectName, devops.PRODUCTION, asOfOrNow(asOf)),
+		dal.Orderby("d.finished_date DESC"),
+	)
+	if err == nil {
+		summary.LastDeployment = &ServiceDeploymentSummary{
+			Id:           deployment.Id,
+			Name:         deployment.Name,
+			Result:       deployment.Result,
+			Environment:  deployment.Environment,
+			FinishedDate: deployment.FinishedDate,
+		}
+	} else if !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "error getting DB last deployment")
+	}
+	return summary, nil
+}
+
+// GetRepoServiceSummaries returns the summaries of the repo in every project it belongs to
+func GetRepoServiceSummaries(query *ServiceSummaryRepoQuery) ([]*ServiceSummary, errors.Error) {
+	asOf, err := parseAsOf(query.AsOf)
+	if err != nil {
+		return nil, err
+	}
+	var repoClause dal.Clause
+	switch {
+	case query.Url != "":
+		repoClause = dal.Where("url = ?", query.Url)
+	case query.Name != "":
+		repoClause = dal.Where("name = ?", query.Name)
+	default:
+		return nil, errors.BadInput.New("either url or name of the repo is required")
+	}
+	repos := make([]*code.Repo, 0)
+	err = db.All(&repos, repoClause)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error finding DB repos")
+	}
+	if len(repos) == 0 {
+		return nil, errors.NotFound.New("repo not found")
+	}
+	summaries := make([]*ServiceSummary, 0)
+	for _, repo := range repos {
+		var projectNames []string
+		err = db.Pluck(
+			"pm.project_name", &projectNames,
+			dal.From("project_mapping pm"),
+			dal.Where("pm.table = 'repos' AND pm.row_id = ?", repo.Id),
+		)
+		if err != nil {
+			return nil, errors.Default.Wrap(err, "error finding DB project mappings")
+		}
+		for _, projectName := range projectNames {
+			summary, err := makeServiceSummary(projectName, asOf)
+			if err != nil {
+				return nil, err
+			}
+			summary.RepoId = repo.Id
+			summary.RepoName = repo.Name
+			deploymentCommit := &devops.CicdDeploymentCommit{}
+			err = db.First(
+				deploymentCommit,
+				dal.Where(
+					"(repo_id = ? OR repo_url = ?) AND environment = ? AND finished_date < ?",
+					repo.Id, repo.Url, devops.PRODUCTION, asOfOrNow(asOf),
+				),
+				dal.Orderby("finished_date DESC"),
+			)
+			if err == nil {
+				summary.LastDeployment = &ServiceDeploymentSummary{
+					Id:           deploymentCommit.CicdDeploymentId,
+					Name:         deploymentCommit.Name,
+					Result:       deploymentCommit.Result,
+					Environment:  deploymentCommit.Environment,
+					FinishedDate: deploymentCommit.FinishedDate,
+				}
+			} else if !db.IsErrorNotFound(err) {
+				return nil, errors.Default.Wrap(err, "error getting DB last deployment commit")
+			}
+			summaries = append(summaries, summary)
+		}
+	}
+	return summaries, nil
+}
+
+// makeServiceSummary fills the project level fields, the dora category comes from the latest health score
+// calculated by the dora plugin before `asOf`
+func makeServiceSummary(projectName string, asOf *time.Time) (*ServiceSummary, errors.Error) {
+	summary := &ServiceSummary{ProjectName: projectName}
+	before := asOfOrNow(asOf)
+	healthScore := &crossdomain.ProjectHealthScore{}
+	err := db.First(
+		healthScore,
+		dal.Where("project_name = ? AND snapshot_date < ?", projectName, before),
+		dal.Orderby("snapshot_date DESC"),
+	)
+	if err == nil {
+		summary.HealthScore = &healthScore.Score
+		summary.DoraCategory = doraCategory(healthScore.DoraScore)
+	} else if !db.IsErrorNotFound(err) {
+		return nil, errors.Default.Wrap(err, "error getting DB project health score")
+	}
+	summary.OpenIncidentCount, err = db.Count(
+		dal.From("issues i"),
+		dal.Join("JOIN board_issues bi ON bi.issue_id = i.id"),
+		dal.Join("JOIN project_mapping pm ON (pm.table = 'boards' AND pm.row_id = bi.board_id)"),
+		dal.Where(
+			`pm.project_name = ? AND i.type = ? AND i.created_date < ? AND (
+				i.resolution_date IS NULL AND i.status != ? OR i.resolution_date >= ?
+			)`,
+			projectName, ticket.INCIDENT, before, ticket.DONE, before,
+		),
+	)
+	if err != nil {
+		return nil, errors.Default.Wrap(err, "error counting DB open incidents")
+	}
+	return summary, nil
+}
+
+// doraCategory maps the dora score (100 elite, 75 high, 50 medium, 25 low) back to the nearest level
+func doraCategory(doraScore *float64) string {
+	switch {
+	case doraScore == nil:
+		return ""
+	case *doraScore >= 87.5:
+		return DORA_ELITE
+	case *doraScore >= 62.5:
+		return DORA_HIGH
+	case *doraScore >= 37.5:
+		return DORA_MEDIUM
+	default:
+		return DORA_LOW
+	}
+}
@@ -0,0 +1,34 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDoraCategory(t *testing.T) {
+	score := func(v float64) *float64 { return &v }
+	assert.Equal(t, "", doraCategory(nil))
+	assert.Equal(t, DORA_ELITE, doraCategory(score(100)))
+	assert.Equal(t, DORA_HIGH, doraCategory(score(87.4)))
+	assert.Equal(t, DORA_HIGH, doraCategory(score(62.5)))
+	assert.Equal(t, DORA_MEDIUM, doraCategory(score(50)))
+	assert.Equal(t, DORA_LOW, doraCategory(score(25)))
+}
@@ -0,0 +1,90 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"sort"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+)
+
+// tokenExpiryCheckInterval is how often the tokens of the connections are checked for expiry
+const tokenExpiryCheckInterval = 24 * time.Hour
+
+// TokenExpiryNotification is sent when the token of a connection is about to expire
+type TokenExpiryNotification struct {
+	Plugin         string
+	ConnectionId   uint64
+	ConnectionName string
+	ExpiresAt      time.Time
+}
+
+// filterExpiringTokens returns the tokens expiring within warningDays from now
+func filterExpiringTokens(pluginName string, expiries []plugin.TokenExpiry, now time.Time, warningDays int) []TokenExpiryNotification {
+	notifications := make([]TokenExpiryNotification, 0)
+	for _, expiry := range expiries {
+		if expiry.ExpiresWithin(now, warningDays) {
+			notifications = append(notifications, TokenExpiryNotification{
+				Plugin:         pluginName,
+				ConnectionId:   expiry.ConnectionId,
+				ConnectionName: expiry.ConnectionName,
+				ExpiresAt:      *expiry.ExpiresAt,
+			})
+		}
+	}
+	return notifications
+}
+
+// checkTokenExpiries warns about the connections whose token is about to expire
+func checkTokenExpiries() {
+	warningDays := cfg.GetInt("TOKEN_EXPIRY_WARNING_DAYS")
+	now := time.Now()
+	checkers := make(map[string]plugin.PluginTokenExpiry)
+	pluginNames := make([]string, 0)
+	for name, pluginMeta := range plugin.AllPlugins() {
+		if checker, ok := pluginMeta.(plugin.PluginTokenExpiry); ok {
+			checkers[name] = checker
+			pluginNames = append(pluginNames, name)
+		}
+	}
+	sort.Strings(pluginNames)
+	for _, name := range pluginNames {
+		expiries, err := checkers[name].GetTokenExpiries()
+		if err != nil {
+			logger.Error(err, "failed to get token expiries of plugin %s", name)
+			continue
+		}
+		for _, n := range filterExpiringTokens(name, expiries, now, warningDays) {
+			logger.Warn(nil, "the token of %s connection %d (%s) expires on %s", n.Plugin, n.ConnectionId, n.ConnectionName, n.ExpiresAt.Format("2006-01-02"))
+			if notificationService == nil {
+				continue
+			}
+			if err := notificationService.TokenExpiring(n); err != nil {
+				logger.Error(err, "failed to send notification: %v", err)
+			}
+		}
+	}
+}
+
+func watchTokenExpiries() {
+	checkTokenExpiries()
+	for range time.Tick(tokenExpiryCheckInterval) {
+		checkTokenExpiries()
+	}
+}
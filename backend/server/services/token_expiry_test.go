@@ -0,0 +1,47 @@
+/*
+Licensed to the Apache Software Foundation (ASF) under one or more
+contributor license agreements.  See the NOTICE file distributed with
+this work for additional information regarding copyright ownership.
+The ASF licenses this file to You under the Apache License, Version 2.0
+(the "License"); you may not use this file except in compliance with
+the License.  You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/apache/incubator-devlake/core/plugin"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFilterExpiringTokens(t *testing.T) {
+	now := time.Date(2023, 12, 20, 0, 0, 0, 0, time.UTC)
+	expired := now.AddDate(0, 0, -1)
+	soon := now.AddDate(0, 0, 3)
+	later := now.AddDate(0, 1, 0)
+	expiries := []plugin.TokenExpiry{
+		{ConnectionId: 1, ConnectionName: "never", ExpiresAt: nil},
+		{ConnectionId: 2, ConnectionName: "expired", ExpiresAt: &expired},
+		{ConnectionId: 3, ConnectionName: "soon", ExpiresAt: &soon},
+		{ConnectionId: 4, ConnectionName: "later", ExpiresAt: &later},
+	}
+
+	assert.Equal(t, []TokenExpiryNotification{
+		{Plugin: "gitlab", ConnectionId: 2, ConnectionName: "expired", ExpiresAt: expired},
+		{Plugin: "gitlab", ConnectionId: 3, ConnectionName: "soon", ExpiresAt: soon},
+	}, filterExpiringTokens("gitlab", expiries, now, 14))
+	assert.Equal(t, []TokenExpiryNotification{
+		{Plugin: "gitlab", ConnectionId: 2, ConnectionName: "expired", ExpiresAt: expired},
+	}, filterExpiringTokens("gitlab", expiries, now, 0))
+}
@@ -23,6 +23,7 @@ export const EntitiesLabel: Record<string, string> = {
   CICD: 'CI/CD',
   CROSS: 'Cross Domain',
   CODEQUALITY: 'Code Quality Domain',
+  SECURITY: 'Security',
 };
 
 export const transformEntities = (entities: string[]) =>